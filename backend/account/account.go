@@ -0,0 +1,138 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrUserExists         = errors.New("user already exists")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidUsername    = errors.New("username must be 3-32 characters of letters, digits, '.', '_' or '-'")
+	ErrWeakPassword       = errors.New("password must be at least 8 characters")
+)
+
+// ProviderLocal 아이디/비밀번호로 가입한 계정
+const ProviderLocal = "local"
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{3,32}$`)
+
+// User 계정 정보
+type User struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	DisplayName  string    `json:"display_name"`
+	PasswordHash string    `json:"password_hash,omitempty"`
+	Role         string    `json:"role,omitempty"` // 계정 수준 역할 (예: moderator), 비어있으면 일반 사용자
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Store 계정 저장소
+type Store interface {
+	Create(ctx context.Context, user *User) error
+	GetByID(ctx context.Context, id string) (*User, error)
+	GetByUsername(ctx context.Context, username string) (*User, error)
+}
+
+// Service 가입/로그인/세션 발급
+type Service struct {
+	store    Store
+	sessions *SessionIssuer
+}
+
+// NewService 생성자
+func NewService(store Store, sessions *SessionIssuer) *Service {
+	return &Service{
+		store:    store,
+		sessions: sessions,
+	}
+}
+
+// Sessions 세션 발급기 (다른 로그인 방식에서 재사용)
+func (s *Service) Sessions() *SessionIssuer {
+	return s.sessions
+}
+
+// Signup 로컬 계정 생성
+func (s *Service) Signup(ctx context.Context, username, password, displayName string) (*User, error) {
+	if !usernamePattern.MatchString(username) {
+		return nil, ErrInvalidUsername
+	}
+	if len(password) < 8 {
+		return nil, ErrWeakPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	if displayName == "" {
+		displayName = username
+	}
+	user := &User{
+		ID:           newID(),
+		Username:     username,
+		DisplayName:  displayName,
+		PasswordHash: string(hash),
+		CreatedAt:    time.Now(),
+	}
+	if err := s.store.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Login 비밀번호 확인 후 세션 토큰 발급
+func (s *Service) Login(ctx context.Context, username, password string) (*User, *Session, error) {
+	user, err := s.store.GetByUsername(ctx, username)
+	if errors.Is(err, ErrUserNotFound) {
+		// 존재하지 않는 계정도 동일한 비용으로 응답해 계정 존재 여부 노출 방지
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	session, err := s.sessions.Issue(PrincipalFromUser(user, ProviderLocal))
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, session, nil
+}
+
+// User 계정 조회
+func (s *Service) User(ctx context.Context, id string) (*User, error) {
+	return s.store.GetByID(ctx, id)
+}
+
+// PrincipalFromUser 계정 정보를 세션 주체로 변환 (LiveKit identity는 username 사용)
+func PrincipalFromUser(user *User, provider string) *Principal {
+	return &Principal{
+		UserID:      user.ID,
+		Identity:    user.Username,
+		DisplayName: user.DisplayName,
+		Role:        user.Role,
+		Provider:    provider,
+	}
+}
+
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,106 @@
+package account
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore 인메모리 계정 저장소 (REDIS_URL 미설정 시 사용)
+type MemoryStore struct {
+	mu         sync.RWMutex
+	users      map[string]*User
+	byUsername map[string]string
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:      make(map[string]*User),
+		byUsername: make(map[string]string),
+	}
+}
+
+func (s *MemoryStore) Create(ctx context.Context, user *User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(user.Username)
+	if _, ok := s.byUsername[key]; ok {
+		return ErrUserExists
+	}
+	u := *user
+	s.users[u.ID] = &u
+	s.byUsername[key] = u.ID
+	return nil
+}
+
+func (s *MemoryStore) GetByID(ctx context.Context, id string) (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[id]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (s *MemoryStore) GetByUsername(ctx context.Context, username string) (*User, error) {
+	s.mu.RLock()
+	id, ok := s.byUsername[strings.ToLower(username)]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return s.GetByID(ctx, id)
+}
+
+// MemoryTicketStore 인메모리 스트림 티켓 저장소
+type MemoryTicketStore struct {
+	mu      sync.Mutex
+	tickets map[string]memoryTicket
+}
+
+type memoryTicket struct {
+	principal Principal
+	expiresAt time.Time
+}
+
+// NewMemoryTicketStore 생성자
+func NewMemoryTicketStore() *MemoryTicketStore {
+	return &MemoryTicketStore{tickets: make(map[string]memoryTicket)}
+}
+
+func (s *MemoryTicketStore) Put(ctx context.Context, id string, p *Principal, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 사용되지 않고 만료된 티켓 정리
+	now := time.Now()
+	for key, ticket := range s.tickets {
+		if !now.Before(ticket.expiresAt) {
+			delete(s.tickets, key)
+		}
+	}
+	s.tickets[id] = memoryTicket{principal: *p, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (s *MemoryTicketStore) Take(ctx context.Context, id string) (*Principal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, ok := s.tickets[id]
+	if !ok {
+		return nil, ErrInvalidTicket
+	}
+	delete(s.tickets, id)
+	if !time.Now().Before(ticket.expiresAt) {
+		return nil, ErrInvalidTicket
+	}
+	p := ticket.principal
+	return &p, nil
+}
@@ -0,0 +1,84 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const principalContextKey = "account.principal"
+
+// RequireAuth Authorization: Bearer <세션 토큰> 검증 미들웨어
+func RequireAuth(sessions *SessionIssuer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// TicketFromQuery로 이미 인증됨
+			if PrincipalFrom(c) != nil {
+				return next(c)
+			}
+			raw := bearerToken(c.Request())
+			if raw == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+			}
+			p, err := sessions.Verify(raw)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired session").SetInternal(err)
+			}
+			c.Set(principalContextKey, p)
+			return next(c)
+		}
+	}
+}
+
+// OptionalAuth 세션 토큰이 있으면 검증해 주체를 저장, 없거나 유효하지 않으면 익명으로 진행
+func OptionalAuth(sessions *SessionIssuer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if raw := bearerToken(c.Request()); raw != "" {
+				if p, err := sessions.Verify(raw); err == nil {
+					c.Set(principalContextKey, p)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// TicketFromQuery 헤더를 붙일 수 없는 EventSource/WebSocket 클라이언트용으로
+// ?ticket= 의 1회용 스트림 티켓을 검증해 주체를 저장하는 미들웨어 (헤더가 있으면 헤더 우선)
+// 세션 토큰은 URL로 받지 않음 (접근 로그에 남지 않도록)
+func TicketFromQuery(tickets *Tickets) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.QueryParam("ticket")
+			if id == "" || bearerToken(c.Request()) != "" {
+				return next(c)
+			}
+			p, err := tickets.Redeem(c.Request().Context(), id)
+			if errors.Is(err, ErrInvalidTicket) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired stream ticket")
+			}
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify stream ticket").SetInternal(err)
+			}
+			c.Set(principalContextKey, p)
+			return next(c)
+		}
+	}
+}
+
+// PrincipalFrom RequireAuth가 저장한 인증 주체 조회 (없으면 nil)
+func PrincipalFrom(c echo.Context) *Principal {
+	p, _ := c.Get(principalContextKey).(*Principal)
+	return p
+}
+
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get(echo.HeaderAuthorization)
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
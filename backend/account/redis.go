@@ -0,0 +1,121 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore Redis 기반 계정 저장소
+//
+//	account:user:<id>          계정 JSON
+//	account:username:<name>    username(소문자) -> id
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func userKey(id string) string {
+	return "account:user:" + id
+}
+
+func usernameKey(username string) string {
+	return "account:username:" + strings.ToLower(username)
+}
+
+func (s *RedisStore) Create(ctx context.Context, user *User) error {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+
+	// username 선점 후 계정 저장 (동시 가입 경쟁 방지)
+	ok, err := s.rdb.SetNX(ctx, usernameKey(user.Username), user.ID, 0).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUserExists
+	}
+	if err := s.rdb.Set(ctx, userKey(user.ID), data, 0).Err(); err != nil {
+		s.rdb.Del(ctx, usernameKey(user.Username))
+		return err
+	}
+	return nil
+}
+
+func (s *RedisStore) GetByID(ctx context.Context, id string) (*User, error) {
+	data, err := s.rdb.Get(ctx, userKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *RedisStore) GetByUsername(ctx context.Context, username string) (*User, error) {
+	id, err := s.rdb.Get(ctx, usernameKey(username)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, id)
+}
+
+// RedisTicketStore Redis 기반 스트림 티켓 저장소 (인스턴스 간 공유)
+//
+//	account:ticket:<id>    주체 JSON (TTL = 티켓 유효 시간)
+type RedisTicketStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisTicketStore 생성자
+func NewRedisTicketStore(rdb *redis.Client) *RedisTicketStore {
+	return &RedisTicketStore{rdb: rdb}
+}
+
+func ticketKey(id string) string {
+	return "account:ticket:" + id
+}
+
+func (s *RedisTicketStore) Put(ctx context.Context, id string, p *Principal, ttl time.Duration) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, ticketKey(id), data, ttl).Err()
+}
+
+func (s *RedisTicketStore) Take(ctx context.Context, id string) (*Principal, error) {
+	// GETDEL로 꺼내면서 삭제 (동시에 사용해도 한 요청만 성공)
+	data, err := s.rdb.GetDel(ctx, ticketKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidTicket
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var p Principal
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
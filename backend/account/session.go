@@ -0,0 +1,88 @@
+package account
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+)
+
+const sessionIssuer = "livekit-echosystem"
+
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// Principal 인증된 사용자 (세션 토큰에서 복원)
+type Principal struct {
+	UserID      string `json:"uid"`
+	Identity    string `json:"identity"` // LiveKit 참가자 identity
+	DisplayName string `json:"name,omitempty"`
+	Role        string `json:"role,omitempty"`
+	Provider    string `json:"provider,omitempty"`
+}
+
+// Session 발급된 세션 토큰
+type Session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SessionIssuer 세션 JWT 발급/검증 (HS256)
+type SessionIssuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSessionIssuer 생성자
+func NewSessionIssuer(secret string, ttl time.Duration) *SessionIssuer {
+	return &SessionIssuer{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+// Issue 세션 토큰 발급
+func (s *SessionIssuer) Issue(p *Principal) (*Session, error) {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: s.secret},
+		(&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	claims := jwt.Claims{
+		Issuer:    sessionIssuer,
+		Subject:   p.UserID,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(expiresAt),
+	}
+
+	token, err := jwt.Signed(signer).Claims(claims).Claims(p).CompactSerialize()
+	if err != nil {
+		return nil, err
+	}
+	return &Session{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// Verify 세션 토큰 검증 후 주체 반환
+func (s *SessionIssuer) Verify(raw string) (*Principal, error) {
+	tok, err := jwt.ParseSigned(raw)
+	if err != nil {
+		return nil, ErrInvalidSession
+	}
+
+	claims := jwt.Claims{}
+	p := &Principal{}
+	if err := tok.Claims(s.secret, &claims, p); err != nil {
+		return nil, ErrInvalidSession
+	}
+	if err := claims.Validate(jwt.Expected{Issuer: sessionIssuer, Time: time.Now()}); err != nil {
+		return nil, ErrInvalidSession
+	}
+	if p.Identity == "" || claims.Subject != p.UserID {
+		return nil, ErrInvalidSession
+	}
+	return p, nil
+}
@@ -0,0 +1,58 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidTicket = errors.New("invalid or expired stream ticket")
+
+// DefaultTicketTTL 스트림 티켓 유효 시간 (발급 직후 SSE/WebSocket 연결에 한 번 사용)
+const DefaultTicketTTL = 30 * time.Second
+
+// Ticket 쿼리 문자열 인증용 1회용 티켓
+// 세션 토큰은 URL(접근 로그, 브라우저 기록)에 남기지 않고 대신 이 티켓을 ?ticket=으로 전달
+type Ticket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TicketStore 티켓 저장소
+type TicketStore interface {
+	Put(ctx context.Context, id string, p *Principal, ttl time.Duration) error
+	// Take 티켓을 꺼내면서 삭제 (없거나 만료되었으면 ErrInvalidTicket, 한 번만 성공)
+	Take(ctx context.Context, id string) (*Principal, error)
+}
+
+// Tickets 스트림 티켓 발급/사용
+type Tickets struct {
+	store TicketStore
+	ttl   time.Duration
+}
+
+// NewTickets 생성자
+func NewTickets(store TicketStore, ttl time.Duration) *Tickets {
+	return &Tickets{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Issue 인증된 주체로 티켓 발급
+func (t *Tickets) Issue(ctx context.Context, p *Principal) (*Ticket, error) {
+	id := newID()
+	expiresAt := time.Now().Add(t.ttl)
+	if err := t.store.Put(ctx, id, p, t.ttl); err != nil {
+		return nil, err
+	}
+	return &Ticket{Ticket: id, ExpiresAt: expiresAt}, nil
+}
+
+// Redeem 티켓 사용 (한 번 사용하면 다시 쓸 수 없음)
+func (t *Tickets) Redeem(ctx context.Context, id string) (*Principal, error) {
+	if id == "" {
+		return nil, ErrInvalidTicket
+	}
+	return t.store.Take(ctx, id)
+}
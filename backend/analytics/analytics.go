@@ -0,0 +1,256 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"backend/catalog"
+	"backend/lifecycle"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+)
+
+// Kind 기록하는 이벤트 종류
+type Kind string
+
+const (
+	KindJoin  Kind = "join"
+	KindLeave Kind = "leave"
+	KindEnd   Kind = "end" // 룸 종료 (남아 있던 시청자의 시청 종료)
+)
+
+// Event 시청자 입장/퇴장 기록 (호스트와 인그레스는 기록하지 않음)
+type Event struct {
+	Kind     Kind      `json:"kind"`
+	Identity string    `json:"identity,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// Store 스트림별 시청 집계 저장소 (이벤트를 받을 때마다 집계만 갱신, 스트림이 끝난 뒤에도 보관)
+type Store interface {
+	// Record 이벤트를 집계에 반영 (웹훅이 도착한 순서대로)
+	Record(ctx context.Context, streamKey string, event Event) error
+	// Load 집계 조회 (기록이 없으면 빈 집계)
+	Load(ctx context.Context, streamKey string) (*Aggregate, error)
+}
+
+// 동시 시청자 집계 단위 (시계열 구간은 이 배수로 맞춤)
+const bucketSize = 10 * time.Second
+
+// Bucket 집계 단위 하나의 동시 시청자 수
+type Bucket struct {
+	Max  int // 구간 안의 최대 (시작 시점의 시청자 포함)
+	Last int // 구간의 마지막 이벤트 후
+}
+
+// Aggregate 스트림 시청 집계 - 크기는 이벤트 수가 아니라 고유 시청자 수와 방송 시간에 비례
+// 같은 identity가 여러 번 입장해도 동시 시청자는 한 명으로 센다.
+type Aggregate struct {
+	Sessions      int // 입장 횟수 (재접속 포함)
+	UniqueViewers int
+	Watched       time.Duration // 나간 시청자의 누적 시청 시간
+	PeakViewers   int
+	PeakAt        time.Time
+	Watching      map[string]time.Time // 시청 중인 identity -> 입장 시각
+	Buckets       map[int64]Bucket     // 이벤트가 있었던 집계 단위 (시작 unix 초)
+}
+
+// NewAggregate 빈 집계
+func NewAggregate() *Aggregate {
+	return &Aggregate{Watching: map[string]time.Time{}, Buckets: map[int64]Bucket{}}
+}
+
+// Apply 이벤트 반영 (firstVisit: 처음 입장한 identity인지, MemoryStore용 - RedisStore는 같은 계산을 스크립트로 수행)
+func (a *Aggregate) Apply(event Event, firstVisit bool) {
+	before := len(a.Watching)
+	leave := func(joinedAt time.Time) {
+		if watched := event.At.Sub(joinedAt); watched > 0 {
+			a.Watched += watched
+		}
+	}
+	switch event.Kind {
+	case KindJoin:
+		a.Sessions++
+		if firstVisit {
+			a.UniqueViewers++
+		}
+		if _, ok := a.Watching[event.Identity]; !ok {
+			a.Watching[event.Identity] = event.At
+		}
+	case KindLeave:
+		if joinedAt, ok := a.Watching[event.Identity]; ok {
+			leave(joinedAt)
+			delete(a.Watching, event.Identity)
+		}
+	case KindEnd:
+		for identity, joinedAt := range a.Watching {
+			leave(joinedAt)
+			delete(a.Watching, identity)
+		}
+	}
+
+	// 구간의 첫 이벤트면 시작 시점의 시청자 수를 포함, 구간 시작 시각의 이벤트는 그 값을 대체 (그 순간에만 있던 시청자는 세지 않음)
+	n := len(a.Watching)
+	start := event.At.Truncate(bucketSize)
+	bucket, ok := a.Buckets[start.Unix()]
+	if !ok {
+		bucket.Max = before
+	}
+	if event.At.Equal(start) || n > bucket.Max {
+		bucket.Max = n
+	}
+	bucket.Last = n
+	a.Buckets[start.Unix()] = bucket
+	if n > a.PeakViewers {
+		a.PeakViewers, a.PeakAt = n, event.At
+	}
+}
+
+// Point 시계열 한 구간의 최대 동시 시청자 수
+type Point struct {
+	At      time.Time `json:"at"` // 구간 시작
+	Viewers int       `json:"viewers"`
+}
+
+// Report 스트림 시청 통계
+type Report struct {
+	PeakViewers         int        `json:"peak_viewers"`
+	PeakAt              *time.Time `json:"peak_at,omitempty"`
+	UniqueViewers       int        `json:"unique_viewers"`
+	Sessions            int        `json:"sessions"` // 입장 횟수 (재접속 포함)
+	TotalWatchSeconds   int64      `json:"total_watch_seconds"`
+	AverageWatchSeconds int64      `json:"average_watch_seconds"` // 시청자 1명당 평균 시청 시간
+	CurrentViewers      int        `json:"current_viewers"`
+	Series              []Point    `json:"series"`
+	Interval            int64      `json:"interval"` // 시계열 구간 (초)
+}
+
+// 시계열 최대 구간 수 (넘으면 구간을 넓힘)
+const maxPoints = 1440
+
+// Summarize 집계로 통계 계산
+// 아직 나가지 않은 시청자는 until(종료 시각 또는 현재)까지 시청한 것으로 보고,
+// 이벤트가 없는 구간은 그 시점의 시청자 수를 이어받는다.
+func Summarize(a *Aggregate, until time.Time, interval time.Duration) Report {
+	interval = max((interval+bucketSize-1)/bucketSize*bucketSize, bucketSize)
+	report := Report{
+		PeakViewers:    a.PeakViewers,
+		UniqueViewers:  a.UniqueViewers,
+		Sessions:       a.Sessions,
+		CurrentViewers: len(a.Watching),
+		Series:         []Point{},
+	}
+	if a.PeakViewers > 0 {
+		peakAt := a.PeakAt
+		report.PeakAt = &peakAt
+	}
+	watched := a.Watched
+	for _, joinedAt := range a.Watching {
+		if until.After(joinedAt) {
+			watched += until.Sub(joinedAt)
+		}
+	}
+	report.TotalWatchSeconds = int64(watched.Seconds())
+	if report.UniqueViewers > 0 {
+		report.AverageWatchSeconds = report.TotalWatchSeconds / int64(report.UniqueViewers)
+	}
+
+	first := int64(math.MaxInt64)
+	for start := range a.Buckets {
+		first = min(first, start)
+	}
+	start := time.Unix(first, 0)
+	if len(a.Buckets) == 0 || start.After(until) {
+		report.Interval = int64(interval.Seconds())
+		return report
+	}
+	if span := until.Sub(start); span/interval >= maxPoints {
+		interval = (span/maxPoints + time.Minute).Truncate(time.Minute)
+	}
+	start = start.Truncate(interval)
+	report.Interval = int64(interval.Seconds())
+
+	last := 0 // 직전 집계 단위가 끝난 시점의 시청자 수
+	for at := start; !at.After(until); at = at.Add(interval) {
+		viewers := 0
+		for b := at; b.Before(at.Add(interval)) && !b.After(until); b = b.Add(bucketSize) {
+			v := last
+			if bucket, ok := a.Buckets[b.Unix()]; ok {
+				v, last = bucket.Max, bucket.Last
+			}
+			viewers = max(viewers, v)
+		}
+		report.Series = append(report.Series, Point{At: at, Viewers: viewers})
+	}
+	return report
+}
+
+// Recorder 웹훅 구독자 - 카탈로그 스트림의 시청자 입장/퇴장과 룸 종료 집계
+type Recorder struct {
+	store   Store
+	streams catalog.Repository
+}
+
+// NewRecorder 생성자
+func NewRecorder(store Store, streams catalog.Repository) *Recorder {
+	return &Recorder{store: store, streams: streams}
+}
+
+// streamKey 스트림 기록마다 고유한 집계 키 (같은 룸 이름을 다시 써도 통계가 섞이지 않도록 생성 시각 포함)
+func streamKey(stream *catalog.Stream) string {
+	return stream.RoomId + ":" + strconv.FormatInt(stream.CreatedAt.UnixMilli(), 10)
+}
+
+// HandleWebhook participant_joined, participant_left, room_finished 기록 (카탈로그에 없는 회의 룸은 무시)
+func (r *Recorder) HandleWebhook(ctx context.Context, event *livekit.WebhookEvent) error {
+	if event.Room == nil {
+		return nil
+	}
+	stream, err := r.streams.Get(ctx, event.Room.Name)
+	if errors.Is(err, catalog.ErrStreamNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	at := time.Now()
+	if event.CreatedAt > 0 {
+		at = time.Unix(event.CreatedAt, 0)
+	}
+
+	record := Event{At: at}
+	switch event.Event {
+	case webhook.EventParticipantJoined, webhook.EventParticipantLeft:
+		participant := event.GetParticipant()
+		// 종료된 스트림의 룸 이름으로 열린 회의는 기록하지 않음
+		if participant == nil || stream.Ended() {
+			return nil
+		}
+		// 호스트(생성자, 인그레스)와 에이전트(채팅 기록 봇 등)는 시청자가 아님
+		if participant.Kind == livekit.ParticipantInfo_AGENT || lifecycle.IsHost(stream, lifecycle.Event{Identity: participant.Identity, Ingress: participant.Kind == livekit.ParticipantInfo_INGRESS}) {
+			return nil
+		}
+		record.Kind, record.Identity = KindJoin, participant.Identity
+		if event.Event == webhook.EventParticipantLeft {
+			record.Kind = KindLeave
+		}
+	case webhook.EventRoomFinished:
+		record.Kind = KindEnd
+	default:
+		return nil
+	}
+	return r.store.Record(ctx, streamKey(stream), record)
+}
+
+// Report 스트림 통계 (until 이후 구간은 제외)
+func (r *Recorder) Report(ctx context.Context, stream *catalog.Stream, until time.Time, interval time.Duration) (Report, error) {
+	aggregate, err := r.store.Load(ctx, streamKey(stream))
+	if err != nil {
+		return Report{}, err
+	}
+	return Summarize(aggregate, until, interval), nil
+}
@@ -0,0 +1,55 @@
+package analytics
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// memoryStream 스트림 하나의 집계와 고유 시청자
+type memoryStream struct {
+	aggregate *Aggregate
+	visited   map[string]bool
+}
+
+// MemoryStore 인메모리 집계 저장소 (단일 인스턴스/테스트용, 재시작 시 사라짐)
+type MemoryStore struct {
+	mu      sync.RWMutex
+	streams map[string]*memoryStream
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{streams: map[string]*memoryStream{}}
+}
+
+func (s *MemoryStore) Record(ctx context.Context, streamKey string, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stream, ok := s.streams[streamKey]
+	if !ok {
+		stream = &memoryStream{aggregate: NewAggregate(), visited: map[string]bool{}}
+		s.streams[streamKey] = stream
+	}
+	firstVisit := event.Kind == KindJoin && !stream.visited[event.Identity]
+	if firstVisit {
+		stream.visited[event.Identity] = true
+	}
+	stream.aggregate.Apply(event, firstVisit)
+	return nil
+}
+
+func (s *MemoryStore) Load(ctx context.Context, streamKey string) (*Aggregate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stream, ok := s.streams[streamKey]
+	if !ok {
+		return NewAggregate(), nil
+	}
+	copied := *stream.aggregate
+	copied.Watching = maps.Clone(stream.aggregate.Watching)
+	copied.Buckets = maps.Clone(stream.aggregate.Buckets)
+	return &copied, nil
+}
@@ -0,0 +1,137 @@
+package analytics
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 마지막 이벤트 후 통계를 보관하는 기간
+const aggregateRetention = 90 * 24 * time.Hour
+
+// recordScript 이벤트를 집계에 반영 (Aggregate.Apply와 같은 계산)
+//
+//	KEYS[1]  analytics:<stream>:stats     hash (sessions, watched(ms), peak, peak_at(ms))
+//	KEYS[2]  analytics:<stream>:watching  hash (identity -> 입장 시각 ms)
+//	KEYS[3]  analytics:<stream>:viewers   set (고유 시청자)
+//	KEYS[4]  analytics:<stream>:buckets   hash (<집계 단위 시작 unix 초>:max|last -> 시청자 수)
+//	ARGV     kind, identity, at(ms), bucket, exact(구간 시작 시각이면 1), retention(ms)
+var recordScript = redis.NewScript(`
+local at = tonumber(ARGV[3])
+local before = redis.call('HLEN', KEYS[2])
+local function leave(joined)
+  local watched = at - tonumber(joined)
+  if watched > 0 then
+    redis.call('HINCRBY', KEYS[1], 'watched', watched)
+  end
+end
+if ARGV[1] == 'join' then
+  redis.call('HINCRBY', KEYS[1], 'sessions', 1)
+  redis.call('SADD', KEYS[3], ARGV[2])
+  redis.call('HSETNX', KEYS[2], ARGV[2], ARGV[3])
+elseif ARGV[1] == 'leave' then
+  local joined = redis.call('HGET', KEYS[2], ARGV[2])
+  if joined then
+    leave(joined)
+    redis.call('HDEL', KEYS[2], ARGV[2])
+  end
+else
+  for _, joined in ipairs(redis.call('HVALS', KEYS[2])) do
+    leave(joined)
+  end
+  redis.call('DEL', KEYS[2])
+end
+local n = redis.call('HLEN', KEYS[2])
+local max = tonumber(redis.call('HGET', KEYS[4], ARGV[4] .. ':max') or before)
+if ARGV[5] == '1' or n > max then
+  max = n
+end
+redis.call('HSET', KEYS[4], ARGV[4] .. ':max', max, ARGV[4] .. ':last', n)
+if n > tonumber(redis.call('HGET', KEYS[1], 'peak') or 0) then
+  redis.call('HSET', KEYS[1], 'peak', n, 'peak_at', ARGV[3])
+end
+for _, key in ipairs(KEYS) do
+  redis.call('PEXPIRE', key, ARGV[6])
+end
+return n
+`)
+
+// RedisStore Redis 기반 집계 저장소 (analytics:<stream>:*, 인스턴스 간 공유)
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func aggregateKeys(streamKey string) []string {
+	prefix := "analytics:" + streamKey
+	return []string{prefix + ":stats", prefix + ":watching", prefix + ":viewers", prefix + ":buckets"}
+}
+
+func (s *RedisStore) Record(ctx context.Context, streamKey string, event Event) error {
+	start := event.At.Truncate(bucketSize)
+	exact := "0"
+	if event.At.Equal(start) {
+		exact = "1"
+	}
+	return recordScript.Run(ctx, s.rdb, aggregateKeys(streamKey),
+		string(event.Kind), event.Identity, event.At.UnixMilli(), start.Unix(), exact, aggregateRetention.Milliseconds()).Err()
+}
+
+func (s *RedisStore) Load(ctx context.Context, streamKey string) (*Aggregate, error) {
+	keys := aggregateKeys(streamKey)
+	var (
+		stats    *redis.MapStringStringCmd
+		watching *redis.MapStringStringCmd
+		viewers  *redis.IntCmd
+		buckets  *redis.MapStringStringCmd
+	)
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		stats = pipe.HGetAll(ctx, keys[0])
+		watching = pipe.HGetAll(ctx, keys[1])
+		viewers = pipe.SCard(ctx, keys[2])
+		buckets = pipe.HGetAll(ctx, keys[3])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a := NewAggregate()
+	values := stats.Val()
+	a.Sessions, _ = strconv.Atoi(values["sessions"])
+	a.PeakViewers, _ = strconv.Atoi(values["peak"])
+	watched, _ := strconv.ParseInt(values["watched"], 10, 64)
+	a.Watched = time.Duration(watched) * time.Millisecond
+	if peakAt, err := strconv.ParseInt(values["peak_at"], 10, 64); err == nil {
+		a.PeakAt = time.UnixMilli(peakAt)
+	}
+	a.UniqueViewers = int(viewers.Val())
+	for identity, value := range watching.Val() {
+		if joinedAt, err := strconv.ParseInt(value, 10, 64); err == nil {
+			a.Watching[identity] = time.UnixMilli(joinedAt)
+		}
+	}
+	for field, value := range buckets.Val() {
+		start, kind, ok := strings.Cut(field, ":")
+		unix, err := strconv.ParseInt(start, 10, 64)
+		n, nerr := strconv.Atoi(value)
+		if !ok || err != nil || nerr != nil {
+			continue
+		}
+		bucket := a.Buckets[unix]
+		if kind == "max" {
+			bucket.Max = n
+		} else {
+			bucket.Last = n
+		}
+		a.Buckets[unix] = bucket
+	}
+	return a, nil
+}
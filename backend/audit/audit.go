@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// 룸당 보관하는 최대 기록 수 (오래된 기록부터 삭제)
+const maxEntries = 1000
+
+// Action 관리 작업 종류
+type Action string
+
+const (
+	ActionMute        Action = "participant.mute"
+	ActionUnmute      Action = "participant.unmute"
+	ActionRemove      Action = "participant.remove"
+	ActionPermissions Action = "participant.permissions"
+	ActionPromote     Action = "stage.promote" // 무대 요청 승인
+	ActionDeny        Action = "stage.deny"
+	ActionDemote      Action = "stage.demote"
+	ActionChatMute    Action = "chat.mute" // 채팅 관리 봇의 일시 채팅 금지
+)
+
+// Entry 관리 작업 기록
+type Entry struct {
+	ID      string                 `json:"id"`
+	RoomId  string                 `json:"room_id"`
+	Actor   string                 `json:"actor"` // 작업자 identity (관리자 API 키는 "admin")
+	Action  Action                 `json:"action"`
+	Target  string                 `json:"target"` // 대상 참가자 identity
+	Reason  string                 `json:"reason,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	At      time.Time              `json:"at"`
+}
+
+// Store 룸별 관리 기록 저장소
+type Store interface {
+	Append(ctx context.Context, entry *Entry) error
+	// List 최신순 (limit <= 0이면 전체)
+	List(ctx context.Context, roomId string, limit int) ([]*Entry, error)
+}
+
+// Log 관리 작업 기록
+type Log struct {
+	store Store
+}
+
+// NewLog 생성자
+func NewLog(store Store) *Log {
+	return &Log{store: store}
+}
+
+// Record ID와 시각을 채워 저장
+func (l *Log) Record(ctx context.Context, entry *Entry) error {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	entry.ID = hex.EncodeToString(id)
+	entry.At = time.Now()
+	return l.store.Append(ctx, entry)
+}
+
+// List 룸의 관리 기록 (최신순)
+func (l *Log) List(ctx context.Context, roomId string, limit int) ([]*Entry, error) {
+	return l.store.List(ctx, roomId, limit)
+}
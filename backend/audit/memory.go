@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore 인메모리 관리 기록 저장소 (단일 인스턴스/테스트용)
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries map[string][]*Entry // 오래된 순
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string][]*Entry{}}
+}
+
+func (s *MemoryStore) Append(ctx context.Context, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := append(s.entries[entry.RoomId], entry)
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+	s.entries[entry.RoomId] = entries
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, roomId string, limit int) ([]*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := s.entries[roomId]
+	if limit <= 0 || limit > len(entries) {
+		limit = len(entries)
+	}
+	result := make([]*Entry, 0, limit)
+	for i := len(entries) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, entries[i])
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 마지막 기록 후 보관 기간
+const entryRetention = 90 * 24 * time.Hour
+
+// RedisStore Redis 기반 관리 기록 저장소 (audit:<room> 리스트, 최신이 앞)
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func entriesKey(roomId string) string {
+	return "audit:" + roomId
+}
+
+func (s *RedisStore) Append(ctx context.Context, entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	key := entriesKey(entry.RoomId)
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, data)
+		pipe.LTrim(ctx, key, 0, maxEntries-1)
+		pipe.Expire(ctx, key, entryRetention)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) List(ctx context.Context, roomId string, limit int) ([]*Entry, error) {
+	values, err := s.rdb.LRange(ctx, entriesKey(roomId), 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*Entry, 0, len(values))
+	for _, value := range values {
+		var entry Entry
+		if err := json.Unmarshal([]byte(value), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
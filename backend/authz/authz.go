@@ -0,0 +1,95 @@
+package authz
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+
+	"backend/account"
+	"backend/policy"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAdminKey 관리자 API 키 헤더
+const HeaderAdminKey = "X-Admin-Key"
+
+const actorContextKey = "authz.actor"
+
+// Actor 요청 주체 (관리자 API 키 보유자 또는 로그인 사용자)
+type Actor struct {
+	Admin     bool
+	Principal *account.Principal
+}
+
+// IsModerator 운영자 계정 여부
+func (a *Actor) IsModerator() bool {
+	return a.Principal != nil && policy.Role(a.Principal.Role) == policy.RoleModerator
+}
+
+// CanManage 룸 생성자, 운영자 계정, 관리자 키 보유자만 관리 가능
+func (a *Actor) CanManage(creatorIdentity string) bool {
+	if a.Admin || a.IsModerator() {
+		return true
+	}
+	return a.Principal != nil && creatorIdentity != "" && a.Principal.Identity == creatorIdentity
+}
+
+// AdminKeys 관리자 API 키 목록 (해시로 보관, 상수 시간 비교)
+type AdminKeys struct {
+	digests [][sha256.Size]byte
+}
+
+// NewAdminKeys 생성자 - 빈 문자열은 무시
+func NewAdminKeys(keys []string) *AdminKeys {
+	ak := &AdminKeys{}
+	for _, k := range keys {
+		if k != "" {
+			ak.digests = append(ak.digests, sha256.Sum256([]byte(k)))
+		}
+	}
+	return ak
+}
+
+// Valid 키 일치 여부
+func (ak *AdminKeys) Valid(key string) bool {
+	digest := sha256.Sum256([]byte(key))
+	valid := 0
+	for _, d := range ak.digests {
+		valid |= subtle.ConstantTimeCompare(d[:], digest[:])
+	}
+	return valid == 1
+}
+
+// RequireActor 관리자 API 키(X-Admin-Key) 또는 로그인 세션 필요
+// 소유권 확인은 대상 리소스를 조회한 뒤 핸들러에서 CanManage로 수행
+func RequireActor(sessions *account.SessionIssuer, keys *AdminKeys) echo.MiddlewareFunc {
+	requireAuth := account.RequireAuth(sessions)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withSession := requireAuth(func(c echo.Context) error {
+			c.Set(actorContextKey, &Actor{Principal: account.PrincipalFrom(c)})
+			return next(c)
+		})
+		return func(c echo.Context) error {
+			if key := c.Request().Header.Get(HeaderAdminKey); key != "" {
+				if !keys.Valid(key) {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid admin API key")
+				}
+				c.Set(actorContextKey, &Actor{Admin: true})
+				return next(c)
+			}
+			return withSession(c)
+		}
+	}
+}
+
+// ActorFrom RequireActor가 저장한 요청 주체 조회 (없으면 nil)
+func ActorFrom(c echo.Context) *Actor {
+	a, _ := c.Get(actorContextKey).(*Actor)
+	return a
+}
+
+// ErrForbidden 권한 없음 응답
+func ErrForbidden() *echo.HTTPError {
+	return echo.NewHTTPError(http.StatusForbidden, "Only the stream creator, moderators or admins can perform this action")
+}
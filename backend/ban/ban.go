@@ -0,0 +1,180 @@
+package ban
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+var (
+	ErrBanNotFound    = errors.New("ban not found")
+	ErrInvalidSubject = errors.New("invalid ban subject")
+)
+
+// Kind 차단 대상 종류
+type Kind string
+
+const (
+	KindIdentity Kind = "identity" // LiveKit 참가자 identity
+	KindAccount  Kind = "account"  // 계정 ID (identity를 바꿔도 차단 유지)
+	KindIP       Kind = "ip"
+)
+
+// Ban 차단 정보 (RoomId가 비어 있으면 전체 차단)
+type Ban struct {
+	ID        string     `json:"id"`
+	RoomId    string     `json:"room_id,omitempty"`
+	Kind      Kind       `json:"kind"`
+	Value     string     `json:"value"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // nil이면 영구 차단
+}
+
+// Global 전체 차단 여부
+func (b *Ban) Global() bool {
+	return b.RoomId == ""
+}
+
+// Active 만료되지 않은 차단 여부
+func (b *Ban) Active(now time.Time) bool {
+	return b.ExpiresAt == nil || now.Before(*b.ExpiresAt)
+}
+
+// Subject 참가 요청자 (차단 여부 확인 대상)
+type Subject struct {
+	Identity string
+	UserID   string
+	IP       string
+}
+
+// Store 차단 저장소
+// 같은 범위(룸/전체)의 같은 대상에 대한 차단은 하나만 유지 (Save 시 교체)
+type Store interface {
+	Save(ctx context.Context, b *Ban) error
+	Get(ctx context.Context, id string) (*Ban, error)
+	Delete(ctx context.Context, id string) error
+	// List 룸 차단 목록 (roomId가 비어 있으면 전체 차단 목록)
+	List(ctx context.Context, roomId string) ([]*Ban, error)
+	// Match 해당 범위에서 대상에 걸린 유효한 차단 (없으면 nil)
+	Match(ctx context.Context, roomId string, kind Kind, value string) (*Ban, error)
+}
+
+// Service 차단 등록/해제/확인
+type Service struct {
+	store Store
+}
+
+// NewService 생성자
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Ban 차단 등록 (ttl이 0이면 영구)
+func (s *Service) Ban(ctx context.Context, roomId string, kind Kind, value, reason, createdBy string, ttl time.Duration) (*Ban, error) {
+	value, err := normalize(kind, value)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	b := &Ban{
+		ID:        newID(),
+		RoomId:    roomId,
+		Kind:      kind,
+		Value:     value,
+		Reason:    reason,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+	}
+	if ttl > 0 {
+		expiresAt := now.Add(ttl)
+		b.ExpiresAt = &expiresAt
+	}
+
+	if err := s.store.Save(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Unban 차단 해제 (다른 범위의 차단은 해제할 수 없음)
+func (s *Service) Unban(ctx context.Context, roomId, id string) error {
+	b, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if b.RoomId != roomId {
+		return ErrBanNotFound
+	}
+	return s.store.Delete(ctx, id)
+}
+
+// List 룸(또는 전체) 차단 목록
+func (s *Service) List(ctx context.Context, roomId string) ([]*Ban, error) {
+	return s.store.List(ctx, roomId)
+}
+
+// Check 룸 참가 시 차단 확인 (전체 차단 우선, 차단되지 않았으면 nil)
+func (s *Service) Check(ctx context.Context, roomId string, subject Subject) (*Ban, error) {
+	candidates := []struct {
+		kind  Kind
+		value string
+	}{
+		{KindIdentity, subject.Identity},
+		{KindAccount, subject.UserID},
+		{KindIP, subject.IP},
+	}
+
+	for _, scope := range []string{"", roomId} {
+		for _, c := range candidates {
+			if c.value == "" {
+				continue
+			}
+			value, err := normalize(c.kind, c.value)
+			if err != nil {
+				continue
+			}
+			b, err := s.store.Match(ctx, scope, c.kind, value)
+			if err != nil {
+				return nil, err
+			}
+			if b != nil {
+				return b, nil
+			}
+		}
+		if roomId == "" {
+			break
+		}
+	}
+	return nil, nil
+}
+
+// normalize 대상 값 검증 및 정규화 (IP는 표준 표기로)
+func normalize(kind Kind, value string) (string, error) {
+	if value == "" {
+		return "", ErrInvalidSubject
+	}
+	switch kind {
+	case KindIdentity, KindAccount:
+		return value, nil
+	case KindIP:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return "", fmt.Errorf("%w: invalid ip %q", ErrInvalidSubject, value)
+		}
+		return ip.String(), nil
+	}
+	return "", fmt.Errorf("%w: unknown kind %q", ErrInvalidSubject, kind)
+}
+
+func newID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
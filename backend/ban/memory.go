@@ -0,0 +1,90 @@
+package ban
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore 인메모리 차단 저장소
+type MemoryStore struct {
+	mu   sync.Mutex
+	bans map[string]*Ban
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{bans: make(map[string]*Ban)}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, b *Ban) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, existing := range s.bans {
+		if existing.RoomId == b.RoomId && existing.Kind == b.Kind && existing.Value == b.Value {
+			delete(s.bans, id)
+		}
+	}
+	copied := *b
+	s.bans[b.ID] = &copied
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Ban, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.bans[id]
+	if !ok || !b.Active(time.Now()) {
+		return nil, ErrBanNotFound
+	}
+	copied := *b
+	return &copied, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.bans[id]; !ok {
+		return ErrBanNotFound
+	}
+	delete(s.bans, id)
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, roomId string) ([]*Ban, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var list []*Ban
+	for id, b := range s.bans {
+		if !b.Active(now) {
+			delete(s.bans, id)
+			continue
+		}
+		if b.RoomId == roomId {
+			copied := *b
+			list = append(list, &copied)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (s *MemoryStore) Match(ctx context.Context, roomId string, kind Kind, value string) (*Ban, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, b := range s.bans {
+		if b.RoomId == roomId && b.Kind == kind && b.Value == value && b.Active(now) {
+			copied := *b
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,144 @@
+package ban
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore Redis 기반 차단 저장소 (만료는 키 TTL로 처리)
+//
+//	ban:<id>                          차단 정보 (JSON)
+//	ban:match:<scope>:<kind>:<value>  대상 → 차단 id
+//	ban:list:<scope>                  범위별 차단 id 집합
+//
+// scope는 룸 차단이면 room:<room_id>, 전체 차단이면 global
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func scopeKey(roomId string) string {
+	if roomId == "" {
+		return "global"
+	}
+	return "room:" + roomId
+}
+
+func banKey(id string) string {
+	return "ban:" + id
+}
+
+func matchKey(roomId string, kind Kind, value string) string {
+	return "ban:match:" + scopeKey(roomId) + ":" + string(kind) + ":" + value
+}
+
+func listKey(roomId string) string {
+	return "ban:list:" + scopeKey(roomId)
+}
+
+func (s *RedisStore) Save(ctx context.Context, b *Ban) error {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	var ttl time.Duration // 0이면 만료 없음
+	if b.ExpiresAt != nil {
+		ttl = time.Until(*b.ExpiresAt)
+	}
+
+	// 같은 대상의 기존 차단은 교체
+	previous, err := s.rdb.Get(ctx, matchKey(b.RoomId, b.Kind, b.Value)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if previous != "" {
+			pipe.Del(ctx, banKey(previous))
+			pipe.SRem(ctx, listKey(b.RoomId), previous)
+		}
+		pipe.Set(ctx, banKey(b.ID), data, ttl)
+		pipe.Set(ctx, matchKey(b.RoomId, b.Kind, b.Value), b.ID, ttl)
+		pipe.SAdd(ctx, listKey(b.RoomId), b.ID)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*Ban, error) {
+	data, err := s.rdb.Get(ctx, banKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrBanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b Ban
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	b, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, banKey(id))
+		pipe.Del(ctx, matchKey(b.RoomId, b.Kind, b.Value))
+		pipe.SRem(ctx, listKey(b.RoomId), id)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) List(ctx context.Context, roomId string) ([]*Ban, error) {
+	ids, err := s.rdb.SMembers(ctx, listKey(roomId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*Ban
+	for _, id := range ids {
+		b, err := s.Get(ctx, id)
+		if errors.Is(err, ErrBanNotFound) {
+			s.rdb.SRem(ctx, listKey(roomId), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, b)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (s *RedisStore) Match(ctx context.Context, roomId string, kind Kind, value string) (*Ban, error) {
+	id, err := s.rdb.Get(ctx, matchKey(roomId, kind, value)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.Get(ctx, id)
+	if errors.Is(err, ErrBanNotFound) {
+		return nil, nil
+	}
+	return b, err
+}
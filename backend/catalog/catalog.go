@@ -0,0 +1,173 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+var (
+	ErrStreamNotFound  = errors.New("stream not found")
+	ErrStreamExists    = errors.New("stream already exists") // Create: 같은 room_id의 기록이 이미 있음
+	ErrUnchanged       = errors.New("stream unchanged")      // Update 함수가 반환하면 저장하지 않음
+	ErrVersionConflict = errors.New("stream metadata was modified")
+)
+
+// 시청자 정원과 별도로 LiveKit 룸에 남겨 두는 자리 (호스트, 인그레스, 운영자, 채팅 기록 봇)
+const StaffSeats = 4
+
+// State 스트림 상태
+type State string
+
+const (
+	StateScheduled      State = "scheduled"        // 예약됨 (룸 생성 전)
+	StateWaitingForHost State = "waiting-for-host" // 룸은 열렸지만 호스트가 아직 송출하지 않음
+	StateLive           State = "live"             // 호스트(또는 인그레스)가 송출 중
+	StatePaused         State = "paused"           // 송출 중이던 호스트가 트랙을 내리거나 나감
+	StateEnded          State = "ended"
+)
+
+// States 전체 상태 (상태별 인덱스 관리용)
+var States = []State{StateScheduled, StateWaitingForHost, StateLive, StatePaused, StateEnded}
+
+// StateChange 상태 전이 기록
+type StateChange struct {
+	State State     `json:"state"`
+	At    time.Time `json:"at"`
+	Cause string    `json:"cause,omitempty"` // 전이를 일으킨 이벤트
+}
+
+// Stream 스트림 기록 (LiveKit 룸이 사라진 뒤에도 유지)
+type Stream struct {
+	RoomId          string                 `json:"room_id"`
+	CreatorIdentity string                 `json:"creator_identity"`
+	Metadata        map[string]interface{} `json:"metadata"`         // 룸 메타데이터와 동일한 값 (title, description, type 등)
+	MetadataVersion int64                  `json:"metadata_version"` // 메타데이터 수정 시마다 증가 (ETag)
+	CreatedAt       time.Time              `json:"created_at"`
+	EndedAt         *time.Time             `json:"ended_at,omitempty"`    // 삭제(종료)된 스트림
+	MaxViewers      uint32                 `json:"max_viewers,omitempty"` // 시청자 정원 (0이면 제한 없음, 초과 시 대기열)
+
+	// 예약 스트림 (ScheduledAt이 있으면 스케줄러가 시작 전에 룸을 생성)
+	ScheduledAt   *time.Time `json:"scheduled_at,omitempty"`
+	ProvisionedAt *time.Time `json:"provisioned_at,omitempty"` // 룸 생성 시각
+	HostJoinedAt  *time.Time `json:"host_joined_at,omitempty"` // 호스트(또는 인그레스) 첫 접속 시각
+	NoShow        bool       `json:"no_show,omitempty"`        // 호스트가 나타나지 않아 종료됨
+	Ingress       *Ingress   `json:"ingress,omitempty"`        // 예약 시 요청한 인그레스 (스트림 키 포함, 관리자에게만 노출)
+
+	// 상태 (lifecycle 패키지가 이벤트로 전이)
+	State        State         `json:"state,omitempty"`
+	StateHistory []StateChange `json:"state_history,omitempty"`
+	HostTracks   int           `json:"host_tracks,omitempty"` // 호스트가 발행 중인 트랙 수
+}
+
+// Ingress 예약 스트림의 인그레스 설정 및 생성 결과
+type Ingress struct {
+	Type      string `json:"type"` // rtmp, whip
+	IngressId string `json:"ingress_id,omitempty"`
+	URL       string `json:"url,omitempty"`
+	StreamKey string `json:"stream_key,omitempty"`
+}
+
+// AwaitingHost 호스트가 아직 접속하지 않은 예약 스트림 여부 (스케줄러가 룸 생성/no-show를 처리할 대상)
+func (s *Stream) AwaitingHost() bool {
+	return s.ScheduledAt != nil && s.HostJoinedAt == nil && !s.Ended()
+}
+
+// Scheduled 아직 룸이 생성되지 않은 예약 스트림 여부
+func (s *Stream) Scheduled() bool {
+	return s.ScheduledAt != nil && s.ProvisionedAt == nil && !s.Ended()
+}
+
+// CurrentState 저장된 상태 (상태 도입 전 기록은 다른 필드로 추정)
+func (s *Stream) CurrentState() State {
+	switch {
+	case s.State != "":
+		return s.State
+	case s.Ended():
+		return StateEnded
+	case s.Scheduled():
+		return StateScheduled
+	}
+	return StateWaitingForHost
+}
+
+// StateChangedAt 마지막 상태 전이 시각
+func (s *Stream) StateChangedAt() time.Time {
+	if n := len(s.StateHistory); n > 0 {
+		return s.StateHistory[n-1].At
+	}
+	return s.CreatedAt
+}
+
+// RoomMaxParticipants LiveKit 룸 최대 참가자 수 (정원이 없으면 0 = 제한 없음)
+func (s *Stream) RoomMaxParticipants() uint32 {
+	if s.MaxViewers == 0 {
+		return 0
+	}
+	return s.MaxViewers + StaffSeats
+}
+
+// Ended 종료 여부
+func (s *Stream) Ended() bool {
+	return s.EndedAt != nil
+}
+
+// IsPrivate 메타데이터의 isPrivate 값 (프론트엔드는 bool, 일부 클라이언트는 문자열로 전송)
+func (s *Stream) IsPrivate() bool {
+	switch v := s.Metadata["isPrivate"].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// MetadataJSON 룸 메타데이터 문자열
+func (s *Stream) MetadataJSON() (string, error) {
+	data, err := json.Marshal(s.Metadata)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromRoom 카탈로그에 없는 룸(인그레스, 이전 버전에서 생성 등)을 룸 메타데이터로 복원
+func FromRoom(room *livekit.Room) *Stream {
+	var metadata map[string]interface{}
+	if room.Metadata != "" {
+		json.Unmarshal([]byte(room.Metadata), &metadata)
+	}
+	creator, _ := metadata["creator_identity"].(string)
+	state := StateWaitingForHost
+	if room.NumPublishers > 0 {
+		state = StateLive
+	}
+	return &Stream{
+		RoomId:          room.Name,
+		CreatorIdentity: creator,
+		Metadata:        metadata,
+		CreatedAt:       time.Unix(room.CreationTime, 0),
+		State:           state,
+	}
+}
+
+// Repository 스트림 카탈로그 저장소
+type Repository interface {
+	// Create 새 기록 저장 (같은 room_id가 이미 있으면 덮어쓰지 않고 ErrStreamExists)
+	Create(ctx context.Context, s *Stream) error
+	// Save 생성 또는 전체 교체
+	Save(ctx context.Context, s *Stream) error
+	Get(ctx context.Context, roomId string) (*Stream, error)
+	// List 최신순 전체 목록 (종료된 스트림 포함)
+	List(ctx context.Context) ([]*Stream, error)
+	// ListAwaitingHost 호스트를 기다리는 예약 스트림 중 startsBefore 이전에 시작하는 기록을 시작 시각순으로 조회
+	ListAwaitingHost(ctx context.Context, startsBefore time.Time) ([]*Stream, error)
+	// ListByState 상태별 인덱스로 지정한 상태(CurrentState)의 기록만 최신순 조회 (종료된 기록까지 전부 읽지 않도록)
+	ListByState(ctx context.Context, states ...State) ([]*Stream, error)
+	// Update 읽기-수정-저장을 원자적으로 수행 (fn이 ErrUnchanged를 반환하면 저장하지 않음)
+	Update(ctx context.Context, roomId string, fn func(s *Stream) error) (*Stream, error)
+}
@@ -0,0 +1,132 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryRepository 인메모리 스트림 카탈로그 (재시작 시 초기화)
+type MemoryRepository struct {
+	mu      sync.RWMutex
+	streams map[string]*Stream
+}
+
+// NewMemoryRepository 생성자
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{streams: make(map[string]*Stream)}
+}
+
+func (r *MemoryRepository) Create(ctx context.Context, s *Stream) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.streams[s.RoomId]; ok {
+		return ErrStreamExists
+	}
+	r.streams[s.RoomId] = clone(s)
+	return nil
+}
+
+func (r *MemoryRepository) Save(ctx context.Context, s *Stream) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.streams[s.RoomId] = clone(s)
+	return nil
+}
+
+func (r *MemoryRepository) Get(ctx context.Context, roomId string) (*Stream, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.streams[roomId]
+	if !ok {
+		return nil, ErrStreamNotFound
+	}
+	return clone(s), nil
+}
+
+func (r *MemoryRepository) List(ctx context.Context) ([]*Stream, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*Stream, 0, len(r.streams))
+	for _, s := range r.streams {
+		list = append(list, clone(s))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (r *MemoryRepository) ListByState(ctx context.Context, states ...State) ([]*Stream, error) {
+	wanted := make(map[State]bool, len(states))
+	for _, state := range states {
+		wanted[state] = true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := []*Stream{}
+	for _, s := range r.streams {
+		if wanted[s.CurrentState()] {
+			list = append(list, clone(s))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (r *MemoryRepository) ListAwaitingHost(ctx context.Context, startsBefore time.Time) ([]*Stream, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := []*Stream{}
+	for _, s := range r.streams {
+		if s.AwaitingHost() && !s.ScheduledAt.After(startsBefore) {
+			list = append(list, clone(s))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ScheduledAt.Before(*list[j].ScheduledAt) })
+	return list, nil
+}
+
+func (r *MemoryRepository) Update(ctx context.Context, roomId string, fn func(s *Stream) error) (*Stream, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.streams[roomId]
+	if !ok {
+		return nil, ErrStreamNotFound
+	}
+	s := clone(stored)
+	if err := fn(s); errors.Is(err, ErrUnchanged) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+	r.streams[roomId] = clone(s)
+	return s, nil
+}
+
+// clone 호출자가 저장된 값을 수정하지 않도록 복사 (메타데이터는 얕은 복사, 시각은 불변 값으로 취급)
+func clone(s *Stream) *Stream {
+	copied := *s
+	if s.Metadata != nil {
+		copied.Metadata = make(map[string]interface{}, len(s.Metadata))
+		for k, v := range s.Metadata {
+			copied.Metadata[k] = v
+		}
+	}
+	if s.StateHistory != nil {
+		copied.StateHistory = append([]StateChange(nil), s.StateHistory...)
+	}
+	if s.Ingress != nil {
+		ingress := *s.Ingress
+		copied.Ingress = &ingress
+	}
+	return &copied
+}
@@ -0,0 +1,272 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisRepository Redis 기반 스트림 카탈로그
+//
+//	stream:<room_id>        스트림 기록 (JSON)
+//	stream:index            room_id 정렬 집합 (score = 생성 시각 ms)
+//	stream:state:<state>    상태별 room_id 정렬 집합 (score = 생성 시각 ms, 기록과 같은 트랜잭션에서 갱신)
+//	stream:awaiting-host    호스트를 기다리는 예약 스트림 정렬 집합 (score = 예약 시작 시각 ms, 스케줄러용)
+type RedisRepository struct {
+	rdb *redis.Client
+}
+
+// NewRedisRepository 생성자
+func NewRedisRepository(rdb *redis.Client) *RedisRepository {
+	return &RedisRepository{rdb: rdb}
+}
+
+const (
+	indexKey        = "stream:index"
+	awaitingHostKey = "stream:awaiting-host"
+)
+
+func streamKey(roomId string) string {
+	return "stream:" + roomId
+}
+
+func stateKey(state State) string {
+	return "stream:state:" + string(state)
+}
+
+// indexState 현재 상태의 인덱스에만 남도록, 호스트를 기다리는 예약 스트림만 예약 인덱스에 남도록 갱신
+// (트랜잭션 파이프라인 안에서 호출)
+func indexState(ctx context.Context, pipe redis.Pipeliner, s *Stream) {
+	current := s.CurrentState()
+	for _, state := range States {
+		if state != current {
+			pipe.ZRem(ctx, stateKey(state), s.RoomId)
+		}
+	}
+	pipe.ZAdd(ctx, stateKey(current), redis.Z{Score: float64(s.CreatedAt.UnixMilli()), Member: s.RoomId})
+	if s.AwaitingHost() {
+		pipe.ZAdd(ctx, awaitingHostKey, redis.Z{Score: float64(s.ScheduledAt.UnixMilli()), Member: s.RoomId})
+	} else {
+		pipe.ZRem(ctx, awaitingHostKey, s.RoomId)
+	}
+}
+
+// awaitingHostScore 예약 인덱스 score (대상이 아니면 빈 문자열)
+func awaitingHostScore(s *Stream) string {
+	if !s.AwaitingHost() {
+		return ""
+	}
+	return strconv.FormatInt(s.ScheduledAt.UnixMilli(), 10)
+}
+
+// createScript 기록이 없을 때만 저장하고 인덱스에 추가 (SETNX)
+//
+//	KEYS[1] 스트림 기록, KEYS[2] 전체 인덱스, KEYS[3] 상태별 인덱스, KEYS[4] 예약 인덱스
+//	ARGV[1] 기록 JSON, ARGV[2] 생성 시각 ms, ARGV[3] room_id, ARGV[4] 예약 시작 시각 ms (대상이 아니면 빈 문자열)
+var createScript = redis.NewScript(`
+if not redis.call('SET', KEYS[1], ARGV[1], 'NX') then return 0 end
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[3])
+redis.call('ZADD', KEYS[3], ARGV[2], ARGV[3])
+if ARGV[4] ~= '' then
+  redis.call('ZADD', KEYS[4], ARGV[4], ARGV[3])
+end
+return 1
+`)
+
+func (r *RedisRepository) Create(ctx context.Context, s *Stream) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	created, err := createScript.Run(ctx, r.rdb, []string{streamKey(s.RoomId), indexKey, stateKey(s.CurrentState()), awaitingHostKey}, data, s.CreatedAt.UnixMilli(), s.RoomId, awaitingHostScore(s)).Int()
+	if err != nil {
+		return err
+	}
+	if created == 0 {
+		return ErrStreamExists
+	}
+	return nil
+}
+
+func (r *RedisRepository) Save(ctx context.Context, s *Stream) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, streamKey(s.RoomId), data, 0)
+		pipe.ZAdd(ctx, indexKey, redis.Z{Score: float64(s.CreatedAt.UnixMilli()), Member: s.RoomId})
+		indexState(ctx, pipe, s)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisRepository) Get(ctx context.Context, roomId string) (*Stream, error) {
+	data, err := r.rdb.Get(ctx, streamKey(roomId)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrStreamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s Stream
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *RedisRepository) List(ctx context.Context) ([]*Stream, error) {
+	ids, err := r.rdb.ZRevRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	return r.load(ctx, ids, func(roomId string) {
+		r.rdb.ZRem(ctx, indexKey, roomId)
+	})
+}
+
+func (r *RedisRepository) ListByState(ctx context.Context, states ...State) ([]*Stream, error) {
+	cmds := make([]*redis.ZSliceCmd, len(states))
+	_, err := r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, state := range states {
+			cmds[i] = pipe.ZRevRangeWithScores(ctx, stateKey(state), 0, -1)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var members []redis.Z
+	for _, cmd := range cmds {
+		members = append(members, cmd.Val()...)
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].Score > members[j].Score })
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i], _ = member.Member.(string)
+	}
+	return r.load(ctx, ids, func(roomId string) {
+		for _, state := range states {
+			r.rdb.ZRem(ctx, stateKey(state), roomId)
+		}
+	})
+}
+
+func (r *RedisRepository) ListAwaitingHost(ctx context.Context, startsBefore time.Time) ([]*Stream, error) {
+	ids, err := r.rdb.ZRangeByScore(ctx, awaitingHostKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(startsBefore.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	return r.load(ctx, ids, func(roomId string) {
+		r.rdb.ZRem(ctx, awaitingHostKey, roomId)
+	})
+}
+
+// Reindex 기존 기록으로 상태별/예약 인덱스 재구성 (인덱스 도입 전에 저장된 기록용, 시작 시 한 번)
+func (r *RedisRepository) Reindex(ctx context.Context) error {
+	list, err := r.List(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = r.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, s := range list {
+			indexState(ctx, pipe, s)
+		}
+		return nil
+	})
+	return err
+}
+
+// load room_id 순서대로 기록 조회 (기록이 사라진 항목은 prune으로 인덱스에서 정리)
+func (r *RedisRepository) load(ctx context.Context, ids []string, prune func(roomId string)) ([]*Stream, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = streamKey(id)
+	}
+	values, err := r.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Stream, 0, len(values))
+	for i, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			// 기록이 사라진 인덱스 항목 정리
+			prune(ids[i])
+			continue
+		}
+		var s Stream
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			return nil, err
+		}
+		list = append(list, &s)
+	}
+	return list, nil
+}
+
+// 동시 수정으로 트랜잭션이 실패했을 때 재시도 횟수
+const updateRetries = 5
+
+func (r *RedisRepository) Update(ctx context.Context, roomId string, fn func(s *Stream) error) (*Stream, error) {
+	key := streamKey(roomId)
+	for i := 0; i < updateRetries; i++ {
+		var updated *Stream
+		err := r.rdb.Watch(ctx, func(tx *redis.Tx) error {
+			data, err := tx.Get(ctx, key).Bytes()
+			if errors.Is(err, redis.Nil) {
+				return ErrStreamNotFound
+			}
+			if err != nil {
+				return err
+			}
+			var s Stream
+			if err := json.Unmarshal(data, &s); err != nil {
+				return err
+			}
+			if err := fn(&s); err != nil {
+				if errors.Is(err, ErrUnchanged) {
+					updated = &s
+					return nil
+				}
+				return err
+			}
+			data, err = json.Marshal(&s)
+			if err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, data, 0)
+				indexState(ctx, pipe, &s)
+				return nil
+			})
+			updated = &s
+			return err
+		}, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+	return nil, redis.TxFailedErr
+}
@@ -0,0 +1,230 @@
+package chat
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+)
+
+// 연결마다 기억하는 최근 메시지 ID 수 (텍스트 스트림과 이전 형식으로 같은 메시지가 두 번 도착)
+const recentIDs = 256
+
+// 봇 임대 기간 (접속해 있는 동안 leaseTTL/3마다 연장, 인스턴스가 죽으면 만료 후 다른 인스턴스가 접속)
+const leaseTTL = 30 * time.Second
+
+// Lease 룸별 봇 접속 임대 - 같은 identity의 봇이 인스턴스마다 접속하면 서로를 내보내므로
+// 임대를 잡은 인스턴스만 접속한다.
+type Lease interface {
+	// Acquire 비어 있으면 owner로 잡음 (이미 owner가 잡고 있으면 연장)
+	Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Renew owner가 잡고 있을 때만 연장 (놓쳤으면 false)
+	Renew(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Release owner가 잡고 있을 때만 해제
+	Release(ctx context.Context, key, owner string) error
+}
+
+// Session 룸에 접속한 봇 연결
+type Session interface {
+	Close()
+}
+
+// Connector 룸에 봇 접속 (받은 메시지는 receive로, 연결이 끊기면 closed 호출)
+type Connector interface {
+	Connect(roomId string, receive func(*Message), closed func()) (Session, error)
+}
+
+// Bot 웹훅 구독자 - 열린 룸마다 봇을 접속시켜 받은 채팅 메시지를 handle로 넘김 (기록 봇, 채팅 관리 봇)
+// 같은 메시지는 연결마다 한 번만 넘긴다.
+type Bot struct {
+	identity  string
+	connector Connector
+	lease     Lease
+	handle    func(m *Message)
+
+	mu    sync.Mutex
+	conns map[string]*connection
+}
+
+// connection 룸 하나의 봇 연결 (접속 중이면 session이 nil)
+type connection struct {
+	owner   string // 임대 소유자 (연결마다 새로 만듦)
+	session Session
+	seen    map[string]bool
+	order   []string // seen에 넣은 순서 (오래된 ID부터 지움)
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewBot 생성자
+func NewBot(identity string, connector Connector, lease Lease, handle func(m *Message)) *Bot {
+	return &Bot{
+		identity:  identity,
+		connector: connector,
+		lease:     lease,
+		handle:    handle,
+		conns:     map[string]*connection{},
+	}
+}
+
+// HandleWebhook room_started, participant_joined에 접속 (재시작 전에 열린 룸 포함), room_finished에 연결 종료
+func (b *Bot) HandleWebhook(ctx context.Context, event *livekit.WebhookEvent) error {
+	roomId := event.GetRoom().GetName()
+	if roomId == "" {
+		return nil
+	}
+	switch event.Event {
+	case webhook.EventRoomStarted:
+		b.connect(roomId)
+	case webhook.EventParticipantJoined:
+		if event.GetParticipant().GetIdentity() != b.identity {
+			b.connect(roomId)
+		}
+	case webhook.EventRoomFinished:
+		b.disconnect(roomId)
+	}
+	return nil
+}
+
+func (b *Bot) leaseKey(roomId string) string {
+	return "chat:" + roomId + ":bot:" + b.identity
+}
+
+// connect 접속 중이 아니면 자리를 예약하고 백그라운드에서 접속 (웹훅 응답을 늦추지 않음)
+func (b *Bot) connect(roomId string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.conns[roomId]; ok {
+		return
+	}
+	owner := make([]byte, 16)
+	rand.Read(owner)
+	conn := &connection{owner: hex.EncodeToString(owner), seen: map[string]bool{}, done: make(chan struct{})}
+	b.conns[roomId] = conn
+	go b.dial(roomId, conn)
+}
+
+// dial 임대를 잡은 경우에만 접속 (다른 인스턴스가 접속 중이면 다음 참가 이벤트에 다시 시도)
+func (b *Bot) dial(roomId string, conn *connection) {
+	acquired, err := b.lease.Acquire(context.Background(), b.leaseKey(roomId), conn.owner, leaseTTL)
+	if err != nil || !acquired {
+		if err != nil {
+			log.Println("chat bot lease failed:", b.identity, roomId, err)
+		}
+		b.drop(roomId, conn)
+		return
+	}
+
+	session, err := b.connector.Connect(roomId, func(m *Message) {
+		b.receive(roomId, conn, m)
+	}, func() {
+		// 룸 종료, 서버 측 퇴장 등 (다음 참가 이벤트에 다시 접속)
+		b.drop(roomId, conn)
+	})
+	if err != nil {
+		log.Println("chat bot connect failed:", b.identity, roomId, err)
+		b.drop(roomId, conn)
+		return
+	}
+
+	b.mu.Lock()
+	current := b.conns[roomId] == conn
+	if current {
+		conn.session = session
+	}
+	b.mu.Unlock()
+	if !current {
+		// 접속하는 동안 룸이 종료되었거나 연결이 끊김
+		session.Close()
+		b.drop(roomId, conn)
+		return
+	}
+	go b.renew(roomId, conn)
+}
+
+// renew 접속해 있는 동안 임대 연장 (임대를 놓치면 다른 인스턴스에 넘기고 연결 종료)
+func (b *Bot) renew(roomId string, conn *connection) {
+	ticker := time.NewTicker(leaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-conn.done:
+			return
+		case <-ticker.C:
+		}
+		renewed, err := b.lease.Renew(context.Background(), b.leaseKey(roomId), conn.owner, leaseTTL)
+		if err != nil {
+			// 일시적인 오류는 다음 주기에 다시 시도 (그 사이 만료되면 Renew가 false)
+			log.Println("chat bot lease renew failed:", b.identity, roomId, err)
+			continue
+		}
+		if !renewed {
+			log.Println("chat bot lease lost:", b.identity, roomId)
+			b.drop(roomId, conn)
+			return
+		}
+	}
+}
+
+func (b *Bot) disconnect(roomId string) {
+	b.mu.Lock()
+	conn := b.conns[roomId]
+	b.mu.Unlock()
+	if conn != nil {
+		b.drop(roomId, conn)
+	}
+}
+
+// drop 연결 정리 (목록에서 제거, 연결 종료, 임대 해제, 여러 번 호출해도 한 번만)
+func (b *Bot) drop(roomId string, conn *connection) {
+	b.mu.Lock()
+	if b.conns[roomId] == conn {
+		delete(b.conns, roomId)
+	}
+	session := conn.session
+	conn.session = nil
+	b.mu.Unlock()
+	// 연결 종료 콜백이 다시 drop을 호출할 수 있으므로 잠금 밖에서 닫음
+	if session != nil {
+		session.Close()
+	}
+
+	conn.once.Do(func() {
+		close(conn.done)
+		if err := b.lease.Release(context.Background(), b.leaseKey(roomId), conn.owner); err != nil {
+			log.Println("chat bot lease release failed:", b.identity, roomId, err)
+		}
+	})
+}
+
+// receive 중복을 걸러 handle로 넘김 (룸 ID, 비어 있는 타임스탬프 채움)
+func (b *Bot) receive(roomId string, conn *connection, m *Message) {
+	if m.Message == "" {
+		return
+	}
+	b.mu.Lock()
+	duplicate := m.ID != "" && conn.seen[m.ID]
+	if m.ID != "" && !duplicate {
+		conn.seen[m.ID] = true
+		conn.order = append(conn.order, m.ID)
+		if len(conn.order) > recentIDs {
+			delete(conn.seen, conn.order[0])
+			conn.order = conn.order[1:]
+		}
+	}
+	b.mu.Unlock()
+	if duplicate {
+		return
+	}
+
+	m.RoomId = roomId
+	if m.Timestamp.IsZero() {
+		m.Timestamp = time.Now()
+	}
+	b.handle(m)
+}
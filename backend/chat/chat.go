@@ -0,0 +1,75 @@
+package chat
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+// 저장하는 메시지 최대 길이 (넘으면 잘라서 저장)
+const maxMessageLength = 4096
+
+// Message 채팅 메시지
+type Message struct {
+	Seq       int64     `json:"seq"` // 룸 안에서 증가하는 순번 (스크롤백 커서)
+	ID        string    `json:"id"`  // 클라이언트가 만든 메시지 ID (중복 저장 방지)
+	RoomId    string    `json:"room_id"`
+	Identity  string    `json:"identity"`
+	Name      string    `json:"name,omitempty"`
+	Role      string    `json:"role,omitempty"` // 보낸 사람의 참가 역할 (참가자 속성)
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Store 룸별 채팅 저장소 (룸이 사라진 뒤에도 보관)
+type Store interface {
+	// Append 메시지 저장 후 순번 부여 (같은 ID가 이미 있으면 false)
+	Append(ctx context.Context, m *Message) (bool, error)
+	// List before보다 앞선 메시지 중 최신 limit개를 오래된 순으로 (before가 0이면 마지막까지)
+	// 더 앞선 메시지가 남아 있으면 true
+	List(ctx context.Context, roomId string, before int64, limit int) ([]*Message, bool, error)
+	// Redact 메시지 삭제 (아직 저장되지 않았으면 같은 ID는 이후에도 저장하지 않음)
+	Redact(ctx context.Context, roomId, id string) error
+}
+
+// Recorder 웹훅 구독자 - 열린 룸마다 기록 봇을 접속시켜 채팅 저장
+type Recorder struct {
+	store Store
+	bot   *Bot
+}
+
+// NewRecorder 생성자
+func NewRecorder(store Store, connector Connector, lease Lease) *Recorder {
+	r := &Recorder{store: store}
+	r.bot = NewBot(RecorderIdentity, connector, lease, r.record)
+	return r
+}
+
+// HandleWebhook room_started, participant_joined에 접속 (재시작 전에 열린 룸 포함), room_finished에 연결 종료
+func (r *Recorder) HandleWebhook(ctx context.Context, event *livekit.WebhookEvent) error {
+	return r.bot.HandleWebhook(ctx, event)
+}
+
+// History 스크롤백/내보내기 조회
+func (r *Recorder) History(ctx context.Context, roomId string, before int64, limit int) ([]*Message, bool, error) {
+	return r.store.List(ctx, roomId, before, limit)
+}
+
+// Redact 규칙을 어긴 메시지 삭제 (기록 봇보다 먼저 호출되어도 이후에 저장되지 않음)
+func (r *Recorder) Redact(ctx context.Context, roomId, id string) error {
+	if id == "" {
+		return nil
+	}
+	return r.store.Redact(ctx, roomId, id)
+}
+
+func (r *Recorder) record(m *Message) {
+	if runes := []rune(m.Message); len(runes) > maxMessageLength {
+		m.Message = string(runes[:maxMessageLength])
+	}
+	if _, err := r.store.Append(context.Background(), m); err != nil {
+		log.Println("chat record failed:", m.RoomId, err)
+	}
+}
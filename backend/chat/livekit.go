@@ -0,0 +1,144 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"backend/keyring"
+	"backend/policy"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// RecorderIdentity 기록 봇 identity (룸마다 임대를 잡은 인스턴스 하나만 접속)
+const RecorderIdentity = "chat-recorder"
+
+// botTokenTTL 봇 토큰 유효 기간 (접속할 때만 쓰고, 접속 후에는 LiveKit이 갱신)
+const botTokenTTL = 10 * time.Minute
+
+// LiveKit 채팅 토픽 (@livekit/components-react useChat)
+const (
+	TextStreamTopic = "lk.chat"       // 텍스트 스트림
+	LegacyTopic     = "lk-chat-topic" // 이전 버전 클라이언트의 데이터 메시지
+)
+
+// legacyMessage 이전 버전 채팅 데이터 메시지 (텍스트 스트림과 함께 같은 ID로 전송됨)
+type legacyMessage struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"` // ms
+	Message   string `json:"message"`
+}
+
+// LiveKitConnector 숨김 에이전트 참가자로 룸에 접속해 채팅 수신 (기록 봇, 채팅 관리 봇)
+// 에이전트 참가자는 룸을 유지시키지 않고 시청자 수에도 포함되지 않는다.
+type LiveKitConnector struct {
+	hostURL  string
+	keys     *keyring.Ring
+	identity string
+	name     string
+}
+
+// NewLiveKitConnector 생성자
+func NewLiveKitConnector(hostURL string, keys *keyring.Ring, identity, name string) *LiveKitConnector {
+	return &LiveKitConnector{
+		hostURL:  hostURL,
+		keys:     keys,
+		identity: identity,
+		name:     name,
+	}
+}
+
+// botGrant 채팅 봇 전용 권한 - 숨김, 수신만 (트랙/데이터 발행, 메타데이터 변경 불가)
+// 봇 역할 정책은 발행 권한과 긴 TTL을 주므로 쓰지 않는다.
+func botGrant(roomId string) *auth.VideoGrant {
+	grant := &auth.VideoGrant{
+		Room:     roomId,
+		RoomJoin: true,
+		Hidden:   true,
+	}
+	grant.SetCanSubscribe(true)
+	grant.SetCanPublish(false)
+	grant.SetCanPublishData(false)
+	grant.SetCanUpdateOwnMetadata(false)
+	return grant
+}
+
+func (c *LiveKitConnector) Connect(roomId string, receive func(*Message), closed func()) (Session, error) {
+	primary := c.keys.Primary()
+	at := auth.NewAccessToken(primary.Key, primary.Secret)
+	at.SetIdentity(c.identity).
+		SetName(c.name).
+		SetKind(livekit.ParticipantInfo_AGENT).
+		SetVideoGrant(botGrant(roomId)).
+		SetAttributes(map[string]string{policy.AttributeRole: string(policy.RoleBot)}).
+		SetValidFor(botTokenTTL)
+	token, err := at.ToJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	var room *lksdk.Room
+	// sender 보낸 사람의 이름과 역할 속성
+	sender := func(m *Message) *Message {
+		if p := room.GetParticipantByIdentity(m.Identity); p != nil {
+			m.Name, m.Role = p.Name(), p.Attributes()[policy.AttributeRole]
+		}
+		return m
+	}
+	callback := &lksdk.RoomCallback{
+		OnDisconnected: closed,
+		ParticipantCallback: lksdk.ParticipantCallback{
+			OnDataPacket: func(data lksdk.DataPacket, params lksdk.DataReceiveParams) {
+				packet, ok := data.(*lksdk.UserDataPacket)
+				if !ok || packet.Topic != LegacyTopic {
+					return
+				}
+				var msg legacyMessage
+				if err := json.Unmarshal(packet.Payload, &msg); err != nil {
+					return
+				}
+				receive(sender(&Message{
+					ID:        msg.ID,
+					Identity:  params.SenderIdentity,
+					Message:   msg.Message,
+					Timestamp: fromMillis(msg.Timestamp),
+				}))
+			},
+		},
+	}
+	// 콜백이 room을 참조하므로 접속 전에 만들고, 첫 메시지를 놓치지 않도록 핸들러도 먼저 등록
+	// (SDK가 처음 등록할 때도 오류를 반환하므로 무시, 핸들러는 등록됨)
+	room = lksdk.NewRoom(callback)
+	room.RegisterTextStreamHandler(TextStreamTopic, func(reader *lksdk.TextStreamReader, identity string) {
+		receive(sender(&Message{
+			ID:        reader.Info.Id,
+			Identity:  identity,
+			Message:   reader.ReadAll(),
+			Timestamp: fromMillis(reader.Info.Timestamp),
+		}))
+	})
+	if err := room.JoinWithToken(c.hostURL, token, lksdk.WithAutoSubscribe(false)); err != nil {
+		return nil, fmt.Errorf("connect chat bot %s: %w", c.identity, err)
+	}
+	return roomSession{room: room}, nil
+}
+
+// roomSession 룸 연결 종료 어댑터
+type roomSession struct {
+	room *lksdk.Room
+}
+
+func (s roomSession) Close() {
+	s.room.Disconnect()
+}
+
+// fromMillis 클라이언트 타임스탬프 (없으면 저장 시각 사용)
+func fromMillis(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
@@ -0,0 +1,136 @@
+package chat
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type memoryRoom struct {
+	messages []*Message // 순번 순 (삭제된 메시지는 빠짐)
+	ids      map[string]bool
+	seq      int64
+}
+
+// MemoryStore 인메모리 채팅 저장소 (단일 인스턴스/테스트용)
+type MemoryStore struct {
+	mu    sync.Mutex
+	rooms map[string]*memoryRoom
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{rooms: map[string]*memoryRoom{}}
+}
+
+func (s *MemoryStore) room(roomId string) *memoryRoom {
+	r, ok := s.rooms[roomId]
+	if !ok {
+		r = &memoryRoom{ids: map[string]bool{}}
+		s.rooms[roomId] = r
+	}
+	return r
+}
+
+func (s *MemoryStore) Append(ctx context.Context, m *Message) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.room(m.RoomId)
+	if m.ID != "" {
+		if r.ids[m.ID] {
+			return false, nil
+		}
+		r.ids[m.ID] = true
+	}
+	r.seq++
+	copied := *m
+	copied.Seq = r.seq
+	r.messages = append(r.messages, &copied)
+	m.Seq = copied.Seq
+	return true, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, roomId string, before int64, limit int) ([]*Message, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.rooms[roomId]
+	if !ok {
+		return []*Message{}, false, nil
+	}
+	end := len(r.messages)
+	if before > 0 {
+		end = sort.Search(len(r.messages), func(i int) bool { return r.messages[i].Seq >= before })
+	}
+	start := max(end-limit, 0)
+	messages := make([]*Message, 0, end-start)
+	for _, m := range r.messages[start:end] {
+		copied := *m
+		messages = append(messages, &copied)
+	}
+	return messages, start > 0, nil
+}
+
+func (s *MemoryStore) Redact(ctx context.Context, roomId, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.room(roomId)
+	r.ids[id] = true
+	r.messages = slices.DeleteFunc(r.messages, func(m *Message) bool { return m.ID == id })
+	return nil
+}
+
+type memoryLease struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// MemoryLease 인메모리 임대 (단일 인스턴스/테스트용)
+type MemoryLease struct {
+	mu     sync.Mutex
+	leases map[string]memoryLease
+}
+
+// NewMemoryLease 생성자
+func NewMemoryLease() *MemoryLease {
+	return &MemoryLease{leases: map[string]memoryLease{}}
+}
+
+func (l *MemoryLease) Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if current, ok := l.leases[key]; ok && current.owner != owner && now.Before(current.expiresAt) {
+		return false, nil
+	}
+	l.leases[key] = memoryLease{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (l *MemoryLease) Renew(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	current, ok := l.leases[key]
+	if !ok || current.owner != owner || !now.Before(current.expiresAt) {
+		return false, nil
+	}
+	l.leases[key] = memoryLease{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (l *MemoryLease) Release(ctx context.Context, key, owner string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if current, ok := l.leases[key]; ok && current.owner == owner {
+		delete(l.leases, key)
+	}
+	return nil
+}
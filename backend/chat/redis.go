@@ -0,0 +1,184 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 마지막 메시지 후 채팅을 보관하는 기간
+const messageRetention = 90 * 24 * time.Hour
+
+// appendScript 중복 확인, 순번 발급, 저장을 원자적으로 수행
+//
+//	KEYS[1]  chat:<room>:messages  sorted set (score = 순번, member = 메시지 JSON)
+//	KEYS[2]  chat:<room>:ids       set (저장했거나 삭제한 메시지 ID)
+//	KEYS[3]  chat:<room>:seq       마지막 순번
+//	KEYS[4]  chat:<room>:index     hash (메시지 ID -> 순번, 삭제용)
+//	ARGV     id, message, retention(ms)
+//	반환     순번 (중복이면 0)
+var appendScript = redis.NewScript(`
+if ARGV[1] ~= '' and redis.call('SADD', KEYS[2], ARGV[1]) == 0 then
+  return 0
+end
+local seq = redis.call('INCR', KEYS[3])
+redis.call('ZADD', KEYS[1], seq, ARGV[2])
+if ARGV[1] ~= '' then
+  redis.call('HSET', KEYS[4], ARGV[1], seq)
+end
+for _, key in ipairs(KEYS) do
+  redis.call('PEXPIRE', key, ARGV[3])
+end
+return seq
+`)
+
+// redactScript 저장된 메시지를 지우고, 아직 저장되지 않았으면 ID를 미리 넣어 이후 저장을 막음
+//
+//	KEYS     messages, ids, index (appendScript와 같음)
+//	ARGV     id, retention(ms)
+var redactScript = redis.NewScript(`
+redis.call('SADD', KEYS[2], ARGV[1])
+local seq = redis.call('HGET', KEYS[3], ARGV[1])
+if seq then
+  redis.call('ZREMRANGEBYSCORE', KEYS[1], seq, seq)
+  redis.call('HDEL', KEYS[3], ARGV[1])
+end
+redis.call('PEXPIRE', KEYS[2], ARGV[2])
+return 1
+`)
+
+// acquireScript 비어 있거나 이미 owner가 잡고 있으면 임대 (SET NX PX)
+//
+//	KEYS[1]  임대 키
+//	ARGV     owner, ttl(ms)
+var acquireScript = redis.NewScript(`
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+  return 1
+end
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+  return 1
+end
+return 0
+`)
+
+// renewScript owner가 잡고 있을 때만 연장
+var renewScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+  return 1
+end
+return 0
+`)
+
+// releaseScript owner가 잡고 있을 때만 해제 (만료 후 다른 인스턴스가 잡은 임대는 그대로)
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RedisStore Redis 기반 채팅 저장소
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func messagesKey(roomId string) string {
+	return "chat:" + roomId + ":messages"
+}
+
+func idsKey(roomId string) string {
+	return "chat:" + roomId + ":ids"
+}
+
+func seqKey(roomId string) string {
+	return "chat:" + roomId + ":seq"
+}
+
+func indexKey(roomId string) string {
+	return "chat:" + roomId + ":index"
+}
+
+func (s *RedisStore) Append(ctx context.Context, m *Message) (bool, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return false, err
+	}
+	keys := []string{messagesKey(m.RoomId), idsKey(m.RoomId), seqKey(m.RoomId), indexKey(m.RoomId)}
+	seq, err := appendScript.Run(ctx, s.rdb, keys, m.ID, data, messageRetention.Milliseconds()).Int64()
+	if err != nil || seq == 0 {
+		return false, err
+	}
+	m.Seq = seq
+	return true, nil
+}
+
+func (s *RedisStore) List(ctx context.Context, roomId string, before int64, limit int) ([]*Message, bool, error) {
+	upper := "+inf"
+	if before > 0 {
+		upper = "(" + strconv.FormatInt(before, 10)
+	}
+	// 한 개 더 읽어서 앞선 메시지가 남았는지 확인
+	values, err := s.rdb.ZRevRangeByScoreWithScores(ctx, messagesKey(roomId), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   upper,
+		Count: int64(limit) + 1,
+	}).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	more := len(values) > limit
+	if more {
+		values = values[:limit]
+	}
+
+	messages := make([]*Message, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		var m Message
+		member, _ := values[i].Member.(string)
+		if err := json.Unmarshal([]byte(member), &m); err != nil {
+			continue
+		}
+		m.Seq = int64(values[i].Score)
+		messages = append(messages, &m)
+	}
+	return messages, more, nil
+}
+
+func (s *RedisStore) Redact(ctx context.Context, roomId, id string) error {
+	keys := []string{messagesKey(roomId), idsKey(roomId), indexKey(roomId)}
+	return redactScript.Run(ctx, s.rdb, keys, id, messageRetention.Milliseconds()).Err()
+}
+
+// RedisLease Redis 기반 임대 (인스턴스 사이에서 룸마다 봇 하나만 접속)
+type RedisLease struct {
+	rdb *redis.Client
+}
+
+// NewRedisLease 생성자
+func NewRedisLease(rdb *redis.Client) *RedisLease {
+	return &RedisLease{rdb: rdb}
+}
+
+func (l *RedisLease) Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	n, err := acquireScript.Run(ctx, l.rdb, []string{key}, owner, ttl.Milliseconds()).Int()
+	return n == 1, err
+}
+
+func (l *RedisLease) Renew(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	n, err := renewScript.Run(ctx, l.rdb, []string{key}, owner, ttl.Milliseconds()).Int()
+	return n == 1, err
+}
+
+func (l *RedisLease) Release(ctx context.Context, key, owner string) error {
+	return releaseScript.Run(ctx, l.rdb, []string{key}, owner).Err()
+}
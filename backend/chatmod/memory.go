@@ -0,0 +1,103 @@
+package chatmod
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 만료된 항목을 정리하는 간격
+const sweepInterval = time.Minute
+
+type userKey struct {
+	roomId   string
+	identity string
+}
+
+// MemoryStore 인메모리 슬로 모드/위반 기록 (단일 인스턴스/테스트용, 만료된 항목은 주기적으로 정리)
+type MemoryStore struct {
+	mu        sync.Mutex
+	paced     map[userKey]time.Time   // 다음 메시지를 보낼 수 있는 시각
+	strikes   map[userKey][]time.Time // 위반 시각 (기간 안의 것만)
+	windows   map[userKey]time.Duration
+	lastSweep time.Time
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		paced:   map[userKey]time.Time{},
+		strikes: map[userKey][]time.Time{},
+		windows: map[userKey]time.Duration{},
+	}
+}
+
+func (s *MemoryStore) Pace(ctx context.Context, roomId, identity string, interval time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	key := userKey{roomId: roomId, identity: identity}
+	if now.Before(s.paced[key]) {
+		return false, nil
+	}
+	s.paced[key] = now.Add(interval)
+	return true, nil
+}
+
+func (s *MemoryStore) Strike(ctx context.Context, roomId, identity string, at time.Time, window time.Duration) (int, error) {
+	if window <= 0 {
+		return 1, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(time.Now())
+	key := userKey{roomId: roomId, identity: identity}
+	strikes := prune(s.strikes[key], at, window)
+	s.strikes[key] = append(strikes, at)
+	s.windows[key] = window
+	return len(s.strikes[key]), nil
+}
+
+func (s *MemoryStore) Reset(ctx context.Context, roomId, identity string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := userKey{roomId: roomId, identity: identity}
+	delete(s.strikes, key)
+	delete(s.windows, key)
+	return nil
+}
+
+// sweep 만료된 슬로 모드/위반 기록 삭제 (호출자가 잠금)
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, until := range s.paced {
+		if !now.Before(until) {
+			delete(s.paced, key)
+		}
+	}
+	for key, strikes := range s.strikes {
+		if strikes = prune(strikes, now, s.windows[key]); len(strikes) == 0 {
+			delete(s.strikes, key)
+			delete(s.windows, key)
+		} else {
+			s.strikes[key] = strikes
+		}
+	}
+}
+
+// prune window 안의 위반 시각만 남김
+func prune(strikes []time.Time, now time.Time, window time.Duration) []time.Time {
+	kept := strikes[:0]
+	for _, at := range strikes {
+		if now.Sub(at) < window {
+			kept = append(kept, at)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,312 @@
+package chatmod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"backend/audit"
+	"backend/chat"
+	"backend/keyring"
+	"backend/permission"
+	"backend/policy"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+	"github.com/twitchtv/twirp"
+)
+
+// Topic 채팅 관리 이벤트 데이터 메시지 토픽
+const Topic = "chat.moderation"
+
+// ActorName 채팅 관리 봇 identity (관리 기록에 남는 작업자 이름)
+const ActorName = "chat-moderator"
+
+// 채팅 관리 이벤트 종류
+const (
+	EventDeleted = "message.deleted" // 룸 전체: 해당 ID의 메시지를 숨김
+	EventWarning = "warning"         // 보낸 사람에게만
+	EventMuted   = "user.muted"      // 룸 전체: 채팅 일시 금지
+	EventUnmuted = "user.unmuted"
+)
+
+// Event 채팅 관리 이벤트
+type Event struct {
+	Type      string     `json:"type"`
+	RoomId    string     `json:"room_id"`
+	Identity  string     `json:"identity"`
+	MessageID string     `json:"message_id,omitempty"`
+	Violation Violation  `json:"violation,omitempty"`
+	Until     *time.Time `json:"until,omitempty"` // 음소거 해제 시각
+	At        time.Time  `json:"at"`
+}
+
+// exemptRoles 검사하지 않는 역할 (진행자, 운영자, 봇)
+var exemptRoles = map[policy.Role]bool{
+	policy.RoleHost:      true,
+	policy.RoleCoHost:    true,
+	policy.RoleModerator: true,
+	policy.RoleBot:       true,
+}
+
+// Store 사용자별 슬로 모드/위반 기록 (인스턴스 간 공유, 기간이 지나면 만료)
+type Store interface {
+	// Pace 슬로 모드 간격이 지났으면 지금부터 다시 interval 동안 막고 true
+	Pace(ctx context.Context, roomId, identity string, interval time.Duration) (bool, error)
+	// Strike 위반 기록 후 window 안의 위반 횟수 반환
+	Strike(ctx context.Context, roomId, identity string, at time.Time, window time.Duration) (int, error)
+	// Reset 위반 기록 삭제 (음소거 후)
+	Reset(ctx context.Context, roomId, identity string) error
+}
+
+// Moderator 웹훅 구독자 - 열린 룸마다 채팅 관리 봇을 접속시켜 메시지를 검사한다.
+// 규칙을 어긴 메시지는 기록에서 지우고 삭제 이벤트와 경고를 보내며,
+// 반복해서 어기면 데이터 전송 권한을 회수해 일정 시간 채팅을 막는다.
+// 메시지는 이미 다른 참가자에게 전달되었으므로 클라이언트가 삭제 이벤트를 받아 숨겨야 한다.
+type Moderator struct {
+	rules       *Rules
+	hostURL     string
+	keys        *keyring.Ring
+	audit       *audit.Log
+	state       Store
+	permissions *permission.Service
+	history     *chat.Recorder
+	bot         *chat.Bot
+
+	mu     sync.Mutex
+	timers map[userKey]*time.Timer // 음소거 해제 예약 (봇이 접속한 인스턴스에만)
+}
+
+// NewModerator 생성자
+func NewModerator(rules *Rules, hostURL string, keys *keyring.Ring, auditLog *audit.Log, state Store, permissions *permission.Service, history *chat.Recorder, connector chat.Connector, lease chat.Lease) *Moderator {
+	m := &Moderator{
+		rules:       rules,
+		hostURL:     hostURL,
+		keys:        keys,
+		audit:       auditLog,
+		state:       state,
+		permissions: permissions,
+		history:     history,
+		timers:      map[userKey]*time.Timer{},
+	}
+	m.bot = chat.NewBot(ActorName, connector, lease, func(msg *chat.Message) {
+		m.Review(context.Background(), msg)
+	})
+	return m
+}
+
+// HandleWebhook room_started, participant_joined에 봇 접속, room_finished에 연결 종료 (음소거 해제 예약도 취소)
+func (m *Moderator) HandleWebhook(ctx context.Context, event *livekit.WebhookEvent) error {
+	if err := m.bot.HandleWebhook(ctx, event); err != nil {
+		return err
+	}
+	if event.Event != webhook.EventRoomFinished {
+		return nil
+	}
+	roomId := event.GetRoom().GetName()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, timer := range m.timers {
+		if key.roomId == roomId {
+			timer.Stop()
+			delete(m.timers, key)
+		}
+	}
+	return nil
+}
+
+// Review 메시지 검사 (위반이면 false, 저장소 오류는 통과시킴)
+func (m *Moderator) Review(ctx context.Context, msg *chat.Message) bool {
+	if exemptRoles[policy.Role(msg.Role)] {
+		return true
+	}
+	cfg := m.rules.Config()
+	violation, violated := m.rules.Check(msg.Message)
+	if !violated && cfg.SlowMode > 0 {
+		allowed, err := m.state.Pace(ctx, msg.RoomId, msg.Identity, cfg.SlowMode)
+		if err != nil {
+			log.Println("chat slow mode check failed:", msg.RoomId, msg.Identity, err)
+		} else if !allowed {
+			violation, violated = ViolationSlowMode, true
+		}
+	}
+	if !violated {
+		return true
+	}
+
+	// 위반 횟수가 쌓이면 음소거 (이미 음소거 중이면 연장하지 않음)
+	var until *time.Time
+	now := time.Now()
+	strikes, err := m.state.Strike(ctx, msg.RoomId, msg.Identity, now, cfg.StrikeWindow)
+	if err != nil {
+		log.Println("chat strike record failed:", msg.RoomId, msg.Identity, err)
+	}
+	if err == nil && cfg.MaxStrikes > 0 && strikes >= cfg.MaxStrikes {
+		muted, err := m.permissions.Muted(ctx, msg.RoomId, msg.Identity)
+		if err != nil {
+			log.Println("chat mute lookup failed:", msg.RoomId, msg.Identity, err)
+		} else if muted == nil {
+			if err := m.state.Reset(ctx, msg.RoomId, msg.Identity); err != nil {
+				log.Println("chat strike reset failed:", msg.RoomId, msg.Identity, err)
+			}
+			mutedUntil := now.Add(cfg.MuteDuration)
+			until = &mutedUntil
+		}
+	}
+
+	go m.enforce(msg, violation, until)
+	return false
+}
+
+// enforce 기록 삭제, 삭제 이벤트, 경고, (필요하면) 음소거
+func (m *Moderator) enforce(msg *chat.Message, violation Violation, until *time.Time) {
+	ctx := context.Background()
+	if err := m.history.Redact(ctx, msg.RoomId, msg.ID); err != nil {
+		log.Println("chat redact failed:", msg.RoomId, msg.ID, err)
+	}
+	roomClient := m.roomClient()
+	m.send(ctx, roomClient, &Event{Type: EventDeleted, RoomId: msg.RoomId, Identity: msg.Identity, MessageID: msg.ID, Violation: violation})
+	m.send(ctx, roomClient, &Event{Type: EventWarning, RoomId: msg.RoomId, Identity: msg.Identity, MessageID: msg.ID, Violation: violation}, msg.Identity)
+	if until == nil {
+		return
+	}
+
+	if err := m.mute(ctx, roomClient, msg.RoomId, msg.Identity, *until); err != nil {
+		log.Println("chat mute failed:", msg.RoomId, msg.Identity, err)
+		return
+	}
+	m.send(ctx, roomClient, &Event{Type: EventMuted, RoomId: msg.RoomId, Identity: msg.Identity, Violation: violation, Until: until})
+	err := m.audit.Record(ctx, &audit.Entry{
+		RoomId:  msg.RoomId,
+		Actor:   ActorName,
+		Action:  audit.ActionChatMute,
+		Target:  msg.Identity,
+		Reason:  string(violation),
+		Details: map[string]interface{}{"until": until.Unix()},
+	})
+	if err != nil {
+		log.Println("audit record failed:", msg.RoomId, audit.ActionChatMute, err)
+	}
+}
+
+// mute 음소거를 먼저 기록하고 (토큰을 갱신해도 until까지 데이터 전송 불가) 데이터 전송 권한 회수
+// 원래 권한이 없던 참가자는 회수하지 않았다고 기록해 해제할 때 권한을 주지 않는다.
+func (m *Moderator) mute(ctx context.Context, roomClient *lksdk.RoomServiceClient, roomId, identity string, until time.Time) error {
+	participant, err := roomClient.GetParticipant(ctx, &livekit.RoomParticipantIdentity{Room: roomId, Identity: identity})
+	if err != nil {
+		return err
+	}
+	granted := participant.Permission
+	if granted == nil {
+		granted = &livekit.ParticipantPermission{}
+	}
+	revoked := granted.CanPublishData
+	if err := m.permissions.Mute(ctx, roomId, identity, until, revoked); err != nil {
+		return err
+	}
+	if revoked {
+		if err := m.setCanPublishData(ctx, roomClient, roomId, identity, granted, false); err != nil {
+			return err
+		}
+	}
+
+	key := userKey{roomId: roomId, identity: identity}
+	m.mu.Lock()
+	if timer, ok := m.timers[key]; ok {
+		timer.Stop()
+	}
+	m.timers[key] = time.AfterFunc(time.Until(until), func() { m.unmute(key) })
+	m.mu.Unlock()
+	return nil
+}
+
+// unmute 음소거 기간이 끝나면 회수한 데이터 전송 권한만 복구
+// 그 사이 진행자가 데이터 전송 권한을 회수했으면 복구하지 않고, 이미 나간 참가자는 무시한다.
+func (m *Moderator) unmute(key userKey) {
+	m.mu.Lock()
+	delete(m.timers, key)
+	m.mu.Unlock()
+
+	ctx := context.Background()
+	muted, err := m.permissions.Unmute(ctx, key.roomId, key.identity)
+	if err != nil {
+		log.Println("chat unmute failed:", key.roomId, key.identity, err)
+		return
+	}
+	if muted == nil {
+		return
+	}
+
+	roomClient := m.roomClient()
+	if muted.Revoked {
+		override, err := m.permissions.Get(ctx, key.roomId, key.identity)
+		if err != nil {
+			log.Println("chat unmute failed:", key.roomId, key.identity, err)
+			return
+		}
+		if override == nil || override.CanPublishData == nil || *override.CanPublishData {
+			participant, err := roomClient.GetParticipant(ctx, &livekit.RoomParticipantIdentity{Room: key.roomId, Identity: key.identity})
+			if err == nil {
+				err = m.setCanPublishData(ctx, roomClient, key.roomId, key.identity, participant.Permission, true)
+			}
+			if isNotFound(err) {
+				return
+			}
+			if err != nil {
+				log.Println("chat unmute failed:", key.roomId, key.identity, err)
+				return
+			}
+		}
+	}
+	m.send(ctx, roomClient, &Event{Type: EventUnmuted, RoomId: key.roomId, Identity: key.identity})
+}
+
+// setCanPublishData 다른 권한은 유지 (조회한 응답 값을 그대로 수정)
+func (m *Moderator) setCanPublishData(ctx context.Context, roomClient *lksdk.RoomServiceClient, roomId, identity string, granted *livekit.ParticipantPermission, allowed bool) error {
+	if granted == nil {
+		granted = &livekit.ParticipantPermission{}
+	}
+	granted.CanPublishData = allowed
+	_, err := roomClient.UpdateParticipant(ctx, &livekit.UpdateParticipantRequest{
+		Room:       roomId,
+		Identity:   identity,
+		Permission: granted,
+	})
+	return err
+}
+
+// send 이벤트 전송 (identities가 없으면 룸 전체, 실패는 로그만 남김)
+func (m *Moderator) send(ctx context.Context, roomClient *lksdk.RoomServiceClient, event *Event, identities ...string) {
+	event.At = time.Now()
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Println("chat moderation event encode failed:", err)
+		return
+	}
+	topic := Topic
+	_, err = roomClient.SendData(ctx, &livekit.SendDataRequest{
+		Room:                  event.RoomId,
+		Data:                  data,
+		Kind:                  livekit.DataPacket_RELIABLE,
+		Topic:                 &topic,
+		DestinationIdentities: identities,
+	})
+	if err != nil {
+		log.Println("chat moderation event send failed:", event.RoomId, event.Type, err)
+	}
+}
+
+func (m *Moderator) roomClient() *lksdk.RoomServiceClient {
+	primary := m.keys.Primary()
+	return lksdk.NewRoomServiceClient(m.hostURL, primary.Key, primary.Secret)
+}
+
+// isNotFound LiveKit에 참가자가 없음 (이미 나감)
+func isNotFound(err error) bool {
+	var twerr twirp.Error
+	return errors.As(err, &twerr) && twerr.Code() == twirp.NotFound
+}
@@ -0,0 +1,61 @@
+package chatmod
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// strikeScript 기간이 지난 위반을 지우고 이번 위반을 더한 뒤 개수 반환
+//
+//	KEYS[1]  chatmod:<room>:strikes:<identity>  sorted set (score = 위반 시각 ms)
+//	ARGV     now(ms), window(ms), member
+var strikeScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', '(' .. (tonumber(ARGV[1]) - tonumber(ARGV[2])))
+redis.call('ZADD', KEYS[1], ARGV[1], ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return redis.call('ZCARD', KEYS[1])
+`)
+
+// RedisStore Redis 기반 슬로 모드/위반 기록 (인스턴스 간 공유, 키 TTL로 만료)
+//
+//	chatmod:<room>:pace:<identity>     슬로 모드 간격 동안 존재
+//	chatmod:<room>:strikes:<identity>  위반 시각 sorted set (위반 기간 동안 유지)
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func paceKey(roomId, identity string) string {
+	return "chatmod:" + roomId + ":pace:" + identity
+}
+
+func strikesKey(roomId, identity string) string {
+	return "chatmod:" + roomId + ":strikes:" + identity
+}
+
+func (s *RedisStore) Pace(ctx context.Context, roomId, identity string, interval time.Duration) (bool, error) {
+	return s.rdb.SetNX(ctx, paceKey(roomId, identity), 1, interval).Result()
+}
+
+func (s *RedisStore) Strike(ctx context.Context, roomId, identity string, at time.Time, window time.Duration) (int, error) {
+	if window <= 0 {
+		return 1, nil
+	}
+	member := strconv.FormatInt(at.UnixNano(), 10)
+	count, err := strikeScript.Run(ctx, s.rdb, []string{strikesKey(roomId, identity)}, at.UnixMilli(), window.Milliseconds(), member).Int()
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *RedisStore) Reset(ctx context.Context, roomId, identity string) error {
+	return s.rdb.Del(ctx, strikesKey(roomId, identity)).Err()
+}
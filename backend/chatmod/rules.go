@@ -0,0 +1,175 @@
+package chatmod
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Violation 채팅 규칙 위반 종류
+type Violation string
+
+const (
+	ViolationBlockedWord Violation = "blocked_word"
+	ViolationPattern     Violation = "pattern"
+	ViolationLink        Violation = "link"
+	ViolationSlowMode    Violation = "slow_mode"
+)
+
+// Config 채팅 관리 설정 (파일에 없는 항목은 기본값 유지)
+type Config struct {
+	Blocklist    []string      `yaml:"blocklist"`     // 금지어 (대소문자 무시, 영문 단어는 단어 단위로 비교)
+	Patterns     []string      `yaml:"patterns"`      // 금지 정규식
+	BlockLinks   bool          `yaml:"block_links"`   // URL/도메인 차단
+	SlowMode     time.Duration `yaml:"slow_mode"`     // 사용자별 최소 메시지 간격 (0이면 끔)
+	MaxStrikes   int           `yaml:"max_strikes"`   // strike_window 안에 이만큼 위반하면 일시 음소거 (0이면 끔)
+	StrikeWindow time.Duration `yaml:"strike_window"` // 위반 횟수를 세는 기간
+	MuteDuration time.Duration `yaml:"mute_duration"` // 일시 음소거 기간
+}
+
+// DefaultConfig 설정 파일이 없을 때 사용하는 기본값 (필터 없음, 반복 위반 시 음소거만 설정)
+func DefaultConfig() Config {
+	return Config{
+		MaxStrikes:   3,
+		StrikeWindow: 10 * time.Minute,
+		MuteDuration: 5 * time.Minute,
+	}
+}
+
+// linkPattern URL 또는 흔한 최상위 도메인으로 끝나는 도메인
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|io|co|kr|me|gg|tv|ly|xyz|app|dev)\b`)
+
+// ruleset 컴파일된 설정
+type ruleset struct {
+	Config
+	blocklist *regexp.Regexp // 금지어가 없으면 nil
+	patterns  []*regexp.Regexp
+}
+
+// Rules 채팅 규칙 조회 (SIGHUP으로 다시 읽음)
+type Rules struct {
+	mu    sync.RWMutex
+	path  string
+	rules *ruleset
+}
+
+// NewRules 생성자 - path가 비어있으면 기본값만 사용
+func NewRules(path string) (*Rules, error) {
+	r := &Rules{path: path}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload 설정 파일을 다시 읽어 규칙 교체
+func (r *Rules) Reload() error {
+	cfg := DefaultConfig()
+	if r.path != "" {
+		loaded, err := LoadFile(r.path)
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+	}
+	rules, err := compile(cfg)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.rules = rules
+	r.mu.Unlock()
+	return nil
+}
+
+// LoadFile YAML(JSON 포함) 설정 파일 로드
+func LoadFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("read chat moderation file: %w", err)
+	}
+	cfg := DefaultConfig()
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parse chat moderation file: %w", err)
+	}
+	if _, err := compile(cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Config 현재 설정
+func (r *Rules) Config() Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.rules.Config
+}
+
+// Check 메시지 내용 검사 (슬로 모드는 Moderator가 확인)
+func (r *Rules) Check(text string) (Violation, bool) {
+	r.mu.RLock()
+	rules := r.rules
+	r.mu.RUnlock()
+
+	if rules.blocklist != nil && rules.blocklist.MatchString(text) {
+		return ViolationBlockedWord, true
+	}
+	for _, pattern := range rules.patterns {
+		if pattern.MatchString(text) {
+			return ViolationPattern, true
+		}
+	}
+	if rules.BlockLinks && linkPattern.MatchString(text) {
+		return ViolationLink, true
+	}
+	return "", false
+}
+
+func compile(cfg Config) (*ruleset, error) {
+	if cfg.SlowMode < 0 || cfg.StrikeWindow < 0 || cfg.MuteDuration < 0 || cfg.MaxStrikes < 0 {
+		return nil, fmt.Errorf("chat moderation durations and max_strikes must not be negative")
+	}
+	rules := &ruleset{Config: cfg}
+
+	// 영문/숫자 금지어는 단어 경계로 비교 ("class"가 "ass"에 걸리지 않도록), 한글 등은 포함 여부로 비교
+	var terms []string
+	for _, word := range cfg.Blocklist {
+		word = strings.TrimSpace(word)
+		if word == "" {
+			continue
+		}
+		term := regexp.QuoteMeta(word)
+		if isASCIIWord(word) {
+			term = `\b` + term + `\b`
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) > 0 {
+		rules.blocklist = regexp.MustCompile(`(?i)(?:` + strings.Join(terms, "|") + `)`)
+	}
+
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+		rules.patterns = append(rules.patterns, re)
+	}
+	return rules, nil
+}
+
+func isASCIIWord(word string) bool {
+	for _, r := range word {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+// LiveKit은 실패한 웹훅을 재전송하므로 같은 이벤트 ID를 이 기간 동안 기억
+const dedupeWindow = 24 * time.Hour
+
+// Handler 이벤트 구독자
+type Handler func(ctx context.Context, event *livekit.WebhookEvent) error
+
+// Deduper 처리한 이벤트 ID 기록 (모든 구독자가 성공한 뒤에만 처리 완료로 기록)
+type Deduper interface {
+	// Processed 처리를 마친 ID인지
+	Processed(ctx context.Context, id string) (bool, error)
+	// MarkProcessed 처리 완료 기록 (dedupeWindow 동안 재전송을 건너뜀)
+	MarkProcessed(ctx context.Context, id string) error
+	// Handled 이전 전송에서 처리에 성공한 구독자 (일부 구독자가 실패해 재전송된 이벤트)
+	Handled(ctx context.Context, id string) (map[string]bool, error)
+	// MarkHandled 처리에 성공한 구독자 기록 (재전송되면 실패한 구독자만 다시 호출)
+	MarkHandled(ctx context.Context, id string, names ...string) error
+}
+
+type subscription struct {
+	name    string
+	types   map[string]bool // 비어 있으면 모든 이벤트
+	handler Handler
+}
+
+// Dispatcher 웹훅 이벤트를 내부 구독자에게 전달
+type Dispatcher struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+// NewDispatcher 생성자
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Subscribe 구독자 등록 (eventTypes는 webhook.EventRoomStarted 등, 없으면 모든 이벤트 수신, name은 로그용)
+func (d *Dispatcher) Subscribe(name string, handler Handler, eventTypes ...string) {
+	types := make(map[string]bool, len(eventTypes))
+	for _, t := range eventTypes {
+		types[t] = true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions = append(d.subscriptions, subscription{name: name, types: types, handler: handler})
+}
+
+// Dispatch 등록 순서대로 구독자 호출 (skip에 있는 구독자는 건너뜀)
+// 한 구독자가 실패해도 나머지 구독자는 호출하고, 성공한 구독자 이름과 실패한 구독자 오류를 모아 반환한다.
+func (d *Dispatcher) Dispatch(ctx context.Context, event *livekit.WebhookEvent, skip map[string]bool) ([]string, error) {
+	d.mu.RLock()
+	subscriptions := d.subscriptions
+	d.mu.RUnlock()
+
+	var handled []string
+	var errs []error
+	for _, s := range subscriptions {
+		if (len(s.types) > 0 && !s.types[event.Event]) || skip[s.name] {
+			continue
+		}
+		if err := s.handler(ctx, event); err != nil {
+			log.Printf("webhook subscriber %s failed on %s (%s): %v", s.name, event.Event, event.Id, err)
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+			continue
+		}
+		handled = append(handled, s.name)
+	}
+	return handled, errors.Join(errs...)
+}
+
+// RoomName 이벤트가 속한 룸 이름 (인그레스 이벤트는 인그레스의 룸)
+func RoomName(event *livekit.WebhookEvent) string {
+	if event.Room != nil && event.Room.Name != "" {
+		return event.Room.Name
+	}
+	if event.IngressInfo != nil {
+		return event.IngressInfo.RoomName
+	}
+	if event.EgressInfo != nil {
+		return event.EgressInfo.RoomName
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 기록 수가 이 값을 넘으면 만료된 ID 정리
+const sweepThreshold = 10000
+
+// memoryEvent 이벤트 ID별 처리 기록
+type memoryEvent struct {
+	processed bool
+	handled   map[string]bool // 처리에 성공한 구독자 (처리 완료 전)
+	expires   time.Time
+}
+
+// MemoryDeduper 인메모리 이벤트 ID 기록 (단일 인스턴스용)
+type MemoryDeduper struct {
+	mu     sync.Mutex
+	events map[string]*memoryEvent
+}
+
+// NewMemoryDeduper 생성자
+func NewMemoryDeduper() *MemoryDeduper {
+	return &MemoryDeduper{events: make(map[string]*memoryEvent)}
+}
+
+// event 만료되지 않은 기록 (create이면 없을 때 만듦, 호출자가 잠금)
+func (d *MemoryDeduper) event(id string, create bool) *memoryEvent {
+	now := time.Now()
+	if len(d.events) > sweepThreshold {
+		for k, e := range d.events {
+			if now.After(e.expires) {
+				delete(d.events, k)
+			}
+		}
+	}
+
+	e, ok := d.events[id]
+	if ok && now.Before(e.expires) {
+		return e
+	}
+	if !create {
+		return nil
+	}
+	e = &memoryEvent{handled: map[string]bool{}, expires: now.Add(dedupeWindow)}
+	d.events[id] = e
+	return e
+}
+
+func (d *MemoryDeduper) Processed(ctx context.Context, id string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e := d.event(id, false)
+	return e != nil && e.processed, nil
+}
+
+func (d *MemoryDeduper) MarkProcessed(ctx context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e := d.event(id, true)
+	e.processed, e.handled = true, nil
+	e.expires = time.Now().Add(dedupeWindow)
+	return nil
+}
+
+func (d *MemoryDeduper) Handled(ctx context.Context, id string) (map[string]bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	handled := map[string]bool{}
+	if e := d.event(id, false); e != nil {
+		for name := range e.handled {
+			handled[name] = true
+		}
+	}
+	return handled, nil
+}
+
+func (d *MemoryDeduper) MarkHandled(ctx context.Context, id string, names ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e := d.event(id, true)
+	for _, name := range names {
+		e.handled[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisDeduper Redis 기반 이벤트 ID 기록 (인스턴스 간 공유)
+//
+//	webhook:event:<id>          처리 완료
+//	webhook:event:<id>:handled  처리에 성공한 구독자 set (일부 구독자가 실패한 이벤트)
+type RedisDeduper struct {
+	rdb *redis.Client
+}
+
+// NewRedisDeduper 생성자
+func NewRedisDeduper(rdb *redis.Client) *RedisDeduper {
+	return &RedisDeduper{rdb: rdb}
+}
+
+func eventKey(id string) string {
+	return "webhook:event:" + id
+}
+
+func handledKey(id string) string {
+	return "webhook:event:" + id + ":handled"
+}
+
+func (d *RedisDeduper) Processed(ctx context.Context, id string) (bool, error) {
+	err := d.rdb.Get(ctx, eventKey(id)).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (d *RedisDeduper) MarkProcessed(ctx context.Context, id string) error {
+	_, err := d.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, eventKey(id), 1, dedupeWindow)
+		pipe.Del(ctx, handledKey(id))
+		return nil
+	})
+	return err
+}
+
+func (d *RedisDeduper) Handled(ctx context.Context, id string) (map[string]bool, error) {
+	names, err := d.rdb.SMembers(ctx, handledKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	handled := make(map[string]bool, len(names))
+	for _, name := range names {
+		handled[name] = true
+	}
+	return handled, nil
+}
+
+func (d *RedisDeduper) MarkHandled(ctx context.Context, id string, names ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	members := make([]interface{}, len(names))
+	for i, name := range names {
+		members[i] = name
+	}
+	_, err := d.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, handledKey(id), members...)
+		pipe.Expire(ctx, handledKey(id), dedupeWindow)
+		return nil
+	})
+	return err
+}
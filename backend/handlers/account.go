@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/account"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Signup/Login 요청/응답 구조체
+type SignupRequest struct {
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	DisplayName string `json:"display_name"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Session account.Session `json:"session"`
+	User    UserInfo        `json:"user"`
+}
+
+type UserInfo struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Role        string `json:"role,omitempty"`
+}
+
+// AccountHandler 구조체
+type AccountHandler struct {
+	accounts *account.Service
+	tickets  *account.Tickets
+}
+
+// NewAccountHandler 생성자
+func NewAccountHandler(accounts *account.Service, tickets *account.Tickets) *AccountHandler {
+	return &AccountHandler{
+		accounts: accounts,
+		tickets:  tickets,
+	}
+}
+
+// Signup 핸들러 - 로컬 계정 생성
+func (h *AccountHandler) Signup(c echo.Context) error {
+	var req SignupRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	user, err := h.accounts.Signup(c.Request().Context(), req.Username, req.Password, req.DisplayName)
+	switch {
+	case errors.Is(err, account.ErrInvalidUsername), errors.Is(err, account.ErrWeakPassword):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, account.ErrUserExists):
+		return echo.NewHTTPError(http.StatusConflict, "Username already taken")
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create account").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, toUserInfo(user))
+}
+
+// Login 핸들러 - 세션 토큰 발급
+func (h *AccountHandler) Login(c echo.Context) error {
+	var req LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	user, session, err := h.accounts.Login(c.Request().Context(), req.Username, req.Password)
+	if errors.Is(err, account.ErrInvalidCredentials) {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid username or password")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to login").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, LoginResponse{
+		Session: *session,
+		User:    toUserInfo(user),
+	})
+}
+
+// Me 핸들러 - 현재 로그인한 사용자 정보
+func (h *AccountHandler) Me(c echo.Context) error {
+	p := account.PrincipalFrom(c)
+	if p == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+
+	return c.JSON(http.StatusOK, UserInfo{
+		ID:          p.UserID,
+		Username:    p.Identity,
+		DisplayName: p.DisplayName,
+		Role:        p.Role,
+	})
+}
+
+// IssueStreamTicket 핸들러 - EventSource/WebSocket 연결용 1회용 티켓 발급
+// 클라이언트는 연결할 때마다 새 티켓을 받아 ?ticket=으로 전달
+func (h *AccountHandler) IssueStreamTicket(c echo.Context) error {
+	p := account.PrincipalFrom(c)
+	if p == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+
+	ticket, err := h.tickets.Issue(c.Request().Context(), p)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to issue stream ticket").SetInternal(err)
+	}
+	return c.JSON(http.StatusCreated, ticket)
+}
+
+func toUserInfo(user *account.User) UserInfo {
+	return UserInfo{
+		ID:          user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		Role:        user.Role,
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/analytics"
+	"backend/authz"
+	"backend/catalog"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 시계열 구간 (초)
+const (
+	defaultAnalyticsInterval = 60
+	minAnalyticsInterval     = 10
+	maxAnalyticsInterval     = 3600
+)
+
+// StreamAnalyticsResponse 스트림 시청 통계 응답
+type StreamAnalyticsResponse struct {
+	RoomId    string `json:"room_id"`
+	Live      bool   `json:"live"`
+	CreatedAt int64  `json:"created_at"`
+	EndedAt   int64  `json:"ended_at,omitempty"`
+	analytics.Report
+}
+
+// AnalyticsHandler 구조체
+type AnalyticsHandler struct {
+	recorder *analytics.Recorder
+	streams  catalog.Repository
+}
+
+// NewAnalyticsHandler 생성자
+func NewAnalyticsHandler(recorder *analytics.Recorder, streams catalog.Repository) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		recorder: recorder,
+		streams:  streams,
+	}
+}
+
+// GetStreamAnalytics 핸들러 - 최대/고유 시청자, 평균 시청 시간, 동시 시청자 시계열 (생성자/운영자/관리자, 종료 후에도 조회 가능)
+func (h *AnalyticsHandler) GetStreamAnalytics(c echo.Context) error {
+	roomId := c.Param("room_id")
+	if roomId == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Room id is required")
+	}
+	interval := defaultAnalyticsInterval
+	if v := c.QueryParam("interval"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < minAnalyticsInterval || n > maxAnalyticsInterval {
+			return echo.NewHTTPError(http.StatusBadRequest, "interval must be between 10 and 3600 seconds")
+		}
+		interval = n
+	}
+
+	ctx := c.Request().Context()
+	stream, err := h.streams.Get(ctx, roomId)
+	if errors.Is(err, catalog.ErrStreamNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get stream").SetInternal(err)
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(stream.CreatorIdentity) {
+		return authz.ErrForbidden()
+	}
+
+	// 종료된 스트림은 종료 시각까지, 진행 중이면 현재까지
+	until := time.Now()
+	if stream.EndedAt != nil {
+		until = *stream.EndedAt
+	}
+	report, err := h.recorder.Report(ctx, stream, until, time.Duration(interval)*time.Second)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load analytics").SetInternal(err)
+	}
+
+	res := StreamAnalyticsResponse{
+		RoomId:    roomId,
+		Live:      !stream.Ended(),
+		CreatedAt: stream.CreatedAt.Unix(),
+		Report:    report,
+	}
+	if stream.EndedAt != nil {
+		res.EndedAt = stream.EndedAt.Unix()
+	}
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"backend/account"
+	"backend/authz"
+	"backend/ban"
+	"backend/keyring"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// CreateBan 요청/응답 구조체 (identity, user_id, ip 중 하나 이상)
+type CreateBanRequest struct {
+	Identity  string `json:"identity,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	ExpiresIn int64  `json:"expires_in"` // 초 단위, 0이면 영구 차단
+}
+
+type CreateBanResponse struct {
+	Bans   []*ban.Ban `json:"bans"`
+	Kicked []string   `json:"kicked"` // 강제 퇴장된 룸 목록
+}
+
+type ListBansResponse struct {
+	Bans  []*ban.Ban `json:"bans"`
+	Total int        `json:"total"`
+}
+
+// BanHandler 구조체
+type BanHandler struct {
+	hostURL string
+	keys    *keyring.Ring
+	bans    *ban.Service
+}
+
+// NewBanHandler 생성자
+func NewBanHandler(hostURL string, keys *keyring.Ring, bans *ban.Service) *BanHandler {
+	return &BanHandler{
+		hostURL: hostURL,
+		keys:    keys,
+		bans:    bans,
+	}
+}
+
+// CreateRoomBan 핸들러 - 참가자 강제 퇴장 및 룸 차단 (생성자/운영자/관리자)
+func (h *BanHandler) CreateRoomBan(c echo.Context) error {
+	room, err := h.authorizeRoom(c)
+	if err != nil {
+		return err
+	}
+
+	var req CreateBanRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if req.Identity != "" && req.Identity == roomCreator(room) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot ban the stream creator")
+	}
+
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	return h.createBans(c, roomClient, room.Name, req, []string{room.Name})
+}
+
+// ListRoomBans 핸들러 - 룸 차단 목록
+func (h *BanHandler) ListRoomBans(c echo.Context) error {
+	room, err := h.authorizeRoom(c)
+	if err != nil {
+		return err
+	}
+	return h.listBans(c, room.Name)
+}
+
+// DeleteRoomBan 핸들러 - 룸 차단 해제
+func (h *BanHandler) DeleteRoomBan(c echo.Context) error {
+	room, err := h.authorizeRoom(c)
+	if err != nil {
+		return err
+	}
+	return h.deleteBan(c, room.Name)
+}
+
+// CreateGlobalBan 핸들러 - 모든 룸에서 강제 퇴장 및 전체 차단 (운영자/관리자)
+func (h *BanHandler) CreateGlobalBan(c echo.Context) error {
+	if err := requireModerator(c); err != nil {
+		return err
+	}
+
+	var req CreateBanRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	// identity 차단이면 참여 중인 모든 룸에서 퇴장
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	var rooms []string
+	if req.Identity != "" {
+		resp, err := roomClient.ListRooms(c.Request().Context(), &livekit.ListRoomsRequest{})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list rooms").SetInternal(err)
+		}
+		for _, room := range resp.Rooms {
+			rooms = append(rooms, room.Name)
+		}
+	}
+
+	return h.createBans(c, roomClient, "", req, rooms)
+}
+
+// ListGlobalBans 핸들러 - 전체 차단 목록
+func (h *BanHandler) ListGlobalBans(c echo.Context) error {
+	if err := requireModerator(c); err != nil {
+		return err
+	}
+	return h.listBans(c, "")
+}
+
+// DeleteGlobalBan 핸들러 - 전체 차단 해제
+func (h *BanHandler) DeleteGlobalBan(c echo.Context) error {
+	if err := requireModerator(c); err != nil {
+		return err
+	}
+	return h.deleteBan(c, "")
+}
+
+// createBans 요청된 대상별로 차단 등록 후 identity는 룸에서 퇴장시킴
+func (h *BanHandler) createBans(c echo.Context, roomClient *lksdk.RoomServiceClient, roomId string, req CreateBanRequest, kickFrom []string) error {
+	if req.Identity == "" && req.UserID == "" && req.IP == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "One of identity, user_id or ip is required")
+	}
+	if req.ExpiresIn < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "expires_in must not be negative")
+	}
+	ttl := time.Duration(req.ExpiresIn) * time.Second
+
+	createdBy := "admin"
+	if actor := authz.ActorFrom(c); actor.Principal != nil {
+		createdBy = actor.Principal.Identity
+		if (req.Identity != "" && req.Identity == createdBy) || (req.UserID != "" && req.UserID == actor.Principal.UserID) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Cannot ban yourself")
+		}
+	}
+
+	ctx := c.Request().Context()
+	var created []*ban.Ban
+	for _, target := range []struct {
+		kind  ban.Kind
+		value string
+	}{
+		{ban.KindIdentity, req.Identity},
+		{ban.KindAccount, req.UserID},
+		{ban.KindIP, req.IP},
+	} {
+		if target.value == "" {
+			continue
+		}
+		b, err := h.bans.Ban(ctx, roomId, target.kind, target.value, req.Reason, createdBy, ttl)
+		if errors.Is(err, ban.ErrInvalidSubject) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create ban").SetInternal(err)
+		}
+		created = append(created, b)
+	}
+
+	// 이미 접속한 참가자 퇴장 (없는 참가자는 무시)
+	kicked := []string{}
+	if req.Identity != "" {
+		for _, room := range kickFrom {
+			_, err := roomClient.RemoveParticipant(context.Background(), &livekit.RoomParticipantIdentity{
+				Room:     room,
+				Identity: req.Identity,
+			})
+			if err == nil {
+				kicked = append(kicked, room)
+			}
+		}
+	}
+
+	return c.JSON(http.StatusCreated, CreateBanResponse{
+		Bans:   created,
+		Kicked: kicked,
+	})
+}
+
+func (h *BanHandler) listBans(c echo.Context, roomId string) error {
+	bans, err := h.bans.List(c.Request().Context(), roomId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list bans").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, ListBansResponse{
+		Bans:  bans,
+		Total: len(bans),
+	})
+}
+
+func (h *BanHandler) deleteBan(c echo.Context, roomId string) error {
+	banId := c.Param("ban_id")
+	err := h.bans.Unban(c.Request().Context(), roomId, banId)
+	if errors.Is(err, ban.ErrBanNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Ban not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to remove ban").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Ban removed successfully",
+		"ban_id":  banId,
+	})
+}
+
+// authorizeRoom 룸 존재 및 관리 권한 확인
+func (h *BanHandler) authorizeRoom(c echo.Context) (*livekit.Room, error) {
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	room, err := findRoom(c.Request().Context(), roomClient, c.Param("room_id"))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if room == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(roomCreator(room)) {
+		return nil, authz.ErrForbidden()
+	}
+	return room, nil
+}
+
+// requireModerator 전체 차단은 운영자 계정 또는 관리자 키만 가능
+func requireModerator(c echo.Context) error {
+	if actor := authz.ActorFrom(c); actor == nil || !(actor.Admin || actor.IsModerator()) {
+		return authz.ErrForbidden()
+	}
+	return nil
+}
+
+// checkBan 토큰 발급 전 차단 여부 확인 (차단되었으면 403)
+func checkBan(c echo.Context, bans *ban.Service, roomId string, principal *account.Principal) error {
+	b, err := bans.Check(c.Request().Context(), roomId, ban.Subject{
+		Identity: principal.Identity,
+		UserID:   principal.UserID,
+		IP:       c.RealIP(),
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check bans").SetInternal(err)
+	}
+	if b == nil {
+		return nil
+	}
+	if b.Global() {
+		return echo.NewHTTPError(http.StatusForbidden, "You are banned")
+	}
+	return echo.NewHTTPError(http.StatusForbidden, "You are banned from this stream")
+}
+
+// EnforceBans 웹훅 구독자 - 차단 전에 발급된 토큰으로 재접속한 참가자를 강제 퇴장
+func (h *BanHandler) EnforceBans(ctx context.Context, event *livekit.WebhookEvent) error {
+	if event.Room == nil || event.Participant == nil {
+		return nil
+	}
+	b, err := h.bans.Check(ctx, event.Room.Name, ban.Subject{Identity: event.Participant.Identity})
+	if err != nil || b == nil {
+		return err
+	}
+	_, err = newRoomClient(h.hostURL, h.keys).RemoveParticipant(ctx, &livekit.RoomParticipantIdentity{
+		Room:     event.Room.Name,
+		Identity: event.Participant.Identity,
+	})
+	return err
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"backend/account"
+	"backend/catalog"
+	"backend/chat"
+	"backend/keyring"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+)
+
+// 채팅 기록 조회 개수
+const (
+	defaultChatLimit = 50
+	maxChatLimit     = 1000
+)
+
+// ChatHistoryResponse 채팅 기록 (오래된 순)
+type ChatHistoryResponse struct {
+	RoomId   string          `json:"room_id"`
+	Messages []*chat.Message `json:"messages"`
+	HasMore  bool            `json:"has_more"`
+	// 이전 메시지를 읽을 때 before로 넘길 값 (has_more일 때만)
+	NextBefore int64 `json:"next_before,omitempty"`
+}
+
+// ChatHandler 구조체
+type ChatHandler struct {
+	hostURL  string
+	keys     *keyring.Ring
+	streams  catalog.Repository
+	recorder *chat.Recorder
+}
+
+// NewChatHandler 생성자
+func NewChatHandler(hostURL string, keys *keyring.Ring, streams catalog.Repository, recorder *chat.Recorder) *ChatHandler {
+	return &ChatHandler{
+		hostURL:  hostURL,
+		keys:     keys,
+		streams:  streams,
+		recorder: recorder,
+	}
+}
+
+// GetChatHistory 핸들러 - 채팅 스크롤백/내보내기 (before 순번 이전 메시지 중 최신 limit개, 종료 후에도 조회 가능)
+// 비공개 스트림은 생성자/운영자와 현재 룸에 접속한 참가자만 조회할 수 있다.
+func (h *ChatHandler) GetChatHistory(c echo.Context) error {
+	limit := defaultChatLimit
+	if v := c.QueryParam("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxChatLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 1000")
+		}
+		limit = n
+	}
+	var before int64
+	if v := c.QueryParam("before"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "before must be a message seq")
+		}
+		before = n
+	}
+
+	ctx := c.Request().Context()
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	stream, room, err := findStream(ctx, h.streams, roomClient, c.Param("room_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if stream == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+	if stream.IsPrivate() {
+		principal := account.PrincipalFrom(c)
+		allowed := canSeePrivate(principal, stream.CreatorIdentity)
+		if !allowed && principal != nil && room != nil {
+			_, err := roomClient.GetParticipant(ctx, &livekit.RoomParticipantIdentity{Room: room.Name, Identity: principal.Identity})
+			allowed = err == nil
+		}
+		if !allowed {
+			return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+		}
+	}
+
+	messages, more, err := h.recorder.History(ctx, stream.RoomId, before, limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load chat history").SetInternal(err)
+	}
+	res := ChatHistoryResponse{
+		RoomId:   stream.RoomId,
+		Messages: messages,
+		HasMore:  more,
+	}
+	if more && len(messages) > 0 {
+		res.NextBefore = messages[0].Seq
+	}
+	return c.JSON(http.StatusOK, res)
+}
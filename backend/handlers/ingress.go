@@ -3,19 +3,27 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"backend/account"
+	"backend/authz"
+	"backend/catalog"
+	"backend/keyring"
+	"backend/lifecycle"
+	"backend/policy"
+
 	"github.com/labstack/echo/v4"
-	"github.com/livekit/protocol/auth"
 	"github.com/livekit/protocol/livekit"
 	lksdk "github.com/livekit/server-sdk-go/v2"
 )
 
 // LiveKit Ingress API 요청/응답 구조체
 type CreateIngressRequest struct {
-	RoomName    string                 `json:"room_name"`
+	RoomName    string                 `json:"room_name"` // 비우면 새 스트림, 지정하면 본인이 만든 진행 중인 스트림에 연결
 	IngressType string                 `json:"ingress_type"`
 	Metadata    map[string]interface{} `json:"metadata"`
 }
@@ -51,19 +59,26 @@ type IngressInfo struct {
 	CreatedAt           string `json:"createdAt"`
 }
 
+// ingressIdentitySuffix 인그레스 참가자 identity 접미사 (호스트 identity와 구분)
+const ingressIdentitySuffix = " (via OBS)"
+
 // IngressHandler 구조체
 type IngressHandler struct {
-	hostURL   string
-	apiKey    string
-	apiSecret string
+	hostURL  string
+	keys     *keyring.Ring
+	policies *policy.Engine
+	streams  catalog.Repository
+	states   *lifecycle.Machine
 }
 
 // NewIngressHandler 생성자
-func NewIngressHandler(hostURL, apiKey, apiSecret string) *IngressHandler {
+func NewIngressHandler(hostURL string, keys *keyring.Ring, policies *policy.Engine, streams catalog.Repository, states *lifecycle.Machine) *IngressHandler {
 	return &IngressHandler{
-		hostURL:   hostURL,
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
+		hostURL:  hostURL,
+		keys:     keys,
+		policies: policies,
+		streams:  streams,
+		states:   states,
 	}
 }
 
@@ -75,35 +90,35 @@ func (h *IngressHandler) CreateIngress(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
+	// creator_identity는 클라이언트 값 대신 로그인한 사용자로 고정
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+	creatorIdentity := principal.Identity
+	if req.Metadata == nil {
+		req.Metadata = map[string]interface{}{}
+	}
+	req.Metadata["creator_identity"] = creatorIdentity
+
 	// 1. LiveKit Room Service 클라이언트 생성
-	roomClient := lksdk.NewRoomServiceClient(h.hostURL, h.apiKey, h.apiSecret)
+	roomClient := newRoomClient(h.hostURL, h.keys)
 	// 3. Ingress 생성
-	ingressClient := lksdk.NewIngressClient(h.hostURL, h.apiKey, h.apiSecret)
-
-	// 2. 방 생성
-	roomName := req.RoomName
-	if roomName == "" {
-		roomName = fmt.Sprintf("room-%d", time.Now().Unix())
-	}
+	ingressClient := newIngressClient(h.hostURL, h.keys)
 
-	metadata, _ := json.Marshal(req.Metadata)
-	room, err := roomClient.CreateRoom(context.Background(), &livekit.CreateRoomRequest{
-		Name:     roomName,
-		Metadata: string(metadata),
-	})
+	// 2. 방 생성 (room_name을 지정하면 본인이 만든 진행 중인 스트림에만 연결)
+	roomName, err := h.prepareRoom(c, roomClient, req.RoomName, req.Metadata)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create room")
+		return err
 	}
 
 	ingressOptions := &livekit.CreateIngressRequest{
 		Name:                roomName,
 		RoomName:            roomName,
-		ParticipantName:     req.Metadata["creator_identity"].(string) + " (via OBS)",
-		ParticipantIdentity: req.Metadata["creator_identity"].(string) + " (via OBS)",
+		ParticipantName:     creatorIdentity + ingressIdentitySuffix,
+		ParticipantIdentity: creatorIdentity + ingressIdentitySuffix,
 	}
 
-	fmt.Println("[TEST DEBUG] room: ", room)
-
 	if req.IngressType == "whip" {
 		ingressOptions.BypassTranscoding = true
 	} else {
@@ -120,32 +135,50 @@ func (h *IngressHandler) CreateIngress(c echo.Context) error {
 
 	ingress, err := ingressClient.CreateIngress(context.Background(), ingressOptions)
 	if err != nil {
+		if req.RoomName == "" {
+			abandonStream(context.Background(), h.states, roomName)
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create ingress")
 	}
 
+	// 스트림 삭제 시 함께 정리되도록 카탈로그에 인그레스 기록
+	ingressType := "rtmp"
+	if req.IngressType == "whip" {
+		ingressType = "whip"
+	}
+	_, err = h.states.Update(c.Request().Context(), roomName, func(stream *catalog.Stream) error {
+		stream.Ingress = &catalog.Ingress{Type: ingressType, IngressId: ingress.IngressId, URL: ingress.Url, StreamKey: ingress.StreamKey}
+		return nil
+	})
+	if err != nil {
+		ingressClient.DeleteIngress(context.Background(), &livekit.DeleteIngressRequest{IngressId: ingress.IngressId})
+		if req.RoomName == "" {
+			abandonStream(context.Background(), h.states, roomName)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save stream").SetInternal(err)
+	}
+
 	fmt.Println("[TEST DEBUG] ingress: ", ingress)
 
-	// 4. 시청자용 토큰 생성
-	viewerToken := auth.NewAccessToken(h.apiKey, h.apiSecret)
-	viewerToken.SetVideoGrant(&auth.VideoGrant{
-		Room:           roomName,
-		RoomJoin:       true,
-		CanPublish:     &[]bool{false}[0],
-		CanSubscribe:   &[]bool{true}[0],
-		CanPublishData: &[]bool{true}[0],
-	})
-	viewerToken.SetIdentity(req.Metadata["creator_identity"].(string))
-	viewerToken.SetValidFor(time.Hour)
+	// 4. 호스트용 토큰 생성 (미디어는 인그레스가 발행하므로 호스트 정책을 그대로 사용)
+	primary := h.keys.Primary()
+	hostToken, err := h.policies.NewAccessToken(primary.Key, primary.Secret, policy.RoleHost, roomName, creatorIdentity)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply grant policy").SetInternal(err)
+	}
+	hostToken.SetName(principal.DisplayName)
+	token, err := hostToken.ToJWT()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate LiveKit token")
+	}
 
 	// 5. 응답 생성
 	response := CreateIngressResponse{}
 	response.Ingress.URL = ingress.Url
 	response.Ingress.StreamKey = ingress.StreamKey
 	response.Ingress.RoomName = roomName
-	response.AuthToken = h.createAuthToken(roomName, req.Metadata["creator_identity"].(string))
+	response.AuthToken = token
 	response.ConnectionDetails.WSURL = "wss://localhost:7880"
-
-	token, _ := viewerToken.ToJWT()
 	response.ConnectionDetails.Token = token
 
 	fmt.Printf("Created ingress for room: %s, URL: %s\n", roomName, ingress.Url)
@@ -153,24 +186,60 @@ func (h *IngressHandler) CreateIngress(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
-// createAuthToken 헬퍼 함수
-func (h *IngressHandler) createAuthToken(room, identity string) string {
-	at := auth.NewAccessToken(h.apiKey, h.apiSecret)
-	grant := &auth.VideoGrant{
-		RoomJoin: true,
-		Room:     room,
+// prepareRoom 인그레스를 연결할 룸 준비
+// room_name이 없으면 카탈로그에 새 이름을 확보한 뒤 룸 생성,
+// 있으면 호출자가 생성자인 진행 중인 스트림만 허용 (다른 사람의 룸에 호스트 토큰을 받지 않도록)
+func (h *IngressHandler) prepareRoom(c echo.Context, roomClient *lksdk.RoomServiceClient, roomName string, metadata map[string]interface{}) (string, error) {
+	ctx := c.Request().Context()
+	principal := account.PrincipalFrom(c)
+
+	if roomName != "" {
+		stream, room, err := findStream(ctx, h.streams, roomClient, roomName)
+		if err != nil {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to get stream").SetInternal(err)
+		}
+		if stream == nil {
+			return "", echo.NewHTTPError(http.StatusNotFound, "Stream not found")
+		}
+		if stream.CreatorIdentity != principal.Identity {
+			return "", echo.NewHTTPError(http.StatusForbidden, "Only the stream creator can add an ingress")
+		}
+		if room == nil || stream.Ended() {
+			return "", echo.NewHTTPError(http.StatusConflict, "Stream is not live")
+		}
+		if stream.Ingress != nil && stream.Ingress.IngressId != "" {
+			return "", echo.NewHTTPError(http.StatusConflict, "Stream already has an ingress")
+		}
+		// 카탈로그 기록 없이 메타데이터로 복원한 룸은 인그레스를 기록할 수 있도록 저장
+		if err := h.states.Create(ctx, stream); err != nil && !errors.Is(err, catalog.ErrStreamExists) {
+			return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to save stream").SetInternal(err)
+		}
+		return roomName, nil
 	}
-	at.SetVideoGrant(grant).
-		SetIdentity(identity).
-		SetValidFor(time.Hour)
 
-	token, _ := at.ToJWT()
-	return token
+	stream := &catalog.Stream{
+		CreatorIdentity: principal.Identity,
+		Metadata:        metadata,
+		CreatedAt:       time.Now(),
+	}
+	if err := reserveStream(ctx, h.states, stream); err != nil {
+		return "", err
+	}
+	metadataJSON, _ := json.Marshal(metadata)
+	_, err := roomClient.CreateRoom(context.Background(), &livekit.CreateRoomRequest{
+		Name:     stream.RoomId,
+		Metadata: string(metadataJSON),
+	})
+	if err != nil {
+		abandonStream(context.Background(), h.states, stream.RoomId)
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to create room")
+	}
+	return stream.RoomId, nil
 }
 
 // ListIngress 핸들러 - 모든 Ingress 조회
 func (h *IngressHandler) ListIngress(c echo.Context) error {
-	ingressClient := lksdk.NewIngressClient(h.hostURL, h.apiKey, h.apiSecret)
+	ingressClient := newIngressClient(h.hostURL, h.keys)
 
 	// 모든 Ingress 조회
 	ingresses, err := ingressClient.ListIngress(context.Background(), &livekit.ListIngressRequest{})
@@ -210,7 +279,7 @@ func (h *IngressHandler) GetIngress(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Ingress ID is required")
 	}
 
-	ingressClient := lksdk.NewIngressClient(h.hostURL, h.apiKey, h.apiSecret)
+	ingressClient := newIngressClient(h.hostURL, h.keys)
 
 	// 모든 Ingress 조회 후 ID로 필터링
 	ingresses, err := ingressClient.ListIngress(context.Background(), &livekit.ListIngressRequest{})
@@ -247,10 +316,28 @@ func (h *IngressHandler) DeleteIngress(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Ingress ID is required")
 	}
 
-	ingressClient := lksdk.NewIngressClient(h.hostURL, h.apiKey, h.apiSecret)
+	ingressClient := newIngressClient(h.hostURL, h.keys)
+
+	// 생성자/운영자/관리자만 삭제 가능
+	ingresses, err := ingressClient.ListIngress(c.Request().Context(), &livekit.ListIngressRequest{
+		IngressId: ingressId,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get ingress").SetInternal(err)
+	}
+	if len(ingresses.Items) == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Ingress not found")
+	}
+	creator, err := h.ingressCreator(c.Request().Context(), ingresses.Items[0])
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(creator) {
+		return authz.ErrForbidden()
+	}
 
 	// Ingress 삭제
-	_, err := ingressClient.DeleteIngress(context.Background(), &livekit.DeleteIngressRequest{
+	_, err = ingressClient.DeleteIngress(context.Background(), &livekit.DeleteIngressRequest{
 		IngressId: ingressId,
 	})
 	if err != nil {
@@ -262,3 +349,17 @@ func (h *IngressHandler) DeleteIngress(c echo.Context) error {
 		"ingressId": ingressId,
 	})
 }
+
+// ingressCreator 인그레스 생성자 조회
+// 카탈로그(없으면 룸 메타데이터)의 creator_identity를 우선 사용하고, 둘 다 없으면 인그레스 참가자 identity에서 복원
+func (h *IngressHandler) ingressCreator(ctx context.Context, ingress *livekit.IngressInfo) (string, error) {
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	stream, _, err := findStream(ctx, h.streams, roomClient, ingress.RoomName)
+	if err != nil {
+		return "", err
+	}
+	if stream != nil {
+		return stream.CreatorIdentity, nil
+	}
+	return strings.TrimSuffix(ingress.ParticipantIdentity, ingressIdentitySuffix), nil
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"backend/authz"
+	"backend/catalog"
+	"backend/invite"
+	"backend/keyring"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultInviteTTL = 24 * time.Hour
+	maxInviteTTL     = 30 * 24 * time.Hour
+)
+
+// CreateInvite 요청/응답 구조체
+type CreateInviteRequest struct {
+	ExpiresIn int64 `json:"expires_in"` // 초 단위, 0이면 24시간
+	MaxUses   int   `json:"max_uses"`   // 0이면 무제한
+}
+
+type CreateInviteResponse struct {
+	Invite *invite.Invite `json:"invite"`
+	Code   string         `json:"code"`
+}
+
+type ListInvitesResponse struct {
+	Invites []*invite.Invite `json:"invites"`
+	Total   int              `json:"total"`
+}
+
+// InviteHandler 구조체
+type InviteHandler struct {
+	hostURL string
+	keys    *keyring.Ring
+	streams catalog.Repository
+	invites *invite.Service
+}
+
+// NewInviteHandler 생성자
+func NewInviteHandler(hostURL string, keys *keyring.Ring, streams catalog.Repository, invites *invite.Service) *InviteHandler {
+	return &InviteHandler{
+		hostURL: hostURL,
+		keys:    keys,
+		streams: streams,
+		invites: invites,
+	}
+}
+
+// CreateInvite 핸들러 - 비공개 스트림 초대 코드 발급 (생성자/운영자/관리자)
+func (h *InviteHandler) CreateInvite(c echo.Context) error {
+	roomId, err := h.authorizeRoom(c)
+	if err != nil {
+		return err
+	}
+
+	var req CreateInviteRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	ttl := time.Duration(req.ExpiresIn) * time.Second
+	if ttl == 0 {
+		ttl = defaultInviteTTL
+	}
+	if ttl < 0 || ttl > maxInviteTTL {
+		return echo.NewHTTPError(http.StatusBadRequest, "expires_in must be between 1 second and 30 days")
+	}
+	if req.MaxUses < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "max_uses must not be negative")
+	}
+
+	createdBy := "admin"
+	if actor := authz.ActorFrom(c); actor.Principal != nil {
+		createdBy = actor.Principal.Identity
+	}
+
+	inv, code, err := h.invites.Mint(c.Request().Context(), roomId, createdBy, ttl, req.MaxUses)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create invite").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, CreateInviteResponse{
+		Invite: inv,
+		Code:   code,
+	})
+}
+
+// ListInvites 핸들러 - 룸의 초대 목록
+func (h *InviteHandler) ListInvites(c echo.Context) error {
+	roomId, err := h.authorizeRoom(c)
+	if err != nil {
+		return err
+	}
+
+	invites, err := h.invites.List(c.Request().Context(), roomId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list invites").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, ListInvitesResponse{
+		Invites: invites,
+		Total:   len(invites),
+	})
+}
+
+// RevokeInvite 핸들러 - 초대 회수
+func (h *InviteHandler) RevokeInvite(c echo.Context) error {
+	roomId, err := h.authorizeRoom(c)
+	if err != nil {
+		return err
+	}
+
+	inviteId := c.Param("invite_id")
+	err = h.invites.Revoke(c.Request().Context(), roomId, inviteId)
+	if errors.Is(err, invite.ErrInviteNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Invite not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to revoke invite").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message":   "Invite revoked successfully",
+		"invite_id": inviteId,
+	})
+}
+
+// authorizeRoom 스트림 존재 및 관리 권한 확인 후 room_id 반환
+// 카탈로그 기준이라 아직 LiveKit 룸이 열리지 않은 예약 비공개 스트림도 미리 초대 가능
+func (h *InviteHandler) authorizeRoom(c echo.Context) (string, error) {
+	roomId := c.Param("room_id")
+	if roomId == "" {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "Room id is required")
+	}
+
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	stream, _, err := findStream(c.Request().Context(), h.streams, roomClient, roomId)
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusInternalServerError, "Failed to get stream").SetInternal(err)
+	}
+	if stream == nil {
+		return "", echo.NewHTTPError(http.StatusNotFound, "Stream not found")
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(stream.CreatorIdentity) {
+		return "", authz.ErrForbidden()
+	}
+	return roomId, nil
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"backend/account"
+	"backend/catalog"
+	"backend/lifecycle"
+	"backend/lobby"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+)
+
+// 프록시/로드밸런서가 유휴 연결을 끊지 않도록 보내는 주기
+const lobbyHeartbeat = 25 * time.Second
+
+// 인증은 쿠키가 아니라 /api/auth/stream-ticket으로 받은 1회용 티켓(?ticket=)으로만 하므로
+// 다른 출처의 페이지가 사용자 대신 접속할 수 없음 - 다른 출처의 접속도 허용 (CORS도 *)
+var lobbyUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// LobbyEvent 클라이언트로 보내는 로비 이벤트
+type LobbyEvent struct {
+	ID     string          `json:"id,omitempty"`
+	Type   string          `json:"type"`
+	RoomId string          `json:"room_id,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	At     int64           `json:"at,omitempty"`
+}
+
+// ViewersChanged viewers.changed 이벤트 데이터
+type ViewersChanged struct {
+	NumParticipants uint32 `json:"num_participants"`
+}
+
+// LobbyHandler 구조체
+type LobbyHandler struct {
+	hub *lobby.Hub
+}
+
+// NewLobbyHandler 생성자
+func NewLobbyHandler(hub *lobby.Hub) *LobbyHandler {
+	return &LobbyHandler{hub: hub}
+}
+
+// StreamEvents 핸들러 - 로비 이벤트 SSE (Last-Event-ID 헤더 또는 last_event_id 쿼리로 재개)
+func (h *LobbyHandler) StreamEvents(c echo.Context) error {
+	lastID := c.Request().Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.QueryParam("last_event_id")
+	}
+	sub, backlog, err := h.hub.Subscribe(c.Request().Context(), lastID)
+	if err != nil && !errors.Is(err, lobby.ErrGap) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to subscribe").SetInternal(err)
+	}
+	defer sub.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // nginx 버퍼링 해제
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	send := func(event LobbyEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if event.ID != "" {
+			fmt.Fprintf(res, "id: %s\n", event.ID)
+		}
+		if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+	heartbeat := func() error {
+		if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+	return h.serve(c.Request().Context(), account.PrincipalFrom(c), sub, backlog, lastID, err != nil, send, heartbeat)
+}
+
+// StreamEventsWS 핸들러 - 로비 이벤트 WebSocket (last_event_id 쿼리로 재개)
+func (h *LobbyHandler) StreamEventsWS(c echo.Context) error {
+	lastID := c.QueryParam("last_event_id")
+	sub, backlog, err := h.hub.Subscribe(c.Request().Context(), lastID)
+	if err != nil && !errors.Is(err, lobby.ErrGap) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to subscribe").SetInternal(err)
+	}
+	defer sub.Close()
+	gap := err != nil
+
+	conn, err := lobbyUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return nil // Upgrade가 이미 오류 응답을 보냄
+	}
+	defer conn.Close()
+
+	// 클라이언트 메시지는 무시하고 연결 종료만 감지
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(event LobbyEvent) error {
+		return conn.WriteJSON(event)
+	}
+	heartbeat := func() error {
+		return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+	}
+	return h.serve(ctx, account.PrincipalFrom(c), sub, backlog, lastID, gap, send, heartbeat)
+}
+
+// serve 보관 이벤트와 실시간 이벤트를 순서대로 전송 (비공개 스트림은 생성자/운영자에게만)
+func (h *LobbyHandler) serve(ctx context.Context, principal *account.Principal, sub *lobby.Subscription, backlog []*lobby.Event, lastID string, gap bool, send func(LobbyEvent) error, heartbeat func() error) error {
+	deliver := func(event *lobby.Event) error {
+		if lobby.Skip(event, lastID) {
+			return nil
+		}
+		lastID = event.ID
+		if event.Private && !canSeePrivate(principal, event.CreatorIdentity) {
+			return nil
+		}
+		return send(LobbyEvent{
+			ID:     event.ID,
+			Type:   event.Type,
+			RoomId: event.RoomId,
+			Data:   event.Data,
+			At:     event.At.Unix(),
+		})
+	}
+
+	if gap {
+		if err := send(LobbyEvent{Type: lobby.TypeReset}); err != nil {
+			return nil
+		}
+	}
+	for _, event := range backlog {
+		if err := deliver(event); err != nil {
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(lobbyHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := heartbeat(); err != nil {
+				return nil
+			}
+		case event, ok := <-sub.Events:
+			if !ok {
+				// 따라오지 못해 끊김, 클라이언트는 마지막 ID로 재접속
+				return nil
+			}
+			if err := deliver(event); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// StreamChanged 스트림 생성/상태 변경을 로비 이벤트로 발행 (lifecycle.Machine.OnChange)
+func (h *LobbyHandler) StreamChanged(ctx context.Context, stream *catalog.Stream) {
+	eventType := lobby.TypeStreamUpdated
+	switch {
+	case lifecycle.Created(stream):
+		eventType = lobby.TypeStreamCreated
+	case stream.Ended():
+		eventType = lobby.TypeStreamEnded
+	}
+	err := h.hub.Publish(ctx, &lobby.Event{
+		Type:            eventType,
+		RoomId:          stream.RoomId,
+		Private:         stream.IsPrivate(),
+		CreatorIdentity: stream.CreatorIdentity,
+	}, newStreamInfo(stream))
+	if err != nil {
+		log.Println("lobby publish failed:", err)
+	}
+}
+
+// HandleWebhook 웹훅 구독자 - 참가자 입장/퇴장 시 시청자 수 발행
+func (h *LobbyHandler) HandleWebhook(ctx context.Context, event *livekit.WebhookEvent) error {
+	if event.Room == nil || (event.Event != webhook.EventParticipantJoined && event.Event != webhook.EventParticipantLeft) {
+		return nil
+	}
+	stream := catalog.FromRoom(event.Room)
+	return h.hub.Publish(ctx, &lobby.Event{
+		Type:            lobby.TypeViewersChanged,
+		RoomId:          event.Room.Name,
+		Private:         stream.IsPrivate(),
+		CreatorIdentity: stream.CreatorIdentity,
+	}, ViewersChanged{NumParticipants: event.Room.NumParticipants})
+}
@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend/audit"
+	"backend/authz"
+	"backend/catalog"
+	"backend/keyring"
+	"backend/permission"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// 관리 기록 조회 개수
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// MuteParticipant 요청 구조체 (track_sid, source 모두 없으면 발행 중인 모든 트랙)
+type MuteParticipantRequest struct {
+	TrackSid string `json:"track_sid,omitempty"`
+	Source   string `json:"source,omitempty"` // camera, microphone, screen_share, screen_share_audio
+	Muted    *bool  `json:"muted,omitempty"`  // 기본 true, false면 음소거 해제
+	Reason   string `json:"reason,omitempty"`
+}
+
+type MuteParticipantResponse struct {
+	Identity string   `json:"identity"`
+	Muted    bool     `json:"muted"`
+	Tracks   []string `json:"tracks"` // 변경된 트랙 SID
+}
+
+// UpdatePermissions 요청 구조체 (지정한 항목만 변경)
+type UpdatePermissionsRequest struct {
+	CanSubscribe      *bool     `json:"can_subscribe,omitempty"`
+	CanPublish        *bool     `json:"can_publish,omitempty"` // false면 발행 중인 트랙도 내려감
+	CanPublishData    *bool     `json:"can_publish_data,omitempty"`
+	CanPublishSources *[]string `json:"can_publish_sources,omitempty"` // 빈 배열이면 모든 소스 허용
+	Reason            string    `json:"reason,omitempty"`
+}
+
+// ParticipantPermissions 참가자 권한
+type ParticipantPermissions struct {
+	CanSubscribe      bool     `json:"can_subscribe"`
+	CanPublish        bool     `json:"can_publish"`
+	CanPublishData    bool     `json:"can_publish_data"`
+	CanPublishSources []string `json:"can_publish_sources"`
+}
+
+type UpdatePermissionsResponse struct {
+	Identity    string                 `json:"identity"`
+	Permissions ParticipantPermissions `json:"permissions"`
+}
+
+type ListAuditResponse struct {
+	Entries []*audit.Entry `json:"entries"`
+	Total   int            `json:"total"`
+}
+
+// ModerationHandler 구조체
+type ModerationHandler struct {
+	hostURL     string
+	keys        *keyring.Ring
+	streams     catalog.Repository
+	audit       *audit.Log
+	permissions *permission.Service
+}
+
+// NewModerationHandler 생성자
+func NewModerationHandler(hostURL string, keys *keyring.Ring, streams catalog.Repository, auditLog *audit.Log, permissions *permission.Service) *ModerationHandler {
+	return &ModerationHandler{
+		hostURL:     hostURL,
+		keys:        keys,
+		streams:     streams,
+		audit:       auditLog,
+		permissions: permissions,
+	}
+}
+
+// MuteParticipant 핸들러 - 참가자 트랙 음소거/해제 (생성자/운영자/관리자)
+func (h *ModerationHandler) MuteParticipant(c echo.Context) error {
+	var req MuteParticipantRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	muted := req.Muted == nil || *req.Muted
+	var source livekit.TrackSource
+	if req.Source != "" {
+		value, ok := livekit.TrackSource_value[strings.ToUpper(req.Source)]
+		if !ok || value == int32(livekit.TrackSource_UNKNOWN) {
+			return echo.NewHTTPError(http.StatusBadRequest, "source must be camera, microphone, screen_share or screen_share_audio")
+		}
+		source = livekit.TrackSource(value)
+	}
+
+	roomClient, room, participant, err := h.authorizeParticipant(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	changed := []string{}
+	for _, track := range participant.Tracks {
+		if (req.TrackSid != "" && track.Sid != req.TrackSid) || (req.Source != "" && track.Source != source) {
+			continue
+		}
+		_, err := roomClient.MutePublishedTrack(ctx, &livekit.MuteRoomTrackRequest{
+			Room:     room.Name,
+			Identity: participant.Identity,
+			TrackSid: track.Sid,
+			Muted:    muted,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to mute track").SetInternal(err)
+		}
+		changed = append(changed, track.Sid)
+	}
+	if len(changed) == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "No matching published track")
+	}
+
+	action := audit.ActionMute
+	if !muted {
+		action = audit.ActionUnmute
+	}
+	h.record(c, room.Name, action, participant.Identity, req.Reason, map[string]interface{}{"tracks": changed})
+
+	return c.JSON(http.StatusOK, MuteParticipantResponse{
+		Identity: participant.Identity,
+		Muted:    muted,
+		Tracks:   changed,
+	})
+}
+
+// RemoveParticipant 핸들러 - 참가자 내보내기 (차단하지 않으므로 다시 참여 가능, 차단은 bans API)
+func (h *ModerationHandler) RemoveParticipant(c echo.Context) error {
+	roomClient, room, participant, err := h.authorizeParticipant(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = roomClient.RemoveParticipant(c.Request().Context(), &livekit.RoomParticipantIdentity{
+		Room:     room.Name,
+		Identity: participant.Identity,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to remove participant").SetInternal(err)
+	}
+	h.record(c, room.Name, audit.ActionRemove, participant.Identity, c.QueryParam("reason"), nil)
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message":  "Participant removed successfully",
+		"identity": participant.Identity,
+	})
+}
+
+// UpdatePermissions 핸들러 - 참가자 권한 변경 (발행/구독/데이터 전송 허용 여부)
+func (h *ModerationHandler) UpdatePermissions(c echo.Context) error {
+	var req UpdatePermissionsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if req.CanSubscribe == nil && req.CanPublish == nil && req.CanPublishData == nil && req.CanPublishSources == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "At least one granted is required")
+	}
+	var sources []livekit.TrackSource
+	if req.CanPublishSources != nil {
+		for _, name := range *req.CanPublishSources {
+			value, ok := livekit.TrackSource_value[strings.ToUpper(name)]
+			if !ok || value == int32(livekit.TrackSource_UNKNOWN) {
+				return echo.NewHTTPError(http.StatusBadRequest, "can_publish_sources must contain camera, microphone, screen_share or screen_share_audio")
+			}
+			sources = append(sources, livekit.TrackSource(value))
+		}
+	}
+
+	roomClient, room, participant, err := h.authorizeParticipant(c)
+	if err != nil {
+		return err
+	}
+
+	// 지정하지 않은 항목은 현재 권한 유지 (조회한 응답 값을 그대로 수정)
+	granted := participant.Permission
+	if granted == nil {
+		granted = &livekit.ParticipantPermission{}
+	}
+	details := map[string]interface{}{}
+	if req.CanSubscribe != nil {
+		granted.CanSubscribe = *req.CanSubscribe
+		details["can_subscribe"] = *req.CanSubscribe
+	}
+	if req.CanPublish != nil {
+		granted.CanPublish = *req.CanPublish
+		details["can_publish"] = *req.CanPublish
+	}
+	if req.CanPublishData != nil {
+		granted.CanPublishData = *req.CanPublishData
+		details["can_publish_data"] = *req.CanPublishData
+	}
+	if req.CanPublishSources != nil {
+		granted.CanPublishSources = sources
+		details["can_publish_sources"] = *req.CanPublishSources
+	}
+
+	updated, err := roomClient.UpdateParticipant(c.Request().Context(), &livekit.UpdateParticipantRequest{
+		Room:       room.Name,
+		Identity:   participant.Identity,
+		Permission: granted,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update participant").SetInternal(err)
+	}
+	if updated.GetPermission() != nil {
+		granted = updated.Permission
+	}
+	// 다시 참여하거나 토큰을 갱신해도 유지되도록 기록 (발급하는 토큰에 적용)
+	_, err = h.permissions.Update(c.Request().Context(), room.Name, participant.Identity, permission.Override{
+		CanSubscribe:      req.CanSubscribe,
+		CanPublish:        req.CanPublish,
+		CanPublishData:    req.CanPublishData,
+		CanPublishSources: req.CanPublishSources,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save participant permissions").SetInternal(err)
+	}
+	h.record(c, room.Name, audit.ActionPermissions, participant.Identity, req.Reason, details)
+
+	return c.JSON(http.StatusOK, UpdatePermissionsResponse{
+		Identity:    participant.Identity,
+		Permissions: newParticipantPermissions(granted),
+	})
+}
+
+// ListAudit 핸들러 - 룸 관리 기록 (최신순, 룸 종료 후에도 조회 가능)
+func (h *ModerationHandler) ListAudit(c echo.Context) error {
+	limit := defaultAuditLimit
+	if v := c.QueryParam("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxAuditLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 1000")
+		}
+		limit = n
+	}
+
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	stream, _, err := findStream(c.Request().Context(), h.streams, roomClient, c.Param("room_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if stream == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(stream.CreatorIdentity) {
+		return authz.ErrForbidden()
+	}
+
+	entries, err := h.audit.List(c.Request().Context(), stream.RoomId, limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list audit entries").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, ListAuditResponse{
+		Entries: entries,
+		Total:   len(entries),
+	})
+}
+
+// authorizeParticipant 열려 있는 룸의 관리 권한 확인 후 대상 참가자 조회
+func (h *ModerationHandler) authorizeParticipant(c echo.Context) (*lksdk.RoomServiceClient, *livekit.Room, *livekit.ParticipantInfo, error) {
+	ctx := c.Request().Context()
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	stream, room, err := findStream(ctx, h.streams, roomClient, c.Param("room_id"))
+	if err != nil {
+		return nil, nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if room == nil {
+		return nil, nil, nil, echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(stream.CreatorIdentity) {
+		return nil, nil, nil, authz.ErrForbidden()
+	}
+
+	participant, err := roomClient.GetParticipant(ctx, &livekit.RoomParticipantIdentity{
+		Room:     room.Name,
+		Identity: c.Param("identity"),
+	})
+	if err != nil {
+		return nil, nil, nil, echo.NewHTTPError(http.StatusNotFound, "Participant not found")
+	}
+	return roomClient, room, participant, nil
+}
+
+// record 관리 기록 저장
+func (h *ModerationHandler) record(c echo.Context, roomId string, action audit.Action, target, reason string, details map[string]interface{}) {
+	recordAudit(c, h.audit, &audit.Entry{
+		RoomId:  roomId,
+		Action:  action,
+		Target:  target,
+		Reason:  reason,
+		Details: details,
+	})
+}
+
+// recordAudit 요청 주체를 작업자로 관리 기록 저장 (작업은 이미 끝났으므로 실패는 로그만 남김)
+func recordAudit(c echo.Context, auditLog *audit.Log, entry *audit.Entry) {
+	entry.Actor = actorName(authz.ActorFrom(c))
+	if err := auditLog.Record(context.Background(), entry); err != nil {
+		log.Println("audit record failed:", entry.RoomId, entry.Action, err)
+	}
+}
+
+// actorName 기록용 요청 주체 이름 (관리자 API 키는 "admin")
+func actorName(actor *authz.Actor) string {
+	if actor != nil && actor.Principal != nil {
+		return actor.Principal.Identity
+	}
+	return "admin"
+}
+
+func newParticipantPermissions(permission *livekit.ParticipantPermission) ParticipantPermissions {
+	sources := []string{}
+	for _, source := range permission.CanPublishSources {
+		sources = append(sources, strings.ToLower(source.String()))
+	}
+	return ParticipantPermissions{
+		CanSubscribe:      permission.CanSubscribe,
+		CanPublish:        permission.CanPublish,
+		CanPublishData:    permission.CanPublishData,
+		CanPublishSources: sources,
+	}
+}
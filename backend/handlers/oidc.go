@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"backend/account"
+	"backend/oidc"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OIDCHandler 구조체
+type OIDCHandler struct {
+	providers *oidc.Registry
+	states    oidc.StateStore
+	sessions  *account.SessionIssuer
+}
+
+// NewOIDCHandler 생성자
+func NewOIDCHandler(providers *oidc.Registry, states oidc.StateStore, sessions *account.SessionIssuer) *OIDCHandler {
+	return &OIDCHandler{
+		providers: providers,
+		states:    states,
+		sessions:  sessions,
+	}
+}
+
+// ListProviders 핸들러 - 사용 가능한 IdP 목록
+func (h *OIDCHandler) ListProviders(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string][]string{
+		"providers": h.providers.Names(),
+	})
+}
+
+// Login 핸들러 - IdP 로그인 페이지로 리다이렉트
+func (h *OIDCHandler) Login(c echo.Context) error {
+	provider, ok := h.providers.Provider(c.Param("provider"))
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "Unknown identity provider")
+	}
+
+	state, ls, challenge := oidc.NewLoginState(provider.Name())
+	authURL, err := provider.AuthCodeURL(c.Request().Context(), state, ls.Nonce, challenge)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadGateway, "Identity provider unavailable").SetInternal(err)
+	}
+	h.states.Put(state, ls)
+
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback 핸들러 - 인가 코드 교환, ID 토큰 검증 후 세션 발급
+func (h *OIDCHandler) Callback(c echo.Context) error {
+	provider, ok := h.providers.Provider(c.Param("provider"))
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "Unknown identity provider")
+	}
+	if errCode := c.QueryParam("error"); errCode != "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Login rejected by identity provider: "+errCode)
+	}
+
+	ls, ok := h.states.Take(c.QueryParam("state"))
+	if !ok || ls.Provider != provider.Name() {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid or expired login state")
+	}
+	code := c.QueryParam("code")
+	if code == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Authorization code is required")
+	}
+
+	claims, err := provider.Exchange(c.Request().Context(), code, ls.CodeVerifier, ls.Nonce)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Failed to verify identity provider login").SetInternal(err)
+	}
+	principal, err := provider.Principal(claims)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Identity provider did not return required claims").SetInternal(err)
+	}
+
+	session, err := h.sessions.Issue(principal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to issue session").SetInternal(err)
+	}
+
+	// 프론트엔드 주소가 설정되어 있으면 fragment로 세션 전달 (서버 로그/Referer에 남지 않음)
+	if redirect := h.providers.PostLoginRedirect(); redirect != "" {
+		fragment := url.Values{}
+		fragment.Set("token", session.Token)
+		fragment.Set("expires_at", strconv.FormatInt(session.ExpiresAt.Unix(), 10))
+		return c.Redirect(http.StatusFound, redirect+"#"+fragment.Encode())
+	}
+
+	return c.JSON(http.StatusOK, LoginResponse{
+		Session: *session,
+		User: UserInfo{
+			ID:          principal.UserID,
+			Username:    principal.Identity,
+			DisplayName: principal.DisplayName,
+			Role:        principal.Role,
+		},
+	})
+}
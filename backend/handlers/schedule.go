@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"backend/account"
+	"backend/catalog"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 예약 가능한 최대 기간
+const maxScheduleAhead = 30 * 24 * time.Hour
+
+// ScheduleStream 요청 구조체 (CreateStream 필드 + 시작 시각)
+type ScheduleStreamRequest struct {
+	CreateStreamRequest
+	ScheduledAt time.Time `json:"scheduled_at"`           // RFC3339
+	IngressType string    `json:"ingress_type,omitempty"` // rtmp, whip (지정 시 룸과 함께 인그레스 생성)
+}
+
+// ScheduleStream 핸들러 - 예약 스트림 생성 (룸은 시작 직전에 스케줄러가 생성)
+func (h *StreamHandler) ScheduleStream(c echo.Context) error {
+	var req ScheduleStreamRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+
+	now := time.Now()
+	if req.ScheduledAt.IsZero() {
+		return echo.NewHTTPError(http.StatusBadRequest, "scheduled_at is required")
+	}
+	if !req.ScheduledAt.After(now) || req.ScheduledAt.After(now.Add(maxScheduleAhead)) {
+		return echo.NewHTTPError(http.StatusBadRequest, "scheduled_at must be in the future and within 30 days")
+	}
+	var ingress *catalog.Ingress
+	switch req.IngressType {
+	case "":
+	case "rtmp", "whip":
+		ingress = &catalog.Ingress{Type: req.IngressType}
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "ingress_type must be rtmp or whip")
+	}
+
+	protection, secret, err := prepareStream(principal, &req.CreateStreamRequest)
+	if err != nil {
+		return err
+	}
+
+	scheduledAt := req.ScheduledAt.UTC()
+	stream := &catalog.Stream{
+		CreatorIdentity: principal.Identity,
+		Metadata:        req.Metadata,
+		CreatedAt:       now,
+		ScheduledAt:     &scheduledAt,
+		Ingress:         ingress,
+		MaxViewers:      req.MaxViewers,
+	}
+	if err := h.reserveStream(c.Request().Context(), stream); err != nil {
+		return err
+	}
+	if protection != "" {
+		if err := h.passcodes.Set(c.Request().Context(), stream.RoomId, protection, secret); err != nil {
+			h.abandonStream(context.Background(), stream.RoomId)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to set room passcode").SetInternal(err)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, newRoomInfo(stream, nil))
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/account"
+	"backend/catalog"
+	"backend/lobby"
+	"backend/search"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SearchStreams 결과 수
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	maxSearchQueryLen  = 100
+)
+
+// SearchStreams 응답 구조체
+type SearchStreamsResponse struct {
+	Results []SearchResult `json:"results"`
+	Total   int            `json:"total"` // 노출 가능한 전체 일치 수
+}
+
+type SearchResult struct {
+	Room  RoomInfo `json:"room"`
+	Score float64  `json:"score"` // 관련도 (높을수록 먼저)
+}
+
+// SearchHandler 구조체
+type SearchHandler struct {
+	index   *search.Index
+	streams catalog.Repository
+}
+
+// NewSearchHandler 생성자
+func NewSearchHandler(index *search.Index, streams catalog.Repository) *SearchHandler {
+	return &SearchHandler{
+		index:   index,
+		streams: streams,
+	}
+}
+
+// SearchStreams 핸들러 - 제목/설명/태그 검색 (비공개 스트림은 생성자/운영자에게만)
+// q (필수), limit=20 (최대 50), include_ended=true면 종료된 스트림 포함
+func (h *SearchHandler) SearchStreams(c echo.Context) error {
+	text := c.QueryParam("q")
+	if text == "" || len(text) > maxSearchQueryLen {
+		return echo.NewHTTPError(http.StatusBadRequest, "q is required (up to 100 characters)")
+	}
+	limit := defaultSearchLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be a positive integer")
+		}
+		limit = min(n, maxSearchLimit)
+	}
+	includeEnded := c.QueryParam("include_ended") == "true"
+	principal := account.PrincipalFrom(c)
+
+	hits, total, err := h.index.Search(c.Request().Context(), text, limit, func(doc *search.Document) bool {
+		if doc.Ended && !includeEnded {
+			return false
+		}
+		return !doc.Private || canSeePrivate(principal, doc.CreatorIdentity)
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to search streams").SetInternal(err)
+	}
+
+	results := []SearchResult{}
+	for _, hit := range hits {
+		stream, err := h.streams.Get(c.Request().Context(), hit.RoomId)
+		if errors.Is(err, catalog.ErrStreamNotFound) {
+			continue
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get stream").SetInternal(err)
+		}
+		results = append(results, SearchResult{Room: newStreamInfo(stream), Score: hit.Score})
+	}
+
+	return c.JSON(http.StatusOK, SearchStreamsResponse{
+		Results: results,
+		Total:   total,
+	})
+}
+
+// Sync 카탈로그로 색인을 채우고 로비 이벤트(스트림 생성/변경/종료)로 갱신 (ctx가 끝날 때까지)
+// Redis 사용 시 로비 이벤트가 인스턴스 간 공유되므로 다른 인스턴스의 변경도 반영된다.
+func (h *SearchHandler) Sync(ctx context.Context, hub *lobby.Hub) {
+	lastID := ""
+	for ctx.Err() == nil {
+		sub, backlog, err := hub.Subscribe(ctx, lastID)
+		if err != nil && !errors.Is(err, lobby.ErrGap) {
+			log.Println("search index subscribe failed:", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		// 처음이거나 놓친 이벤트가 있으면 카탈로그로 다시 채움 (구독 후에 읽어 그 사이 변경을 놓치지 않음)
+		if lastID == "" || err != nil {
+			if err := h.index.Rebuild(ctx, h.streams); err != nil {
+				log.Println("search index rebuild failed:", err)
+			}
+		}
+		for _, event := range backlog {
+			lastID = event.ID
+			h.apply(ctx, event)
+		}
+
+	consume:
+		for {
+			select {
+			case <-ctx.Done():
+				break consume
+			case event, ok := <-sub.Events:
+				if !ok {
+					break consume
+				}
+				if lobby.Skip(event, lastID) {
+					continue
+				}
+				lastID = event.ID
+				h.apply(ctx, event)
+			}
+		}
+		sub.Close()
+	}
+}
+
+// apply 이벤트가 가리키는 스트림을 카탈로그에서 다시 읽어 색인 갱신
+// 이벤트 본문(RoomInfo)에는 비공개 여부 등 색인에 필요한 값이 빠져 있을 수 있어 쓰지 않음
+func (h *SearchHandler) apply(ctx context.Context, event *lobby.Event) {
+	switch event.Type {
+	case lobby.TypeStreamCreated, lobby.TypeStreamUpdated, lobby.TypeStreamEnded:
+	default:
+		return
+	}
+	stream, err := h.streams.Get(ctx, event.RoomId)
+	if errors.Is(err, catalog.ErrStreamNotFound) {
+		err = h.index.Delete(event.RoomId)
+	} else if err == nil {
+		err = h.index.Put(search.FromStream(stream))
+	}
+	if err != nil {
+		log.Println("search index update failed:", err)
+	}
+}
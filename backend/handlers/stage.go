@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"backend/account"
+	"backend/audit"
+	"backend/authz"
+	"backend/catalog"
+	"backend/keyring"
+	"backend/lifecycle"
+	"backend/policy"
+	"backend/stage"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// StageTopic 무대 이벤트 데이터 메시지 토픽
+const StageTopic = "stage"
+
+// 손들기 메시지 최대 길이
+const maxStageMessageLength = 200
+
+// 무대 이벤트 종류
+const (
+	StageHandRaised  = "hand.raised"    // 손들기
+	StageHandLowered = "hand.lowered"   // 요청 취소 (본인 또는 진행자)
+	StageApproved    = "stage.approved" // 발언자로 승격
+	StageDenied      = "stage.denied"
+	StageDemoted     = "stage.demoted" // 시청자로 강등
+)
+
+// RaiseHandRequest 손들기 요청 구조체
+type RaiseHandRequest struct {
+	Message string `json:"message,omitempty"`
+}
+
+// StageEvent 룸 전체에 보내는 무대 이벤트 (토픽 "stage" 신뢰성 데이터 메시지)
+type StageEvent struct {
+	Type     string         `json:"type"`
+	RoomId   string         `json:"room_id"`
+	Identity string         `json:"identity"`
+	Request  *stage.Request `json:"request,omitempty"`
+	By       string         `json:"by,omitempty"` // 승인/거절/강등한 사람
+	At       time.Time      `json:"at"`
+}
+
+type ListStageRequestsResponse struct {
+	Requests []*stage.Request `json:"requests"`
+	Total    int              `json:"total"`
+}
+
+type StageRoleResponse struct {
+	Identity    string                 `json:"identity"`
+	Role        string                 `json:"role"`
+	Permissions ParticipantPermissions `json:"permissions"`
+}
+
+// StageHandler 구조체
+type StageHandler struct {
+	hostURL  string
+	keys     *keyring.Ring
+	policies *policy.Engine
+	streams  catalog.Repository
+	queue    *stage.Queue
+	audit    *audit.Log
+}
+
+// NewStageHandler 생성자
+func NewStageHandler(hostURL string, keys *keyring.Ring, policies *policy.Engine, streams catalog.Repository, queue *stage.Queue, auditLog *audit.Log) *StageHandler {
+	return &StageHandler{
+		hostURL:  hostURL,
+		keys:     keys,
+		policies: policies,
+		streams:  streams,
+		queue:    queue,
+		audit:    auditLog,
+	}
+}
+
+// RaiseHand 핸들러 - 무대 요청 (룸에 접속한 시청자만, 이미 요청했으면 기존 순서 유지)
+func (h *StageHandler) RaiseHand(c echo.Context) error {
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+	var req RaiseHandRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if len([]rune(req.Message)) > maxStageMessageLength {
+		return echo.NewHTTPError(http.StatusBadRequest, "message must be at most 200 characters")
+	}
+
+	ctx := c.Request().Context()
+	roomClient, stream, err := h.openStream(c)
+	if err != nil {
+		return err
+	}
+	participant, err := roomClient.GetParticipant(ctx, &livekit.RoomParticipantIdentity{
+		Room:     stream.RoomId,
+		Identity: principal.Identity,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusConflict, "Join the stream before requesting the stage")
+	}
+	if onStage(stream, participant) {
+		return echo.NewHTTPError(http.StatusConflict, "Already on stage")
+	}
+
+	request, created, err := h.queue.Raise(ctx, stream.RoomId, participant.Identity, participant.Name, req.Message)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to raise hand").SetInternal(err)
+	}
+	if !created {
+		return c.JSON(http.StatusOK, request)
+	}
+	h.broadcast(roomClient, &StageEvent{Type: StageHandRaised, RoomId: stream.RoomId, Identity: request.Identity, Request: request})
+	return c.JSON(http.StatusCreated, request)
+}
+
+// ListRequests 핸들러 - 무대 요청 목록 (요청 순, 생성자/운영자/관리자)
+func (h *StageHandler) ListRequests(c echo.Context) error {
+	_, stream, err := h.openStream(c)
+	if err != nil {
+		return err
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(stream.CreatorIdentity) {
+		return authz.ErrForbidden()
+	}
+	requests, err := h.queue.List(c.Request().Context(), stream.RoomId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list stage requests").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, ListStageRequestsResponse{
+		Requests: requests,
+		Total:    len(requests),
+	})
+}
+
+// LowerHand 핸들러 - 무대 요청 취소 (본인 또는 생성자/운영자/관리자)
+func (h *StageHandler) LowerHand(c echo.Context) error {
+	roomClient, stream, err := h.openStream(c)
+	if err != nil {
+		return err
+	}
+	identity := c.Param("identity")
+	actor := authz.ActorFrom(c)
+	if actor == nil || (!actor.CanManage(stream.CreatorIdentity) && !isSelf(actor, identity)) {
+		return authz.ErrForbidden()
+	}
+	request, err := h.take(c.Request().Context(), stream.RoomId, identity)
+	if err != nil {
+		return err
+	}
+	h.broadcast(roomClient, &StageEvent{Type: StageHandLowered, RoomId: stream.RoomId, Identity: request.Identity, By: actorName(actor)})
+	return c.JSON(http.StatusOK, map[string]string{
+		"message":  "Hand lowered",
+		"identity": request.Identity,
+	})
+}
+
+// ApproveRequest 핸들러 - 무대 요청 승인 (발언자 권한으로 변경, 생성자/운영자/관리자)
+func (h *StageHandler) ApproveRequest(c echo.Context) error {
+	roomClient, stream, err := h.manageStream(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	request, err := h.take(ctx, stream.RoomId, c.Param("identity"))
+	if err != nil {
+		return err
+	}
+	res, err := h.assignRole(ctx, roomClient, stream.RoomId, request.Identity, policy.RoleSpeaker)
+	if err != nil {
+		return err
+	}
+
+	h.broadcast(roomClient, &StageEvent{Type: StageApproved, RoomId: stream.RoomId, Identity: request.Identity, By: actorName(authz.ActorFrom(c))})
+	recordAudit(c, h.audit, &audit.Entry{RoomId: stream.RoomId, Action: audit.ActionPromote, Target: request.Identity, Reason: request.Message})
+	return c.JSON(http.StatusOK, res)
+}
+
+// DenyRequest 핸들러 - 무대 요청 거절 (생성자/운영자/관리자)
+func (h *StageHandler) DenyRequest(c echo.Context) error {
+	roomClient, stream, err := h.manageStream(c)
+	if err != nil {
+		return err
+	}
+	request, err := h.take(c.Request().Context(), stream.RoomId, c.Param("identity"))
+	if err != nil {
+		return err
+	}
+
+	h.broadcast(roomClient, &StageEvent{Type: StageDenied, RoomId: stream.RoomId, Identity: request.Identity, By: actorName(authz.ActorFrom(c))})
+	recordAudit(c, h.audit, &audit.Entry{RoomId: stream.RoomId, Action: audit.ActionDeny, Target: request.Identity, Reason: c.QueryParam("reason")})
+	return c.JSON(http.StatusOK, map[string]string{
+		"message":  "Stage request denied",
+		"identity": request.Identity,
+	})
+}
+
+// Demote 핸들러 - 무대에서 내리기 (시청자 권한으로 변경, 본인 또는 생성자/운영자/관리자)
+func (h *StageHandler) Demote(c echo.Context) error {
+	roomClient, stream, err := h.openStream(c)
+	if err != nil {
+		return err
+	}
+	identity := c.Param("identity")
+	actor := authz.ActorFrom(c)
+	if actor == nil || (!actor.CanManage(stream.CreatorIdentity) && !isSelf(actor, identity)) {
+		return authz.ErrForbidden()
+	}
+	if identity == stream.CreatorIdentity {
+		return echo.NewHTTPError(http.StatusBadRequest, "The host cannot leave the stage")
+	}
+
+	ctx := c.Request().Context()
+	participant, err := roomClient.GetParticipant(ctx, &livekit.RoomParticipantIdentity{Room: stream.RoomId, Identity: identity})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Participant not found")
+	}
+	if policy.Role(participant.Attributes[policy.AttributeRole]) != policy.RoleSpeaker {
+		return echo.NewHTTPError(http.StatusConflict, "Participant is not on stage")
+	}
+	res, err := h.assignRole(ctx, roomClient, stream.RoomId, identity, policy.RoleViewer)
+	if err != nil {
+		return err
+	}
+
+	h.broadcast(roomClient, &StageEvent{Type: StageDemoted, RoomId: stream.RoomId, Identity: identity, By: actorName(actor)})
+	recordAudit(c, h.audit, &audit.Entry{RoomId: stream.RoomId, Action: audit.ActionDemote, Target: identity, Reason: c.QueryParam("reason")})
+	return c.JSON(http.StatusOK, res)
+}
+
+// ClearFinished 웹훅 구독자 - 나간 참가자의 요청과 종료된 룸의 요청 삭제
+func (h *StageHandler) ClearFinished(ctx context.Context, event *livekit.WebhookEvent) error {
+	roomId := event.GetRoom().GetName()
+	if roomId == "" {
+		return nil
+	}
+	switch event.Event {
+	case webhook.EventRoomFinished:
+		return h.queue.Clear(ctx, roomId)
+	case webhook.EventParticipantLeft:
+		_, err := h.queue.Take(ctx, roomId, event.GetParticipant().GetIdentity())
+		if errors.Is(err, stage.ErrRequestNotFound) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// openStream 열려 있는 스트림 조회
+func (h *StageHandler) openStream(c echo.Context) (*lksdk.RoomServiceClient, *catalog.Stream, error) {
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	stream, room, err := findStream(c.Request().Context(), h.streams, roomClient, c.Param("room_id"))
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if room == nil {
+		return nil, nil, echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+	return roomClient, stream, nil
+}
+
+// manageStream 열려 있는 스트림 조회 후 관리 권한 확인
+func (h *StageHandler) manageStream(c echo.Context) (*lksdk.RoomServiceClient, *catalog.Stream, error) {
+	roomClient, stream, err := h.openStream(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(stream.CreatorIdentity) {
+		return nil, nil, authz.ErrForbidden()
+	}
+	return roomClient, stream, nil
+}
+
+// take 요청 꺼내기 (없으면 404)
+func (h *StageHandler) take(ctx context.Context, roomId, identity string) (*stage.Request, error) {
+	request, err := h.queue.Take(ctx, roomId, identity)
+	if errors.Is(err, stage.ErrRequestNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Stage request not found")
+	}
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to update stage requests").SetInternal(err)
+	}
+	return request, nil
+}
+
+// assignRole 접속 중인 참가자에게 역할 정책의 권한과 역할 속성 적용
+// 토큰은 바꾸지 않으므로 재접속하면 토큰의 역할로 돌아감 (토큰 갱신은 현재 역할을 따름)
+func (h *StageHandler) assignRole(ctx context.Context, roomClient *lksdk.RoomServiceClient, roomId, identity string, role policy.Role) (*StageRoleResponse, error) {
+	p, err := h.policies.Lookup(role)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply grant policy").SetInternal(err)
+	}
+	permission, err := p.Permission()
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply grant policy").SetInternal(err)
+	}
+	attributes := map[string]string{policy.AttributeRole: string(role)}
+	for k, v := range p.Attributes {
+		attributes[k] = v
+	}
+
+	_, err = roomClient.UpdateParticipant(ctx, &livekit.UpdateParticipantRequest{
+		Room:       roomId,
+		Identity:   identity,
+		Permission: permission,
+		Attributes: attributes,
+	})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Participant not found").SetInternal(err)
+	}
+	return &StageRoleResponse{
+		Identity:    identity,
+		Role:        string(role),
+		Permissions: newParticipantPermissions(permission),
+	}, nil
+}
+
+// broadcast 룸 전체에 무대 이벤트 전송 (상태는 이미 바뀌었으므로 실패는 로그만 남김)
+func (h *StageHandler) broadcast(roomClient *lksdk.RoomServiceClient, event *StageEvent) {
+	event.At = time.Now()
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Println("stage event encode failed:", err)
+		return
+	}
+	topic := StageTopic
+	_, err = roomClient.SendData(context.Background(), &livekit.SendDataRequest{
+		Room:  event.RoomId,
+		Data:  data,
+		Kind:  livekit.DataPacket_RELIABLE,
+		Topic: &topic,
+	})
+	if err != nil {
+		log.Println("stage event send failed:", event.RoomId, event.Type, err)
+	}
+}
+
+// onStage 발행 권한이 있는 참가자 (생성자, 인그레스, 발언자 등)
+func onStage(stream *catalog.Stream, participant *livekit.ParticipantInfo) bool {
+	ev := lifecycle.Event{Identity: participant.Identity, Ingress: participant.Kind == livekit.ParticipantInfo_INGRESS}
+	return lifecycle.IsHost(stream, ev) || participant.GetPermission().GetCanPublish()
+}
+
+func isSelf(actor *authz.Actor, identity string) bool {
+	return actor.Principal != nil && actor.Principal.Identity == identity
+}
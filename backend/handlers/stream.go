@@ -2,22 +2,46 @@ package handlers
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
-	"backend/utils"
+	"backend/account"
+	"backend/authz"
+	"backend/ban"
+	"backend/catalog"
+	"backend/invite"
+	"backend/keyring"
+	"backend/lifecycle"
+	"backend/passcode"
+	"backend/permission"
+	"backend/policy"
+	"backend/roster"
+	"backend/waitlist"
 
 	"github.com/labstack/echo/v4"
-	"github.com/livekit/protocol/auth"
 	"github.com/livekit/protocol/livekit"
 	lksdk "github.com/livekit/server-sdk-go/v2"
+	"github.com/twitchtv/twirp"
 )
 
+// 설정 가능한 최대 시청자 정원
+const maxViewersLimit = 10000
+
 // CreateStream 요청/응답 구조체
 type CreateStreamRequest struct {
 	Metadata map[string]interface{} `json:"metadata"`
+	Password string                 `json:"password,omitempty"` // 룸 비밀번호 (해시로 별도 저장, 메타데이터에 넣지 않음)
+	PIN      string                 `json:"pin,omitempty"`      // 숫자 PIN (다이얼인 겸용)
+	// 시청자 정원 (0이면 제한 없음, 가득 차면 JoinStream은 거절하거나 대기열에 넣음)
+	MaxViewers uint32 `json:"max_viewers,omitempty"`
 }
 
 type CreateStreamResponse struct {
@@ -26,10 +50,12 @@ type CreateStreamResponse struct {
 	ConnectionDetails ConnectionDetails `json:"connection_details"`
 }
 
-// JoinStream 요청/응답 구조체
+// JoinStream 요청/응답 구조체 (identity는 로그인 세션에서 결정)
 type JoinStreamRequest struct {
-	Identity string `json:"identity"`
-	RoomId   string `json:"room_id"`
+	RoomId     string `json:"room_id"`
+	InviteCode string `json:"invite_code,omitempty"` // 비공개 스트림 참여 시 필요
+	Passcode   string `json:"passcode,omitempty"`    // 비밀번호/PIN 보호 스트림 참여 시 필요
+	Wait       bool   `json:"wait,omitempty"`        // 정원이 찼을 때 거절 대신 대기열에 등록 (202)
 }
 
 type JoinStreamResponse struct {
@@ -44,8 +70,11 @@ type ConnectionDetails struct {
 
 // ListStreams 응답 구조체
 type ListStreamsResponse struct {
-	Rooms []RoomInfo `json:"rooms"`
-	Total int        `json:"total"`
+	Rooms      []RoomInfo `json:"rooms"`
+	Total      int        `json:"total"`                 // 필터에 맞는 전체 스트림 수
+	NextCursor string     `json:"next_cursor,omitempty"` // 다음 페이지 (없으면 마지막 페이지)
+	// 제한 시간 안에 참가자를 조회하지 못한 룸 (해당 룸의 participants는 비어 있음)
+	FailedRooms []string `json:"failed_rooms,omitempty"`
 }
 
 type RoomInfo struct {
@@ -56,12 +85,22 @@ type RoomInfo struct {
 	EmptyTimeout    uint32                 `json:"empty_timeout"`
 	MaxParticipants uint32                 `json:"max_participants"`
 	Participants    []ParticipantInfo      `json:"participants,omitempty"`
+	Live            bool                   `json:"live"`               // LiveKit 룸이 열려 있는지
+	State           catalog.State          `json:"state"`              // scheduled, waiting-for-host, live, paused, ended
+	StateChangedAt  int64                  `json:"state_changed_at"`   // 마지막 상태 전이 시각
+	EndedAt         int64                  `json:"ended_at,omitempty"` // 종료된 스트림의 종료 시각
+	ScheduledAt     int64                  `json:"scheduled_at,omitempty"`
+	NoShow          bool                   `json:"no_show,omitempty"`     // 예약 시각에 호스트가 나타나지 않아 종료됨
+	MetadataVersion int64                  `json:"metadata_version"`      // 메타데이터 수정 시 If-Match/version으로 전달
+	MaxViewers      uint32                 `json:"max_viewers,omitempty"` // 시청자 정원 (0이면 제한 없음)
 }
 
 // GetStream 응답 구조체
 type GetStreamResponse struct {
-	Room         RoomInfo          `json:"room"`
-	Participants []ParticipantInfo `json:"participants"`
+	Room         RoomInfo              `json:"room"`
+	Participants []ParticipantInfo     `json:"participants"`
+	Ingress      *catalog.Ingress      `json:"ingress,omitempty"` // 예약 스트림 인그레스 (생성자/운영자/관리자에게만)
+	StateHistory []catalog.StateChange `json:"state_history"`
 }
 
 type ParticipantInfo struct {
@@ -76,23 +115,78 @@ type ParticipantInfo struct {
 type StreamHandler struct {
 	hostURL     string
 	clientWSURL string
-	apiKey      string
-	apiSecret   string
+	keys        *keyring.Ring
+	policies    *policy.Engine
+	invites     *invite.Service
+	passcodes   *passcode.Service
+	bans        *ban.Service
+	streams     catalog.Repository
+	states      *lifecycle.Machine
+	roster      *roster.Cache
+	waitlist    *waitlist.Service
+	permissions *permission.Service
 }
 
 // NewStreamHandler 생성자
-func NewStreamHandler(hostURL, clientWSURL, apiKey, apiSecret string) *StreamHandler {
+func NewStreamHandler(hostURL, clientWSURL string, keys *keyring.Ring, policies *policy.Engine, invites *invite.Service, passcodes *passcode.Service, bans *ban.Service, streams catalog.Repository, states *lifecycle.Machine, participants *roster.Cache, waiting *waitlist.Service, permissions *permission.Service) *StreamHandler {
 	return &StreamHandler{
 		hostURL:     hostURL,
 		clientWSURL: clientWSURL,
-		apiKey:      apiKey,
-		apiSecret:   apiSecret,
+		keys:        keys,
+		policies:    policies,
+		invites:     invites,
+		passcodes:   passcodes,
+		bans:        bans,
+		streams:     streams,
+		states:      states,
+		roster:      participants,
+		waitlist:    waiting,
+		permissions: permissions,
 	}
 }
 
-// generateRoomId 헬퍼 함수
+// 새 room_id가 이미 쓰이고 있을 때 다시 생성하는 횟수
+const roomIdAttempts = 3
+
+// generateRoomId 추측할 수 없는 룸 이름 (같은 시각에 만든 스트림끼리 겹치지 않음)
 func generateRoomId() string {
-	return fmt.Sprintf("room-%d", time.Now().Unix())
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "room-" + hex.EncodeToString(b)
+}
+
+// reserveStream 새 room_id로 카탈로그 기록을 먼저 저장해 이름 확보 (즉시/예약 스트림 공통)
+// 기존 기록은 덮어쓰지 않으며, 겹치면 새 이름으로 다시 시도
+func (h *StreamHandler) reserveStream(ctx context.Context, stream *catalog.Stream) error {
+	return reserveStream(ctx, h.states, stream)
+}
+
+// reserveStream StreamHandler.reserveStream과 같으며 상태 머신을 직접 받음 (다른 핸들러용)
+func reserveStream(ctx context.Context, states *lifecycle.Machine, stream *catalog.Stream) error {
+	for i := 0; i < roomIdAttempts; i++ {
+		stream.RoomId = generateRoomId()
+		err := states.Create(ctx, stream)
+		if errors.Is(err, catalog.ErrStreamExists) {
+			continue
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save stream").SetInternal(err)
+		}
+		return nil
+	}
+	return echo.NewHTTPError(http.StatusConflict, "Room id already in use")
+}
+
+// abandonStream 룸/비밀번호 준비에 실패한 스트림 기록을 종료 상태로 (이름은 다시 쓰지 않음)
+func (h *StreamHandler) abandonStream(ctx context.Context, roomId string) {
+	abandonStream(ctx, h.states, roomId)
+}
+
+// abandonStream StreamHandler.abandonStream과 같으며 상태 머신을 직접 받음 (다른 핸들러용)
+func abandonStream(ctx context.Context, states *lifecycle.Machine, roomId string) {
+	if _, _, err := states.Apply(ctx, roomId, lifecycle.Event{Type: lifecycle.EventEnded}); err != nil {
+		log.Println("abandon stream failed:", roomId, err)
+	}
 }
 
 // CreateStream 핸들러 - 스트림 생성 (호스트용)
@@ -102,40 +196,64 @@ func (h *StreamHandler) CreateStream(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
-	// metadata에서 creator_identity 확인
-	creatorIdentity, ok := req.Metadata["creator_identity"].(string)
-	if !ok || creatorIdentity == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "creator_identity is required in metadata")
+	// creator_identity는 클라이언트 값 대신 로그인한 사용자로 고정
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+	creatorIdentity := principal.Identity
+	protection, secret, err := prepareStream(principal, &req)
+	if err != nil {
+		return err
 	}
 
-	// 룸 이름 생성 (없으면 자동 생성)
-	roomId := generateRoomId()
-
-	// 호스트용 LiveKit 토큰 생성
-	at := auth.NewAccessToken(h.apiKey, h.apiSecret)
-	at.SetIdentity(creatorIdentity)
-	at.SetVideoGrant(&auth.VideoGrant{
-		Room:         roomId,
-		RoomJoin:     true,
-		CanPublish:   utils.GetBool(true), // 호스트는 발행 가능
-		CanSubscribe: utils.GetBool(true), // 구독 가능
-	})
-	at.SetValidFor(time.Hour)
-
-	// 룸 생성
-	roomClient := lksdk.NewRoomServiceClient(h.hostURL, h.apiKey, h.apiSecret)
 	metadataJSON, err := json.Marshal(req.Metadata)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal metadata")
 	}
 
+	// 룸이 사라져도 스트림 기록이 남도록 카탈로그에 저장
+	// 룸 생성 전에 이름을 확보해 다른 스트림의 룸/비밀번호/호스트 권한을 덮어쓰지 않음
+	stream := &catalog.Stream{
+		CreatorIdentity: creatorIdentity,
+		Metadata:        req.Metadata,
+		CreatedAt:       time.Now(),
+		MaxViewers:      req.MaxViewers,
+	}
+	if err := h.reserveStream(c.Request().Context(), stream); err != nil {
+		return err
+	}
+	roomId := stream.RoomId
+
+	// 호스트용 LiveKit 토큰 생성
+	primary := h.keys.Primary()
+	at, err := h.policies.NewAccessToken(primary.Key, primary.Secret, policy.RoleHost, roomId, creatorIdentity)
+	if err != nil {
+		h.abandonStream(context.Background(), roomId)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply grant policy").SetInternal(err)
+	}
+	at.SetName(principal.DisplayName)
+
+	// 비밀번호는 룸이 열리기 전에 설정 (비밀번호 없이 참여할 수 있는 틈이 없도록)
+	if protection != "" {
+		if err := h.passcodes.Set(c.Request().Context(), roomId, protection, secret); err != nil {
+			h.abandonStream(context.Background(), roomId)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to set room passcode").SetInternal(err)
+		}
+	}
+
+	// 룸 생성 (바로 시작하는 스트림)
+	roomClient := newRoomClient(h.hostURL, h.keys)
 	_, err = roomClient.CreateRoom(context.Background(), &livekit.CreateRoomRequest{
 		Name:             roomId,
 		Metadata:         string(metadataJSON),
 		EmptyTimeout:     300,
 		DepartureTimeout: 300,
+		MaxParticipants:  stream.RoomMaxParticipants(),
 	})
 	if err != nil {
+		h.passcodes.Clear(context.Background(), roomId)
+		h.abandonStream(context.Background(), roomId)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create room")
 	}
 
@@ -157,6 +275,41 @@ func (h *StreamHandler) CreateStream(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// prepareStream 룸 메타데이터 정리 및 비밀번호/PIN 검증 (즉시/예약 스트림 공통)
+// creator_identity는 클라이언트 값 대신 로그인한 사용자로 고정하고,
+// 비밀번호/PIN은 룸 메타데이터에 노출되지 않도록 보호 방식만 기록
+func prepareStream(principal *account.Principal, req *CreateStreamRequest) (passcode.Kind, string, error) {
+	if req.Metadata == nil {
+		req.Metadata = map[string]interface{}{}
+	}
+	req.Metadata["creator_identity"] = principal.Identity
+
+	for _, key := range []string{"password", "pin", "passcode"} {
+		delete(req.Metadata, key)
+	}
+	delete(req.Metadata, "protection")
+	if req.MaxViewers > maxViewersLimit {
+		return "", "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("max_viewers must be at most %d", maxViewersLimit))
+	}
+	var protection passcode.Kind
+	var secret string
+	switch {
+	case req.Password != "" && req.PIN != "":
+		return "", "", echo.NewHTTPError(http.StatusBadRequest, "Only one of password or pin can be set")
+	case req.Password != "":
+		protection, secret = passcode.KindPassword, req.Password
+	case req.PIN != "":
+		protection, secret = passcode.KindPIN, req.PIN
+	}
+	if protection != "" {
+		if err := passcode.Validate(protection, secret); err != nil {
+			return "", "", echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		req.Metadata["protection"] = string(protection)
+	}
+	return protection, secret, nil
+}
+
 // JoinStream 핸들러 - 시청자가 스트림에 참여
 func (h *StreamHandler) JoinStream(c echo.Context) error {
 	var req JoinStreamRequest
@@ -164,18 +317,64 @@ func (h *StreamHandler) JoinStream(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
-	if req.Identity == "" || req.RoomId == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "Identity and room_id are required")
+	if req.RoomId == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "room_id is required")
+	}
+
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+
+	// 차단된 사용자(identity/계정/IP)는 토큰 발급 거부
+	if err := checkBan(c, h.bans, req.RoomId, principal); err != nil {
+		return err
 	}
 
 	// RoomService 클라이언트 생성
-	roomClient := lksdk.NewRoomServiceClient(h.hostURL, h.apiKey, h.apiSecret)
+	roomClient := newRoomClient(h.hostURL, h.keys)
+
+	stream, room, err := h.lookupStream(c.Request().Context(), roomClient, req.RoomId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if room == nil {
+		switch {
+		case stream == nil:
+			return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+		case stream.Ended():
+			return echo.NewHTTPError(http.StatusGone, "Stream has ended")
+		case stream.ScheduledAt != nil:
+			return echo.NewHTTPError(http.StatusConflict, "Stream has not started yet")
+		}
+		return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+
+	// 비밀번호/PIN 보호 스트림은 생성자/운영자 외에는 확인 (초대 코드 사용 전에 검사)
+	if !canSeePrivate(principal, stream.CreatorIdentity) {
+		err := h.passcodes.Verify(c.Request().Context(), req.RoomId, req.Passcode, "id:"+principal.Identity, "ip:"+c.RealIP())
+		if err != nil {
+			return passcodeError(c, err)
+		}
+	}
+
+	// 비공개 스트림은 생성자/운영자 외에는 초대 코드 필요
+	// 여기서는 확인만 하고, 사용 횟수는 아래 거절 사유(중복 참가, 대기열)를 모두 통과해 토큰을 발급할 때 차감
+	needsInvite := stream.IsPrivate() && !canSeePrivate(principal, stream.CreatorIdentity)
+	if needsInvite {
+		if req.InviteCode == "" {
+			return echo.NewHTTPError(http.StatusForbidden, "Invite code is required for private streams")
+		}
+		if err := h.invites.Check(c.Request().Context(), req.InviteCode, req.RoomId); err != nil {
+			return inviteError(err)
+		}
+	}
 
 	// 동일한 identity를 가진 참가자가 이미 존재하는지 확인
 	exists := false
-	_, err := roomClient.GetParticipant(context.Background(), &livekit.RoomParticipantIdentity{
+	_, err = roomClient.GetParticipant(context.Background(), &livekit.RoomParticipantIdentity{
 		Room:     req.RoomId,
-		Identity: req.Identity,
+		Identity: principal.Identity,
 	})
 	if err == nil {
 		exists = true
@@ -185,178 +384,282 @@ func (h *StreamHandler) JoinStream(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusConflict, "Participant already exists")
 	}
 
-	fmt.Println("[TESTDEBUG] Create JoinStream Token")
-	// 시청자용 LiveKit 토큰 생성 (미디어 파일 재생을 위해 발행 권한 포함)
-	at := auth.NewAccessToken(h.apiKey, h.apiSecret)
-	at.SetIdentity(req.Identity)
-	at.SetVideoGrant(&auth.VideoGrant{
-		Room:           req.RoomId,
-		RoomJoin:       true,
-		CanPublish:     &[]bool{true}[0], // 미디어 파일 재생을 위해 발행 권한 필요
-		CanSubscribe:   &[]bool{true}[0], // 구독 가능
-		CanPublishData: &[]bool{true}[0], // 채팅/반응 가능
-	})
-	at.SetValidFor(time.Hour)
-	at.SetName(req.Identity) // 참가자 이름으로 임시 사용
+	// 정원이 있는 룸은 대기열 순서대로 입장 (생성자/운영자는 정원과 무관)
+	// 토큰은 입장 허가가 만료될 때까지만 유효 (허가가 끝난 뒤 접속해 정원을 넘지 않도록)
+	var admittedUntil time.Time
+	if stream.MaxViewers > 0 && !canSeePrivate(principal, stream.CreatorIdentity) {
+		status, err := h.joinWaitlist(c.Request().Context(), stream, principal.Identity, req.Wait)
+		if err != nil {
+			return err
+		}
+		if !status.Admitted {
+			return c.JSON(http.StatusAccepted, newWaitlistResponse(req.RoomId, status, nil))
+		}
+		admittedUntil = status.AdmittedUntil
+	}
 
-	// 응답 생성
-	livekitToken, err := at.ToJWT()
+	if needsInvite {
+		if err := h.invites.Redeem(c.Request().Context(), req.InviteCode, req.RoomId); err != nil {
+			return inviteError(err)
+		}
+	}
+
+	fmt.Println("[TESTDEBUG] Create JoinStream Token")
+	details, err := h.joinToken(c.Request().Context(), principal, stream, req.RoomId, admittedUntil)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate LiveKit token")
+		return err
 	}
 
 	response := JoinStreamResponse{
-		AuthToken: livekitToken,
-		ConnectionDetails: ConnectionDetails{
-			WSURL: h.clientWSURL,
-			Token: livekitToken,
-		},
+		AuthToken:         details.Token,
+		ConnectionDetails: *details,
 	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
-// ListStreams 핸들러 - 모든 스트림(룸) 조회
+// joinToken 참가자용 LiveKit 토큰 생성 (생성자는 host, 계정 역할이 없으면 viewer 정책을 따름)
+// validUntil이 있으면 정책 유효 기간보다 짧게 그 시각까지만 유효 (대기열 입장 허가)
+func (h *StreamHandler) joinToken(ctx context.Context, principal *account.Principal, stream *catalog.Stream, roomId string, validUntil time.Time) (*ConnectionDetails, error) {
+	role := h.joinRole(principal)
+	if principal.Identity == stream.CreatorIdentity {
+		role = policy.RoleHost
+	}
+	primary := h.keys.Primary()
+	at, err := h.policies.NewAccessToken(primary.Key, primary.Secret, role, roomId, principal.Identity)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply grant policy").SetInternal(err)
+	}
+	at.SetName(principal.DisplayName)
+	// 진행자가 회수한 권한은 다시 참여해도 유지
+	if err := h.permissions.Apply(ctx, at, roomId, principal.Identity); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load participant permissions").SetInternal(err)
+	}
+	if !validUntil.IsZero() {
+		if p, err := h.policies.Lookup(role); err == nil && time.Until(validUntil) < p.ValidFor() {
+			at.SetValidFor(time.Until(validUntil))
+		}
+	}
+
+	livekitToken, err := at.ToJWT()
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate LiveKit token")
+	}
+	return &ConnectionDetails{
+		WSURL: h.clientWSURL,
+		Token: livekitToken,
+	}, nil
+}
+
+// joinRole 계정 역할(OIDC 클레임 매핑 등)에 해당하는 참가 역할
+// 호스트는 룸 생성자에게만 부여하므로 계정 역할로는 허용하지 않음
+func (h *StreamHandler) joinRole(principal *account.Principal) policy.Role {
+	role := policy.Role(principal.Role)
+	if role == "" || role == policy.RoleHost {
+		return policy.RoleViewer
+	}
+	if _, err := h.policies.Lookup(role); err != nil {
+		return policy.RoleViewer
+	}
+	return role
+}
+
+// ListStreams 핸들러 - 스트림 조회 (커서 페이지네이션, 필터/정렬은 parseListStreamsQuery 참고)
+// 기본은 진행 중인 스트림, include_upcoming=true면 예약 스트림, include_ended=true면 종료된 기록 포함
+// participants=false면 참가자 목록을 조회하지 않음 (가벼운 목록)
 func (h *StreamHandler) ListStreams(c echo.Context) error {
-	roomClient := lksdk.NewRoomServiceClient(h.hostURL, h.apiKey, h.apiSecret)
+	query, err := parseListStreamsQuery(c)
+	if err != nil {
+		return err
+	}
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	includeEnded := c.QueryParam("include_ended") == "true"
+	includeUpcoming := c.QueryParam("include_upcoming") == "true"
 
 	// 모든 룸 조회
 	rooms, err := roomClient.ListRooms(context.Background(), &livekit.ListRoomsRequest{})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list rooms")
 	}
+	liveRooms := make(map[string]*livekit.Room, len(rooms.Rooms))
+	for _, room := range rooms.Rooms {
+		liveRooms[room.Name] = room
+	}
 
-	// 응답 데이터 변환
-	var roomList []RoomInfo
+	// 카탈로그 기록 + 카탈로그에 없는 룸(인그레스 등)
+	// 요청한 상태의 인덱스만 읽어 기본 목록이 종료된 기록 전체를 불러오지 않도록 함
+	streams, err := h.streams.ListByState(c.Request().Context(), query.catalogStates(includeUpcoming, includeEnded)...)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list streams").SetInternal(err)
+	}
+	cataloged := make(map[string]bool, len(streams))
+	for _, stream := range streams {
+		cataloged[stream.RoomId] = true
+	}
 	for _, room := range rooms.Rooms {
-		var metadata map[string]interface{}
-		if room.Metadata != "" {
-			json.Unmarshal([]byte(room.Metadata), &metadata)
+		if cataloged[room.Name] {
+			continue
 		}
-
-		// 각 방의 참가자 정보 조회
-		var participants []ParticipantInfo
-		if room.NumParticipants > 0 {
-			participantsResp, err := roomClient.ListParticipants(context.Background(), &livekit.ListParticipantsRequest{
-				Room: room.Name,
-			})
-			if err == nil {
-				for _, participant := range participantsResp.Participants {
-					participantInfo := ParticipantInfo{
-						Identity:    participant.Identity,
-						Name:        participant.Name,
-						State:       participant.State.String(),
-						JoinedAt:    participant.JoinedAt,
-						IsPublisher: len(participant.Tracks) > 0,
-					}
-					participants = append(participants, participantInfo)
-				}
-			}
+		// 읽지 않은 상태의 기록(종료 후 남은 룸 등)은 룸 메타데이터가 아니라 기록의 상태를 따름
+		stream, err := h.streams.Get(c.Request().Context(), room.Name)
+		if errors.Is(err, catalog.ErrStreamNotFound) {
+			stream = catalog.FromRoom(room)
+		} else if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get stream").SetInternal(err)
 		}
+		streams = append(streams, stream)
+	}
 
-		roomInfo := RoomInfo{
-			RoomId:          room.Name,
-			Metadata:        metadata,
-			NumParticipants: room.NumParticipants,
-			CreationTime:    room.CreationTime,
-			EmptyTimeout:    room.EmptyTimeout,
-			MaxParticipants: room.MaxParticipants,
-			Participants:    participants,
+	// 응답 데이터 변환 (비공개 스트림은 생성자/운영자에게만 노출)
+	principal := account.PrincipalFrom(c)
+	roomList := []RoomInfo{}
+	for _, stream := range streams {
+		if stream.IsPrivate() && !canSeePrivate(principal, stream.CreatorIdentity) {
+			continue
+		}
+		roomInfo := newRoomInfo(stream, liveRooms[stream.RoomId])
+		if !query.wantsState(roomInfo.State, includeUpcoming, includeEnded) || !query.match(stream, roomInfo) {
+			continue
 		}
 		roomList = append(roomList, roomInfo)
-		fmt.Printf("[TESTDEBUG] ListStreams room name:[%s], participants:[%d]\n", roomInfo.RoomId, len(participants))
 	}
+	total := len(roomList)
+	page, nextCursor := query.page(roomList)
 
 	response := ListStreamsResponse{
-		Rooms: roomList,
-		Total: len(roomList),
+		Rooms:      page,
+		Total:      total,
+		NextCursor: nextCursor,
+	}
+
+	// 현재 페이지 방의 참가자 정보만 동시에 조회 (스냅샷 캐시 사용)
+	if c.QueryParam("participants") != "false" {
+		var roomIds []string
+		for _, info := range page {
+			if info.Live && info.NumParticipants > 0 {
+				roomIds = append(roomIds, info.RoomId)
+			}
+		}
+		participants, failed := h.roster.Collect(c.Request().Context(), roomIds, func(ctx context.Context, roomId string) ([]*livekit.ParticipantInfo, error) {
+			resp, err := roomClient.ListParticipants(ctx, &livekit.ListParticipantsRequest{Room: roomId})
+			if err != nil {
+				return nil, err
+			}
+			return resp.Participants, nil
+		})
+		for i := range page {
+			for _, participant := range participants[page[i].RoomId] {
+				if participant.GetPermission().GetHidden() {
+					continue
+				}
+				page[i].Participants = append(page[i].Participants, newParticipantInfo(participant))
+			}
+		}
+		response.FailedRooms = failed
 	}
 
-	fmt.Println("[TESTDEBUG] ListStreams roomList:", len(roomList))
+	fmt.Println("[TESTDEBUG] ListStreams roomList:", len(page))
 
 	return c.JSON(http.StatusOK, response)
 }
 
-// GetStream 핸들러 - 특정 스트림(룸) 조회
+// GetStream 핸들러 - 특정 스트림 조회 (종료된 스트림은 기록만 반환)
 func (h *StreamHandler) GetStream(c echo.Context) error {
-	roomId := c.Param("roomId")
+	roomId := c.Param("room_id")
 	if roomId == "" {
 		return echo.NewHTTPError(http.StatusBadRequest, "Room id is required")
 	}
 
-	roomClient := lksdk.NewRoomServiceClient(h.hostURL, h.apiKey, h.apiSecret)
+	roomClient := newRoomClient(h.hostURL, h.keys)
 
-	// 룸 정보 조회
-	rooms, err := roomClient.ListRooms(context.Background(), &livekit.ListRoomsRequest{
-		Names: []string{roomId},
-	})
+	// 스트림/룸 정보 조회
+	stream, room, err := h.lookupStream(c.Request().Context(), roomClient, roomId)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room")
 	}
 
-	if len(rooms.Rooms) == 0 {
+	// 비공개 스트림은 존재 여부도 노출하지 않음
+	if stream == nil || (stream.IsPrivate() && !canSeePrivate(account.PrincipalFrom(c), stream.CreatorIdentity)) {
 		return echo.NewHTTPError(http.StatusNotFound, "Room not found")
 	}
 
-	room := rooms.Rooms[0]
-	var metadata map[string]interface{}
-	if room.Metadata != "" {
-		json.Unmarshal([]byte(room.Metadata), &metadata)
-	}
-
-	roomInfo := RoomInfo{
-		RoomId:          room.Name,
-		Metadata:        metadata,
-		NumParticipants: room.NumParticipants,
-		CreationTime:    room.CreationTime,
-		EmptyTimeout:    room.EmptyTimeout,
-		MaxParticipants: room.MaxParticipants,
-	}
-
-	// 참가자 정보 조회
-	participants, err := roomClient.ListParticipants(context.Background(), &livekit.ListParticipantsRequest{
-		Room: roomId,
-	})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get participants").SetInternal(err)
-	}
-
-	var participantList []ParticipantInfo
-	for _, participant := range participants.Participants {
-		participantInfo := ParticipantInfo{
-			Identity:    participant.Identity,
-			Name:        participant.Name,
-			State:       participant.State.String(),
-			JoinedAt:    participant.JoinedAt,
-			IsPublisher: len(participant.Tracks) > 0,
+	// 참가자 정보 조회 (룸이 열려 있을 때만)
+	participantList := []ParticipantInfo{}
+	if room != nil {
+		participants, err := roomClient.ListParticipants(context.Background(), &livekit.ListParticipantsRequest{
+			Room: roomId,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get participants").SetInternal(err)
+		}
+		for _, participant := range participants.Participants {
+			// 숨김 참가자 (채팅 기록 봇 등)는 목록에 표시하지 않음
+			if participant.GetPermission().GetHidden() {
+				continue
+			}
+			participantList = append(participantList, newParticipantInfo(participant))
 		}
-		participantList = append(participantList, participantInfo)
 	}
 
 	response := GetStreamResponse{
-		Room:         roomInfo,
+		Room:         newRoomInfo(stream, room),
 		Participants: participantList,
+		StateHistory: stream.StateHistory,
+	}
+	if response.StateHistory == nil {
+		response.StateHistory = []catalog.StateChange{}
+	}
+	viewer := &authz.Actor{Principal: account.PrincipalFrom(c)}
+	if viewer.CanManage(stream.CreatorIdentity) {
+		response.Ingress = stream.Ingress
 	}
 
+	c.Response().Header().Set(HeaderETag, versionETag(stream.MetadataVersion))
 	return c.JSON(http.StatusOK, response)
 }
 
-// DeleteStream 핸들러 - 스트림(룸) 삭제
+// DeleteStream 핸들러 - 스트림 종료 (룸 삭제, 카탈로그 기록은 종료 상태로 유지)
 func (h *StreamHandler) DeleteStream(c echo.Context) error {
 	roomId := c.Param("room_id")
 	if roomId == "" {
 		return echo.NewHTTPError(http.StatusBadRequest, "Room id is required")
 	}
 
-	roomClient := lksdk.NewRoomServiceClient(h.hostURL, h.apiKey, h.apiSecret)
+	roomClient := newRoomClient(h.hostURL, h.keys)
+
+	// 생성자/운영자/관리자만 삭제 가능
+	stream, room, err := h.lookupStream(c.Request().Context(), roomClient, roomId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if stream == nil || (room == nil && stream.Ended()) {
+		return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(stream.CreatorIdentity) {
+		return authz.ErrForbidden()
+	}
 
 	fmt.Printf("[TESTDEBUG] DeleteStream room name:[%s]\n", roomId)
 	// 룸 삭제
-	_, err := roomClient.DeleteRoom(context.Background(), &livekit.DeleteRoomRequest{
-		Room: roomId,
-	})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete room").SetInternal(err)
+	if room != nil {
+		_, err = roomClient.DeleteRoom(context.Background(), &livekit.DeleteRoomRequest{
+			Room: roomId,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete room").SetInternal(err)
+		}
+	}
+	if stream.Ingress != nil && stream.Ingress.IngressId != "" {
+		newIngressClient(h.hostURL, h.keys).DeleteIngress(context.Background(), &livekit.DeleteIngressRequest{
+			IngressId: stream.Ingress.IngressId,
+		})
+	}
+	h.passcodes.Clear(c.Request().Context(), roomId)
+	h.waitlist.Clear(c.Request().Context(), roomId)
+
+	_, _, err = h.states.Apply(c.Request().Context(), roomId, lifecycle.Event{Type: lifecycle.EventEnded})
+	if err != nil && !errors.Is(err, catalog.ErrStreamNotFound) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update stream").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -364,3 +667,157 @@ func (h *StreamHandler) DeleteStream(c echo.Context) error {
 		"room_id": roomId,
 	})
 }
+
+// lookupStream 카탈로그 기록과 LiveKit 룸 조회
+// 카탈로그에 없으면 룸 메타데이터로 복원하며, 둘 다 없으면 stream이 nil
+func (h *StreamHandler) lookupStream(ctx context.Context, roomClient *lksdk.RoomServiceClient, roomId string) (*catalog.Stream, *livekit.Room, error) {
+	return findStream(ctx, h.streams, roomClient, roomId)
+}
+
+// findStream lookupStream과 같으며 카탈로그 저장소를 직접 받음 (다른 핸들러용)
+func findStream(ctx context.Context, streams catalog.Repository, roomClient *lksdk.RoomServiceClient, roomId string) (*catalog.Stream, *livekit.Room, error) {
+	room, err := findRoom(ctx, roomClient, roomId)
+	if err != nil {
+		return nil, nil, err
+	}
+	stream, err := streams.Get(ctx, roomId)
+	if errors.Is(err, catalog.ErrStreamNotFound) {
+		if room == nil {
+			return nil, nil, nil
+		}
+		return catalog.FromRoom(room), room, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return stream, room, nil
+}
+
+// newRoomInfo 카탈로그 기록과 (열려 있으면) 현재 룸 상태로 응답 생성
+func newRoomInfo(stream *catalog.Stream, room *livekit.Room) RoomInfo {
+	info := newStreamInfo(stream)
+	// room_finished 이벤트를 놓쳐 룸이 사라졌는데 진행 중으로 남은 기록
+	if room == nil && info.Live {
+		info.Live = false
+		info.State = catalog.StateEnded
+	}
+	if room != nil {
+		info.Live = true
+		info.NumParticipants = room.NumParticipants
+		info.EmptyTimeout = room.EmptyTimeout
+		info.MaxParticipants = room.MaxParticipants
+	}
+	return info
+}
+
+// newStreamInfo 카탈로그 기록만으로 응답 생성 (룸이 열려 있는지는 상태로 판단)
+func newStreamInfo(stream *catalog.Stream) RoomInfo {
+	info := RoomInfo{
+		RoomId:          stream.RoomId,
+		Metadata:        stream.Metadata,
+		CreationTime:    stream.CreatedAt.Unix(),
+		State:           stream.CurrentState(),
+		StateChangedAt:  stream.StateChangedAt().Unix(),
+		MetadataVersion: stream.MetadataVersion,
+		MaxViewers:      stream.MaxViewers,
+	}
+	info.Live = info.State != catalog.StateScheduled && info.State != catalog.StateEnded
+	if stream.EndedAt != nil {
+		info.EndedAt = stream.EndedAt.Unix()
+	}
+	if stream.ScheduledAt != nil {
+		info.ScheduledAt = stream.ScheduledAt.Unix()
+		info.NoShow = stream.NoShow
+	}
+	return info
+}
+
+func newParticipantInfo(participant *livekit.ParticipantInfo) ParticipantInfo {
+	return ParticipantInfo{
+		Identity:    participant.Identity,
+		Name:        participant.Name,
+		State:       participant.State.String(),
+		JoinedAt:    participant.JoinedAt,
+		IsPublisher: len(participant.Tracks) > 0,
+	}
+}
+
+// newRoomClient 현재 primary 키로 RoomService 클라이언트 생성 (키 교체 즉시 반영)
+func newRoomClient(hostURL string, keys *keyring.Ring) *lksdk.RoomServiceClient {
+	primary := keys.Primary()
+	return lksdk.NewRoomServiceClient(hostURL, primary.Key, primary.Secret)
+}
+
+// newIngressClient 현재 primary 키로 Ingress 클라이언트 생성
+func newIngressClient(hostURL string, keys *keyring.Ring) *lksdk.IngressClient {
+	primary := keys.Primary()
+	return lksdk.NewIngressClient(hostURL, primary.Key, primary.Secret)
+}
+
+// findRoom 이름으로 룸 조회 (없으면 nil)
+func findRoom(ctx context.Context, roomClient *lksdk.RoomServiceClient, roomId string) (*livekit.Room, error) {
+	rooms, err := roomClient.ListRooms(ctx, &livekit.ListRoomsRequest{
+		Names: []string{roomId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rooms.Rooms) == 0 {
+		return nil, nil
+	}
+	return rooms.Rooms[0], nil
+}
+
+// roomCreator 룸 메타데이터의 creator_identity
+func roomCreator(room *livekit.Room) string {
+	var metadata map[string]interface{}
+	if room.Metadata != "" {
+		json.Unmarshal([]byte(room.Metadata), &metadata)
+	}
+	creator, _ := metadata["creator_identity"].(string)
+	return creator
+}
+
+// isNotFound LiveKit API의 대상 없음 응답 (참가자/룸)
+func isNotFound(err error) bool {
+	var twerr twirp.Error
+	return errors.As(err, &twerr) && twerr.Code() == twirp.NotFound
+}
+
+// canSeePrivate 비공개 스트림을 초대 없이 보고 참여할 수 있는 사용자 (생성자, 운영자)
+func canSeePrivate(principal *account.Principal, creatorIdentity string) bool {
+	if principal == nil {
+		return false
+	}
+	return principal.Identity == creatorIdentity || policy.Role(principal.Role) == policy.RoleModerator
+}
+
+// inviteError 초대 코드 검증 실패를 HTTP 에러로 변환
+func inviteError(err error) error {
+	switch {
+	case errors.Is(err, invite.ErrInvalidInvite):
+		return echo.NewHTTPError(http.StatusForbidden, "Invalid invite code")
+	case errors.Is(err, invite.ErrInviteExpired):
+		return echo.NewHTTPError(http.StatusForbidden, "Invite code expired")
+	case errors.Is(err, invite.ErrInviteRevoked):
+		return echo.NewHTTPError(http.StatusForbidden, "Invite code revoked")
+	case errors.Is(err, invite.ErrInviteExhausted):
+		return echo.NewHTTPError(http.StatusForbidden, "Invite code has no uses left")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify invite code").SetInternal(err)
+}
+
+// passcodeError 비밀번호/PIN 검증 실패를 HTTP 에러로 변환 (잠금 시 Retry-After 설정)
+func passcodeError(c echo.Context, err error) error {
+	var locked *passcode.LockedError
+	switch {
+	case errors.As(err, &locked):
+		c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		return echo.NewHTTPError(http.StatusTooManyRequests, "Too many failed passcode attempts")
+	case errors.Is(err, passcode.ErrRequired):
+		return echo.NewHTTPError(http.StatusForbidden, "Room passcode is required")
+	case errors.Is(err, passcode.ErrWrongPasscode):
+		return echo.NewHTTPError(http.StatusForbidden, "Incorrect room passcode")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify room passcode").SetInternal(err)
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"backend/catalog"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListStreams 페이지 크기
+const (
+	defaultStreamPageSize = 50
+	maxStreamPageSize     = 100
+)
+
+// ListStreams 정렬 기준
+const (
+	sortByCreatedAt = "created_at"
+	sortByViewers   = "viewers"
+)
+
+// listStreamsQuery ListStreams 쿼리 (필터/정렬/커서)
+type listStreamsQuery struct {
+	limit           int
+	sortBy          string
+	ascending       bool
+	cursor          *streamCursor
+	types           map[string]bool // metadata.type
+	isPrivate       *bool
+	creatorIdentity string
+	states          map[catalog.State]bool
+	minParticipants int64 // -1이면 제한 없음
+	maxParticipants int64
+}
+
+// streamCursor 마지막으로 반환한 항목의 정렬 키 (룸이 생기거나 사라져도 위치가 밀리지 않음)
+type streamCursor struct {
+	SortBy    string `json:"s"`
+	Ascending bool   `json:"a,omitempty"`
+	Value     int64  `json:"v"`
+	RoomId    string `json:"r"`
+}
+
+func (c *streamCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeStreamCursor(raw string) (*streamCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+	var cursor streamCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// parseListStreamsQuery 쿼리 파싱
+//
+//	limit=50 (최대 100), cursor=<next_cursor>
+//	sort=created_at|viewers, order=desc|asc (기본 created_at desc)
+//	type=live,game  is_private=true|false  creator_identity=...
+//	state=live,paused,waiting-for-host,scheduled,ended (지정 시 include_upcoming/include_ended 무시)
+//	min_participants=, max_participants=
+func parseListStreamsQuery(c echo.Context) (*listStreamsQuery, error) {
+	q := &listStreamsQuery{
+		limit:           defaultStreamPageSize,
+		sortBy:          sortByCreatedAt,
+		creatorIdentity: c.QueryParam("creator_identity"),
+		minParticipants: -1,
+		maxParticipants: -1,
+	}
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "limit must be a positive integer")
+		}
+		q.limit = min(limit, maxStreamPageSize)
+	}
+
+	switch sortBy := c.QueryParam("sort"); sortBy {
+	case "":
+	case sortByCreatedAt, sortByViewers:
+		q.sortBy = sortBy
+	default:
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "sort must be created_at or viewers")
+	}
+	switch order := c.QueryParam("order"); order {
+	case "", "desc":
+	case "asc":
+		q.ascending = true
+	default:
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "order must be asc or desc")
+	}
+
+	if raw := c.QueryParam("cursor"); raw != "" {
+		cursor, err := decodeStreamCursor(raw)
+		if err != nil || cursor.SortBy != q.sortBy || cursor.Ascending != q.ascending {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+		q.cursor = cursor
+	}
+
+	if raw := c.QueryParam("type"); raw != "" {
+		q.types = make(map[string]bool)
+		for _, t := range strings.Split(raw, ",") {
+			q.types[strings.TrimSpace(t)] = true
+		}
+	}
+	if raw := c.QueryParam("is_private"); raw != "" {
+		isPrivate, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "is_private must be true or false")
+		}
+		q.isPrivate = &isPrivate
+	}
+	if raw := c.QueryParam("state"); raw != "" {
+		q.states = make(map[catalog.State]bool)
+		for _, s := range strings.Split(raw, ",") {
+			state := catalog.State(strings.TrimSpace(s))
+			switch state {
+			case catalog.StateScheduled, catalog.StateWaitingForHost, catalog.StateLive, catalog.StatePaused, catalog.StateEnded:
+				q.states[state] = true
+			default:
+				return nil, echo.NewHTTPError(http.StatusBadRequest, "Unknown state: "+string(state))
+			}
+		}
+	}
+
+	var err error
+	if q.minParticipants, err = parseCount(c.QueryParam("min_participants")); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "min_participants must be a non-negative integer")
+	}
+	if q.maxParticipants, err = parseCount(c.QueryParam("max_participants")); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "max_participants must be a non-negative integer")
+	}
+	return q, nil
+}
+
+// parseCount 빈 값이면 -1
+func parseCount(raw string) (int64, error) {
+	if raw == "" {
+		return -1, nil
+	}
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err == nil && n < 0 {
+		err = strconv.ErrRange
+	}
+	return n, err
+}
+
+// wantsState 상태 필터가 없을 때 기본 노출 여부는 include_upcoming/include_ended로 결정
+func (q *listStreamsQuery) wantsState(state catalog.State, includeUpcoming, includeEnded bool) bool {
+	if q.states != nil {
+		return q.states[state]
+	}
+	switch state {
+	case catalog.StateScheduled:
+		return includeUpcoming
+	case catalog.StateEnded:
+		return includeEnded
+	}
+	return true
+}
+
+// catalogStates 카탈로그 상태별 인덱스에서 읽을 상태
+// 룸이 사라졌는데 진행 중으로 남은 기록은 종료로 보이므로 ended를 원하면 진행 중 상태도 읽음
+func (q *listStreamsQuery) catalogStates(includeUpcoming, includeEnded bool) []catalog.State {
+	var states []catalog.State
+	for _, state := range catalog.States {
+		wanted := q.wantsState(state, includeUpcoming, includeEnded)
+		if !wanted && state != catalog.StateScheduled && state != catalog.StateEnded {
+			wanted = q.wantsState(catalog.StateEnded, includeUpcoming, includeEnded)
+		}
+		if wanted {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+// match 메타데이터/참가자 수 필터
+func (q *listStreamsQuery) match(stream *catalog.Stream, info RoomInfo) bool {
+	if q.types != nil {
+		t, _ := stream.Metadata["type"].(string)
+		if !q.types[t] {
+			return false
+		}
+	}
+	if q.isPrivate != nil && stream.IsPrivate() != *q.isPrivate {
+		return false
+	}
+	if q.creatorIdentity != "" && stream.CreatorIdentity != q.creatorIdentity {
+		return false
+	}
+	n := int64(info.NumParticipants)
+	if q.minParticipants >= 0 && n < q.minParticipants {
+		return false
+	}
+	if q.maxParticipants >= 0 && n > q.maxParticipants {
+		return false
+	}
+	return true
+}
+
+// sortKey 정렬 값 (같으면 room_id로 순서 고정)
+func (q *listStreamsQuery) sortKey(info RoomInfo) int64 {
+	if q.sortBy == sortByViewers {
+		return int64(info.NumParticipants)
+	}
+	return info.CreationTime
+}
+
+// before 정렬 순서상 a가 b보다 앞인지
+func (q *listStreamsQuery) before(aValue int64, aId string, bValue int64, bId string) bool {
+	if aValue != bValue {
+		return (aValue < bValue) == q.ascending
+	}
+	return aId < bId
+}
+
+// page 정렬 후 커서 다음부터 limit개와 다음 커서 반환
+func (q *listStreamsQuery) page(rooms []RoomInfo) ([]RoomInfo, string) {
+	sort.Slice(rooms, func(i, j int) bool {
+		return q.before(q.sortKey(rooms[i]), rooms[i].RoomId, q.sortKey(rooms[j]), rooms[j].RoomId)
+	})
+
+	start := 0
+	if q.cursor != nil {
+		start = sort.Search(len(rooms), func(i int) bool {
+			return q.before(q.cursor.Value, q.cursor.RoomId, q.sortKey(rooms[i]), rooms[i].RoomId)
+		})
+	}
+	end := min(start+q.limit, len(rooms))
+	page := rooms[start:end]
+
+	next := ""
+	if end < len(rooms) {
+		last := page[len(page)-1]
+		next = (&streamCursor{SortBy: q.sortBy, Ascending: q.ascending, Value: q.sortKey(last), RoomId: last.RoomId}).encode()
+	}
+	return page, next
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"backend/authz"
+	"backend/catalog"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+)
+
+// 메타데이터 동시 수정 방지 헤더 (echo에 상수가 없음)
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// 메타데이터 필드 제한
+const (
+	maxTitleLength       = 100
+	maxDescriptionLength = 1000
+	maxTypeLength        = 32
+	maxTags              = 10
+	maxTagLength         = 30
+	maxMetadataBytes     = 4096 // 병합 결과 JSON 크기 (LiveKit 룸 메타데이터로 전달)
+)
+
+// 수정할 수 없는 메타데이터 키 (생성자, 비밀번호/PIN 보호 방식)
+var reservedMetadataKeys = []string{"creator_identity", "protection", "password", "pin", "passcode"}
+
+// UpdateStream 요청 구조체
+// Metadata는 기존 메타데이터에 병합하며 null 값은 해당 키를 삭제한다.
+// 동시 수정 방지를 위해 If-Match 헤더(GetStream의 ETag) 또는 version 중 하나가 필요하다.
+type UpdateStreamRequest struct {
+	Metadata map[string]interface{} `json:"metadata"`
+	Version  *int64                 `json:"version,omitempty"`
+}
+
+// UpdateStream 핸들러 - 스트림 메타데이터 수정 (생성자/운영자/관리자)
+func (h *StreamHandler) UpdateStream(c echo.Context) error {
+	roomId := c.Param("room_id")
+	if roomId == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Room id is required")
+	}
+
+	var req UpdateStreamRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if len(req.Metadata) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "metadata is required")
+	}
+	if err := validateMetadataPatch(req.Metadata); err != nil {
+		return err
+	}
+	expected, err := expectedVersion(c, req.Version)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	stream, room, err := h.lookupStream(ctx, roomClient, roomId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if stream == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+	if actor := authz.ActorFrom(c); actor == nil || !actor.CanManage(stream.CreatorIdentity) {
+		// 비공개 스트림은 존재 여부도 노출하지 않음
+		if stream.IsPrivate() {
+			return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+		}
+		return authz.ErrForbidden()
+	}
+	if stream.Ended() {
+		return echo.NewHTTPError(http.StatusConflict, "Stream has ended")
+	}
+
+	// 카탈로그에 없는 룸(인그레스 등)은 룸 메타데이터로 기록을 먼저 만든다 (동시에 만들어진 기록은 덮어쓰지 않음)
+	if err := h.streams.Create(ctx, stream); err != nil && !errors.Is(err, catalog.ErrStreamExists) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save stream").SetInternal(err)
+	}
+
+	var current int64
+	stream, err = h.states.Update(ctx, roomId, func(stream *catalog.Stream) error {
+		current = stream.MetadataVersion
+		if stream.MetadataVersion != expected {
+			return catalog.ErrVersionConflict
+		}
+		if stream.Ended() {
+			return catalog.ErrUnchanged
+		}
+		metadata, err := mergeMetadata(stream.Metadata, req.Metadata)
+		if err != nil {
+			return err
+		}
+		stream.Metadata = metadata
+		stream.MetadataVersion++
+		return nil
+	})
+	switch {
+	case errors.Is(err, catalog.ErrVersionConflict):
+		c.Response().Header().Set(HeaderETag, versionETag(current))
+		return echo.NewHTTPError(http.StatusPreconditionFailed, "Stream was modified by someone else, reload and retry")
+	case err != nil:
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			return he
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update stream").SetInternal(err)
+	case stream.Ended():
+		return echo.NewHTTPError(http.StatusConflict, "Stream has ended")
+	}
+
+	// 카탈로그가 기준이므로 LiveKit 반영이 실패해도 다음 수정 때 전체 메타데이터가 다시 반영된다
+	if room != nil {
+		if err := h.pushRoomMetadata(ctx, stream); err != nil {
+			return echo.NewHTTPError(http.StatusBadGateway, "Stream updated but failed to update room metadata").SetInternal(err)
+		}
+	}
+
+	c.Response().Header().Set(HeaderETag, versionETag(stream.MetadataVersion))
+	return c.JSON(http.StatusOK, newRoomInfo(stream, room))
+}
+
+// pushRoomMetadata 카탈로그 메타데이터를 열려 있는 LiveKit 룸에 반영 (참가자에게 RoomMetadataChanged 전달)
+func (h *StreamHandler) pushRoomMetadata(ctx context.Context, stream *catalog.Stream) error {
+	metadata, err := stream.MetadataJSON()
+	if err != nil {
+		return err
+	}
+	_, err = newRoomClient(h.hostURL, h.keys).UpdateRoomMetadata(ctx, &livekit.UpdateRoomMetadataRequest{
+		Room:     stream.RoomId,
+		Metadata: metadata,
+	})
+	return err
+}
+
+// expectedVersion If-Match 헤더 또는 요청 본문의 version (둘 다 없으면 428)
+func expectedVersion(c echo.Context, version *int64) (int64, error) {
+	header := c.Request().Header.Get(HeaderIfMatch)
+	if header == "" {
+		if version == nil {
+			return 0, echo.NewHTTPError(http.StatusPreconditionRequired, "If-Match header or version is required")
+		}
+		return *version, nil
+	}
+	parsed, ok := parseETag(header)
+	if !ok || (version != nil && *version != parsed) {
+		return 0, echo.NewHTTPError(http.StatusPreconditionFailed, "If-Match does not match the current stream version")
+	}
+	return parsed, nil
+}
+
+// versionETag 메타데이터 버전의 ETag 표현
+func versionETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// parseETag versionETag 역변환 (약한 ETag 접두사 허용)
+func parseETag(tag string) (int64, bool) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	return version, err == nil && version >= 0
+}
+
+// validateMetadataPatch 알려진 필드 형식과 수정 불가 키 검사 (null은 삭제)
+func validateMetadataPatch(patch map[string]interface{}) error {
+	for _, key := range reservedMetadataKeys {
+		if _, ok := patch[key]; ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("metadata.%s cannot be changed", key))
+		}
+	}
+	for key, value := range patch {
+		if value == nil {
+			if key == "title" {
+				return echo.NewHTTPError(http.StatusBadRequest, "metadata.title cannot be removed")
+			}
+			continue
+		}
+		switch key {
+		case "title":
+			title, ok := value.(string)
+			if !ok || strings.TrimSpace(title) == "" || utf8.RuneCountInString(title) > maxTitleLength {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("metadata.title must be a non-empty string of at most %d characters", maxTitleLength))
+			}
+		case "description":
+			description, ok := value.(string)
+			if !ok || utf8.RuneCountInString(description) > maxDescriptionLength {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("metadata.description must be a string of at most %d characters", maxDescriptionLength))
+			}
+		case "type":
+			kind, ok := value.(string)
+			if !ok || kind == "" || len(kind) > maxTypeLength {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("metadata.type must be a non-empty string of at most %d characters", maxTypeLength))
+			}
+		case "isPrivate":
+			if _, ok := value.(bool); !ok {
+				return echo.NewHTTPError(http.StatusBadRequest, "metadata.isPrivate must be a boolean")
+			}
+		case "tags":
+			if !validTags(value) {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("metadata.tags must be at most %d non-empty strings of at most %d characters", maxTags, maxTagLength))
+			}
+		}
+	}
+	return nil
+}
+
+func validTags(value interface{}) bool {
+	tags, ok := value.([]interface{})
+	if !ok || len(tags) > maxTags {
+		return false
+	}
+	for _, tag := range tags {
+		s, ok := tag.(string)
+		if !ok || strings.TrimSpace(s) == "" || utf8.RuneCountInString(s) > maxTagLength {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeMetadata 기존 메타데이터에 변경분 병합 (원본은 수정하지 않음)
+func mergeMetadata(metadata, patch map[string]interface{}) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(metadata)+len(patch))
+	for key, value := range metadata {
+		merged[key] = value
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxMetadataBytes {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("metadata must be at most %d bytes", maxMetadataBytes))
+	}
+	return merged, nil
+}
@@ -1,54 +1,268 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"time"
 
+	"backend/account"
+	"backend/ban"
+	"backend/catalog"
+	"backend/keyring"
+	"backend/permission"
+	"backend/policy"
+	"backend/waitlist"
+
 	"github.com/labstack/echo/v4"
 	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
 )
 
+// RefreshToken 요청/응답 구조체
+type RefreshTokenRequest struct {
+	Token string `json:"token"`
+}
+
+type RefreshTokenResponse struct {
+	Token     string    `json:"token"`
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // TokenHandler 구조체
 type TokenHandler struct {
-	apiKey    string
-	apiSecret string
+	hostURL     string
+	keys        *keyring.Ring
+	policies    *policy.Engine
+	accounts    *account.Service
+	bans        *ban.Service
+	streams     catalog.Repository
+	waitlist    *waitlist.Service
+	permissions *permission.Service
 }
 
 // NewTokenHandler 생성자
-func NewTokenHandler(apiKey, apiSecret string) *TokenHandler {
+func NewTokenHandler(hostURL string, keys *keyring.Ring, policies *policy.Engine, accounts *account.Service, bans *ban.Service, streams catalog.Repository, waiting *waitlist.Service, permissions *permission.Service) *TokenHandler {
 	return &TokenHandler{
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
+		hostURL:     hostURL,
+		keys:        keys,
+		policies:    policies,
+		accounts:    accounts,
+		bans:        bans,
+		streams:     streams,
+		waitlist:    waiting,
+		permissions: permissions,
 	}
 }
 
-// GetToken 핸들러
+// GetToken 핸들러 - identity는 로그인 세션에서 결정
 func (h *TokenHandler) GetToken(c echo.Context) error {
-	room := c.QueryParam("room")
-	identity := c.QueryParam("identity")
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
 
+	room := c.QueryParam("room")
 	if room == "" {
 		room = "my-room"
 	}
-	if identity == "" {
-		identity = "identity"
+	if err := checkBan(c, h.bans, room, principal); err != nil {
+		return err
+	}
+	// 스트림은 비공개/정원/호스트 역할을 확인하는 join_stream으로만 참여 (발언자 토큰은 카탈로그에 없는 회의 룸 전용)
+	_, err := h.streams.Get(c.Request().Context(), room)
+	if err == nil {
+		return echo.NewHTTPError(http.StatusConflict, "Streams must be joined via /api/join_stream")
+	}
+	if !errors.Is(err, catalog.ErrStreamNotFound) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get stream").SetInternal(err)
 	}
 
-	token := h.createJoinToken(room, identity)
+	token, err := h.createJoinToken(c.Request().Context(), room, principal)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate LiveKit token").SetInternal(err)
+	}
 	return c.String(http.StatusOK, token)
 }
 
-// createJoinToken 헬퍼 함수
-func (h *TokenHandler) createJoinToken(room, identity string) string {
-	at := auth.NewAccessToken(h.apiKey, h.apiSecret)
-	grant := &auth.VideoGrant{
-		RoomJoin: true,
-		Room:     room,
+// createJoinToken 헬퍼 함수 - 회의 참가자는 speaker 정책으로 발급
+func (h *TokenHandler) createJoinToken(ctx context.Context, room string, principal *account.Principal) (string, error) {
+	primary := h.keys.Primary()
+	at, err := h.policies.NewAccessToken(primary.Key, primary.Secret, policy.RoleSpeaker, room, principal.Identity)
+	if err != nil {
+		return "", err
+	}
+	at.SetName(principal.DisplayName)
+	if err := h.permissions.Apply(ctx, at, room, principal.Identity); err != nil {
+		return "", err
+	}
+	return at.ToJWT()
+}
+
+// RefreshToken 핸들러 - 만료 전 LiveKit 토큰을 현재 정책으로 재발급
+func (h *TokenHandler) RefreshToken(c echo.Context) error {
+	var req RefreshTokenRequest
+	if err := c.Bind(&req); err != nil || req.Token == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "token is required")
+	}
+
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
 	}
-	at.AddGrant(grant).
-		SetIdentity(identity).
-		SetValidFor(time.Hour)
 
-	token, _ := at.ToJWT()
-	return token
+	// 서명 및 만료 검증 (이미 만료된 토큰은 재발급 불가)
+	// 키 교체 중에도 이전 키로 서명된 토큰은 재발급 허용
+	verifier, err := auth.ParseAPIToken(req.Token)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid LiveKit token")
+	}
+	secret := h.keys.GetSecret(verifier.APIKey())
+	if secret == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid LiveKit token")
+	}
+	claims, err := verifier.Verify(secret)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired LiveKit token").SetInternal(err)
+	}
+	if claims.Video == nil || claims.Video.Room == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Token has no room grant")
+	}
+	if claims.Identity != principal.Identity {
+		return echo.NewHTTPError(http.StatusForbidden, "Token does not belong to the current user")
+	}
+	// 강제 퇴장/차단된 참가자는 토큰을 갱신할 수 없음
+	if err := checkBan(c, h.bans, claims.Video.Room, principal); err != nil {
+		return err
+	}
+
+	// 룸이 아직 존재하는지, 역할이 여전히 유효한지 재확인
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	room, err := findRoom(c.Request().Context(), roomClient, claims.Video.Room)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get room").SetInternal(err)
+	}
+	if room == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Room not found")
+	}
+
+	role := policy.Role(claims.Attributes[policy.AttributeRole])
+	var admittedUntil time.Time
+	switch role {
+	case policy.RoleHost:
+		if roomCreator(room) != principal.Identity {
+			return echo.NewHTTPError(http.StatusForbidden, "No longer the host of this room")
+		}
+	case policy.RoleModerator, policy.RoleCoHost:
+		// 계정에서 운영자/공동 진행자 역할이 회수되었으면 시청자로 강등 (세션이 발급된 뒤의 변경도 반영)
+		current, err := h.accountRole(c.Request().Context(), principal)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get account").SetInternal(err)
+		}
+		if current != role {
+			role = policy.RoleViewer
+			break
+		}
+		if role != policy.RoleCoHost {
+			break
+		}
+		// 접속 중인 공동 진행자는 룸에서 바뀐 역할 속성(호스트의 강등)을 따름
+		participant, err := roomClient.GetParticipant(c.Request().Context(), &livekit.RoomParticipantIdentity{Room: room.Name, Identity: claims.Identity})
+		switch {
+		case err == nil:
+			if policy.Role(participant.Attributes[policy.AttributeRole]) != policy.RoleCoHost {
+				role = policy.RoleViewer
+			}
+		case isNotFound(err):
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get participant").SetInternal(err)
+		}
+	case policy.RoleBot:
+		return echo.NewHTTPError(http.StatusForbidden, "Bot tokens cannot be refreshed")
+	case "", policy.RoleViewer, policy.RoleSpeaker:
+		// 무대 승격/강등은 토큰이 아니라 참가자 속성에 반영되므로 현재 역할을 따름
+		participant, err := roomClient.GetParticipant(c.Request().Context(), &livekit.RoomParticipantIdentity{Room: room.Name, Identity: claims.Identity})
+		switch {
+		case err == nil:
+			role = policy.RoleViewer
+			if policy.Role(participant.Attributes[policy.AttributeRole]) == policy.RoleSpeaker {
+				role = policy.RoleSpeaker
+			}
+		case isNotFound(err):
+			// 아직 접속하지 않았거나 재접속 중: 카탈로그 기록이 없는 룸(/getToken 회의)은 발언자 권한 유지
+			stream, err := h.streams.Get(c.Request().Context(), room.Name)
+			if errors.Is(err, catalog.ErrStreamNotFound) {
+				if role != policy.RoleSpeaker {
+					role = policy.RoleViewer
+				}
+				break
+			}
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get stream").SetInternal(err)
+			}
+			role = policy.RoleViewer
+			// 정원이 있는 스트림은 입장 허가가 남아 있을 때만 그 시각까지 재발급 (허가 없이 좌석을 다시 얻지 않도록)
+			if stream.MaxViewers > 0 && !canSeePrivate(principal, stream.CreatorIdentity) {
+				status, err := h.waitlist.Status(c.Request().Context(), room.Name, principal.Identity)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check waitlist").SetInternal(err)
+				}
+				if !status.Admitted {
+					return echo.NewHTTPError(http.StatusConflict, "Waitlist admission expired, rejoin via /api/join_stream")
+				}
+				admittedUntil = status.AdmittedUntil
+			}
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get participant").SetInternal(err)
+		}
+	}
+
+	// 정책이 바뀌었으면 갱신된 권한/TTL로 발급
+	primary := h.keys.Primary()
+	at, err := h.policies.NewAccessToken(primary.Key, primary.Secret, role, claims.Video.Room, claims.Identity)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to apply grant policy").SetInternal(err)
+	}
+	p, _ := h.policies.Lookup(role)
+	validFor := p.ValidFor()
+	if !admittedUntil.IsZero() && time.Until(admittedUntil) < validFor {
+		validFor = time.Until(admittedUntil)
+		at.SetValidFor(validFor)
+	}
+	at.SetName(claims.Name)
+	if claims.Metadata != "" {
+		at.SetMetadata(claims.Metadata)
+	}
+	// 진행자가 회수한 권한은 갱신해도 유지
+	if err := h.permissions.Apply(c.Request().Context(), at, claims.Video.Room, claims.Identity); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load participant permissions").SetInternal(err)
+	}
+
+	token, err := at.ToJWT()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate LiveKit token")
+	}
+
+	return c.JSON(http.StatusOK, RefreshTokenResponse{
+		Token:     token,
+		Role:      string(role),
+		ExpiresAt: time.Now().Add(validFor),
+	})
+}
+
+// accountRole 계정 저장소에서 현재 역할 조회 (삭제된 계정은 역할 없음)
+// OIDC 주체는 계정 저장소에 없으므로 로그인할 때 IdP가 준 역할을 따름
+func (h *TokenHandler) accountRole(ctx context.Context, principal *account.Principal) (policy.Role, error) {
+	if principal.Provider != account.ProviderLocal {
+		return policy.Role(principal.Role), nil
+	}
+	user, err := h.accounts.User(ctx, principal.UserID)
+	if errors.Is(err, account.ErrUserNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return policy.Role(user.Role), nil
 }
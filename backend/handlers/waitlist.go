@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"backend/account"
+	"backend/catalog"
+	"backend/lifecycle"
+	"backend/waitlist"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+)
+
+// 대기 상태 SSE가 순번/입장 허가를 확인하는 주기
+const waitlistPollInterval = 2 * time.Second
+
+// WaitlistResponse 대기 상태 (JoinStream 202 응답, 대기 상태 조회/구독)
+type WaitlistResponse struct {
+	RoomId   string `json:"room_id"`
+	Status   string `json:"status"`   // waiting, admitted
+	Position int    `json:"position"` // 1부터 시작하는 대기 순번 (허가되면 0)
+	// 입장 허가 시 발급한 토큰 (허가 후 일정 시간 안에 접속하지 않으면 좌석이 다음 사람에게 넘어가고 토큰도 만료)
+	// 초대 코드가 필요한 비공개 스트림은 토큰 없이 admitted만 반환하며, 클라이언트가 초대 코드로 join_stream을 다시 호출
+	ConnectionDetails *ConnectionDetails `json:"connection_details,omitempty"`
+}
+
+func newWaitlistResponse(roomId string, status waitlist.Status, details *ConnectionDetails) WaitlistResponse {
+	res := WaitlistResponse{
+		RoomId:            roomId,
+		Status:            "waiting",
+		Position:          status.Position,
+		ConnectionDetails: details,
+	}
+	if status.Admitted {
+		res.Status = "admitted"
+	}
+	return res
+}
+
+// joinWaitlist 대기열에 넣고 빈 좌석이 있으면 바로 입장 허가
+// wait가 false이고 바로 입장할 수 없으면 대기열에서 빼고 409 (이미 대기 중이던 사용자는 유지)
+func (h *StreamHandler) joinWaitlist(ctx context.Context, stream *catalog.Stream, identity string, wait bool) (waitlist.Status, error) {
+	before, err := h.waitlist.Status(ctx, stream.RoomId, identity)
+	if err != nil {
+		return waitlist.Status{}, echo.NewHTTPError(http.StatusInternalServerError, "Failed to check waitlist").SetInternal(err)
+	}
+	seats, err := h.freeSeats(ctx, stream)
+	if err != nil {
+		return waitlist.Status{}, echo.NewHTTPError(http.StatusInternalServerError, "Failed to check room capacity").SetInternal(err)
+	}
+	status, err := h.waitlist.Join(ctx, stream.RoomId, identity, seats)
+	if err != nil {
+		return waitlist.Status{}, echo.NewHTTPError(http.StatusInternalServerError, "Failed to join waitlist").SetInternal(err)
+	}
+	if !status.Admitted && !wait && !before.Waiting() {
+		h.waitlist.Leave(ctx, stream.RoomId, identity)
+		return waitlist.Status{}, echo.NewHTTPError(http.StatusConflict, "Room is full")
+	}
+	return status, nil
+}
+
+// freeSeats 정원에서 현재 시청자(호스트/인그레스/에이전트 제외)를 뺀 좌석 수
+// 입장 허가 후 아직 접속하지 않은 좌석은 대기열 저장소가 따로 센다.
+func (h *StreamHandler) freeSeats(ctx context.Context, stream *catalog.Stream) (int, error) {
+	roomClient := newRoomClient(h.hostURL, h.keys)
+	participants, failed := h.roster.Collect(ctx, []string{stream.RoomId}, func(ctx context.Context, roomId string) ([]*livekit.ParticipantInfo, error) {
+		res, err := roomClient.ListParticipants(ctx, &livekit.ListParticipantsRequest{Room: roomId})
+		if err != nil {
+			return nil, err
+		}
+		return res.Participants, nil
+	})
+	if len(failed) > 0 {
+		return 0, fmt.Errorf("list participants of %s", stream.RoomId)
+	}
+	viewers := 0
+	for _, participant := range participants[stream.RoomId] {
+		ev := lifecycle.Event{Identity: participant.Identity, Ingress: participant.Kind == livekit.ParticipantInfo_INGRESS}
+		if participant.Kind != livekit.ParticipantInfo_AGENT && !lifecycle.IsHost(stream, ev) {
+			viewers++
+		}
+	}
+	return int(stream.MaxViewers) - viewers, nil
+}
+
+// waitlistStatus 빈 좌석만큼 대기열을 진행한 뒤 요청자의 상태 조회 (허가되었으면 토큰 발급)
+// 대기 중에 차단된 사용자는 대기열에서 빼고 거절
+func (h *StreamHandler) waitlistStatus(c echo.Context, principal *account.Principal, roomId string) (*WaitlistResponse, error) {
+	ctx := c.Request().Context()
+	stream, err := h.streams.Get(ctx, roomId)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Not in waitlist")
+	}
+	if stream.Ended() {
+		return nil, echo.NewHTTPError(http.StatusGone, "Stream has ended")
+	}
+	if err := checkBan(c, h.bans, roomId, principal); err != nil {
+		h.waitlist.Leave(ctx, roomId, principal.Identity)
+		return nil, err
+	}
+	if seats, err := h.freeSeats(ctx, stream); err == nil {
+		h.waitlist.Advance(ctx, roomId, seats)
+	}
+	status, err := h.waitlist.Status(ctx, roomId, principal.Identity)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to check waitlist").SetInternal(err)
+	}
+	if !status.Waiting() {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Not in waitlist")
+	}
+	var details *ConnectionDetails
+	// 초대 코드는 토큰을 발급할 때 사용 처리하므로 비공개 스트림은 join_stream으로 받음
+	needsInvite := stream.IsPrivate() && !canSeePrivate(principal, stream.CreatorIdentity)
+	if status.Admitted && !needsInvite {
+		if details, err = h.joinToken(ctx, principal, stream, roomId, status.AdmittedUntil); err != nil {
+			return nil, err
+		}
+	}
+	res := newWaitlistResponse(roomId, status, details)
+	return &res, nil
+}
+
+// GetWaitlist 핸들러 - 대기 순번 조회 (입장 허가되면 connection_details 포함)
+func (h *StreamHandler) GetWaitlist(c echo.Context) error {
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+	res, err := h.waitlistStatus(c, principal, c.Param("room_id"))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// WaitlistEvents 핸들러 - 대기 순번 SSE (순번이 바뀔 때 position, 입장 허가 시 admitted 후 종료)
+func (h *StreamHandler) WaitlistEvents(c echo.Context) error {
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+	ctx := c.Request().Context()
+	roomId := c.Param("room_id")
+	status, err := h.waitlistStatus(c, principal, roomId)
+	if err != nil {
+		return err
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // nginx 버퍼링 해제
+	res.WriteHeader(http.StatusOK)
+
+	send := func(event string, data interface{}) error {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+
+	ticker := time.NewTicker(waitlistPollInterval)
+	defer ticker.Stop()
+	position := -1
+	for {
+		switch {
+		case status.Status == "admitted":
+			send("admitted", status)
+			return nil
+		case status.Position != position:
+			if err := send("position", status); err != nil {
+				return nil
+			}
+			position = status.Position
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		status, err = h.waitlistStatus(c, principal, roomId)
+		if he, ok := err.(*echo.HTTPError); ok {
+			// 대기열에서 빠졌거나 스트림이 끝남
+			send("removed", map[string]interface{}{"room_id": roomId, "message": he.Message})
+			return nil
+		}
+	}
+}
+
+// LeaveWaitlist 핸들러 - 대기 포기 (허가받은 좌석도 반납)
+func (h *StreamHandler) LeaveWaitlist(c echo.Context) error {
+	principal := account.PrincipalFrom(c)
+	if principal == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Authentication required")
+	}
+	roomId := c.Param("room_id")
+	if err := h.waitlist.Leave(c.Request().Context(), roomId, principal.Identity); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to leave waitlist").SetInternal(err)
+	}
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Left waitlist",
+		"room_id": roomId,
+	})
+}
+
+// AdmitWaiting 웹훅 구독자 - 허가받은 시청자가 접속하면 좌석 예약 해제, 시청자가 나가면 다음 대기자 허가
+// participants 구독자(참가자 캐시 무효화) 뒤에 등록해야 빈 좌석을 바로 센다.
+func (h *StreamHandler) AdmitWaiting(ctx context.Context, event *livekit.WebhookEvent) error {
+	roomId := event.GetRoom().GetName()
+	if roomId == "" {
+		return nil
+	}
+	switch event.Event {
+	case webhook.EventRoomFinished:
+		return h.waitlist.Clear(ctx, roomId)
+	case webhook.EventParticipantJoined:
+		return h.waitlist.Leave(ctx, roomId, event.GetParticipant().GetIdentity())
+	case webhook.EventParticipantLeft:
+		stream, err := h.streams.Get(ctx, roomId)
+		if err != nil || stream.MaxViewers == 0 || stream.Ended() {
+			return nil
+		}
+		seats, err := h.freeSeats(ctx, stream)
+		if err != nil {
+			return err
+		}
+		_, err = h.waitlist.Advance(ctx, roomId, seats)
+		return err
+	}
+	return nil
+}
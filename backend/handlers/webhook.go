@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"backend/events"
+	"backend/keyring"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/webhook"
+)
+
+// WebhookHandler 구조체
+type WebhookHandler struct {
+	keys       *keyring.Ring
+	dispatcher *events.Dispatcher
+	seen       events.Deduper
+}
+
+// NewWebhookHandler 생성자
+func NewWebhookHandler(keys *keyring.Ring, dispatcher *events.Dispatcher, seen events.Deduper) *WebhookHandler {
+	return &WebhookHandler{
+		keys:       keys,
+		dispatcher: dispatcher,
+		seen:       seen,
+	}
+}
+
+// ReceiveLiveKit 핸들러 - LiveKit 웹훅 수신
+// Authorization 헤더의 JWT를 키 링으로 검증하고 본문 SHA-256을 확인한 뒤,
+// 이미 처리한 이벤트 ID는 건너뛰고 나머지는 내부 구독자에게 전달
+// 구독자가 실패하면 5xx로 응답해 LiveKit이 재전송하게 하고, 재전송된 이벤트는 실패한 구독자만 다시 호출
+func (h *WebhookHandler) ReceiveLiveKit(c echo.Context) error {
+	event, err := webhook.ReceiveWebhookEvent(c.Request(), h.keys)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid webhook signature").SetInternal(err)
+	}
+
+	ctx := c.Request().Context()
+	var skip map[string]bool
+	if event.Id != "" {
+		// 중복 확인이 안 되더라도 이벤트는 처리 (구독자는 중복에 안전해야 함)
+		processed, err := h.seen.Processed(ctx, event.Id)
+		if err != nil {
+			log.Println("webhook dedupe failed:", err)
+		} else if processed {
+			return c.NoContent(http.StatusOK)
+		}
+		if skip, err = h.seen.Handled(ctx, event.Id); err != nil {
+			log.Println("webhook dedupe failed:", err)
+		}
+	}
+
+	handled, err := h.dispatcher.Dispatch(ctx, event, skip)
+	if err != nil {
+		if event.Id != "" {
+			if err := h.seen.MarkHandled(ctx, event.Id, handled...); err != nil {
+				log.Println("webhook dedupe failed:", err)
+			}
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Webhook subscriber failed").SetInternal(err)
+	}
+	if event.Id != "" {
+		if err := h.seen.MarkProcessed(ctx, event.Id); err != nil {
+			log.Println("webhook dedupe failed:", err)
+		}
+	}
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,191 @@
+package invite
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidInvite   = errors.New("invalid invite code")
+	ErrInviteExpired   = errors.New("invite code expired")
+	ErrInviteRevoked   = errors.New("invite code revoked")
+	ErrInviteExhausted = errors.New("invite code has no uses left")
+	ErrInviteNotFound  = errors.New("invite not found")
+)
+
+// Invite 비공개 스트림 초대 정보
+type Invite struct {
+	ID        string    `json:"id"`
+	RoomId    string    `json:"room_id"`
+	CreatedBy string    `json:"created_by"`
+	MaxUses   int       `json:"max_uses"` // 0이면 무제한
+	Uses      int       `json:"uses"`
+	Revoked   bool      `json:"revoked"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store 초대 저장소 (사용 횟수, 회수 여부 관리)
+type Store interface {
+	Save(ctx context.Context, inv *Invite) error
+	Get(ctx context.Context, id string) (*Invite, error)
+	List(ctx context.Context, roomId string) ([]*Invite, error)
+	Revoke(ctx context.Context, id string) error
+	// Use 사용 횟수를 원자적으로 1 증가 (회수됨/소진 시 에러)
+	Use(ctx context.Context, id string) error
+}
+
+// payload 초대 코드에 서명되어 담기는 값
+type payload struct {
+	ID        string `json:"i"`
+	RoomId    string `json:"r"`
+	ExpiresAt int64  `json:"e"`
+}
+
+// Service 초대 코드 발급/검증
+type Service struct {
+	store  Store
+	secret []byte
+}
+
+// NewService 생성자
+func NewService(store Store, secret string) *Service {
+	return &Service{
+		store:  store,
+		secret: []byte(secret),
+	}
+}
+
+// Mint 초대 코드 발급
+func (s *Service) Mint(ctx context.Context, roomId, createdBy string, ttl time.Duration, maxUses int) (*Invite, string, error) {
+	now := time.Now()
+	inv := &Invite{
+		ID:        newID(),
+		RoomId:    roomId,
+		CreatedBy: createdBy,
+		MaxUses:   maxUses,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if err := s.store.Save(ctx, inv); err != nil {
+		return nil, "", err
+	}
+	return inv, s.sign(payload{ID: inv.ID, RoomId: roomId, ExpiresAt: inv.ExpiresAt.Unix()}), nil
+}
+
+// Check 사용 처리 없이 초대 코드가 지금 쓸 수 있는지 확인 (회수/소진 포함)
+// 거절될 수 있는 다른 검사를 먼저 하고, 토큰을 발급할 때 Redeem으로 사용 처리
+func (s *Service) Check(ctx context.Context, code, roomId string) error {
+	p, err := s.parse(code, roomId)
+	if err != nil {
+		return err
+	}
+	inv, err := s.store.Get(ctx, p.ID)
+	if errors.Is(err, ErrInviteNotFound) {
+		return ErrInvalidInvite
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case inv.Revoked:
+		return ErrInviteRevoked
+	case inv.MaxUses > 0 && inv.Uses >= inv.MaxUses:
+		return ErrInviteExhausted
+	}
+	return nil
+}
+
+// Redeem 초대 코드 검증 후 사용 처리
+func (s *Service) Redeem(ctx context.Context, code, roomId string) error {
+	p, err := s.parse(code, roomId)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Use(ctx, p.ID); err != nil {
+		if errors.Is(err, ErrInviteNotFound) {
+			return ErrInvalidInvite
+		}
+		return err
+	}
+	return nil
+}
+
+// parse 서명, 룸, 만료 시각 검증
+func (s *Service) parse(code, roomId string) (*payload, error) {
+	p, err := s.verify(code)
+	if err != nil {
+		return nil, err
+	}
+	if p.RoomId != roomId {
+		return nil, ErrInvalidInvite
+	}
+	if time.Now().Unix() >= p.ExpiresAt {
+		return nil, ErrInviteExpired
+	}
+	return p, nil
+}
+
+// List 룸의 초대 목록
+func (s *Service) List(ctx context.Context, roomId string) ([]*Invite, error) {
+	return s.store.List(ctx, roomId)
+}
+
+// Revoke 초대 회수 (다른 룸의 초대는 회수 불가)
+func (s *Service) Revoke(ctx context.Context, roomId, id string) error {
+	inv, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if inv.RoomId != roomId {
+		return ErrInviteNotFound
+	}
+	return s.store.Revoke(ctx, id)
+}
+
+// sign base64url(payload).base64url(HMAC-SHA256)
+func (s *Service) sign(p payload) string {
+	data, _ := json.Marshal(p)
+	body := base64.RawURLEncoding.EncodeToString(data)
+	return body + "." + base64.RawURLEncoding.EncodeToString(s.mac(body))
+}
+
+func (s *Service) verify(code string) (*payload, error) {
+	body, sig, ok := strings.Cut(code, ".")
+	if !ok {
+		return nil, ErrInvalidInvite
+	}
+	got, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, s.mac(body)) {
+		return nil, ErrInvalidInvite
+	}
+	data, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidInvite
+	}
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil || p.ID == "" {
+		return nil, ErrInvalidInvite
+	}
+	return &p, nil
+}
+
+func (s *Service) mac(body string) []byte {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write([]byte(body))
+	return m.Sum(nil)
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
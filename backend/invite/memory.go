@@ -0,0 +1,83 @@
+package invite
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore 인메모리 초대 저장소
+type MemoryStore struct {
+	mu      sync.Mutex
+	invites map[string]*Invite
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{invites: make(map[string]*Invite)}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, inv *Invite) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *inv
+	s.invites[inv.ID] = &copied
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Invite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv, ok := s.invites[id]
+	if !ok {
+		return nil, ErrInviteNotFound
+	}
+	copied := *inv
+	return &copied, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, roomId string) ([]*Invite, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var list []*Invite
+	for _, inv := range s.invites {
+		if inv.RoomId == roomId {
+			copied := *inv
+			list = append(list, &copied)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (s *MemoryStore) Revoke(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv, ok := s.invites[id]
+	if !ok {
+		return ErrInviteNotFound
+	}
+	inv.Revoked = true
+	return nil
+}
+
+func (s *MemoryStore) Use(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv, ok := s.invites[id]
+	switch {
+	case !ok:
+		return ErrInviteNotFound
+	case inv.Revoked:
+		return ErrInviteRevoked
+	case inv.MaxUses > 0 && inv.Uses >= inv.MaxUses:
+		return ErrInviteExhausted
+	}
+	inv.Uses++
+	return nil
+}
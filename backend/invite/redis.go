@@ -0,0 +1,142 @@
+package invite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 만료 후에도 목록에서 확인할 수 있도록 잠시 보관
+const redisRetention = 24 * time.Hour
+
+// useScript 회수/소진 확인과 사용 횟수 증가를 원자적으로 수행
+var useScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then return -1 end
+if redis.call('HGET', KEYS[1], 'revoked') == '1' then return -2 end
+local max = tonumber(redis.call('HGET', KEYS[1], 'max_uses'))
+local uses = tonumber(redis.call('HGET', KEYS[1], 'uses'))
+if max > 0 and uses >= max then return -3 end
+return redis.call('HINCRBY', KEYS[1], 'uses', 1)
+`)
+
+// RedisStore Redis 기반 초대 저장소
+//
+//	invite:<id>          hash (data, max_uses, uses, revoked)
+//	invite:room:<room>   초대 id 집합
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func inviteKey(id string) string {
+	return "invite:" + id
+}
+
+func roomInvitesKey(roomId string) string {
+	return "invite:room:" + roomId
+}
+
+func (s *RedisStore) Save(ctx context.Context, inv *Invite) error {
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(inv.ExpiresAt) + redisRetention
+
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, inviteKey(inv.ID), map[string]interface{}{
+			"data":     data,
+			"max_uses": inv.MaxUses,
+			"uses":     inv.Uses,
+			"revoked":  boolToInt(inv.Revoked),
+		})
+		pipe.Expire(ctx, inviteKey(inv.ID), ttl)
+		pipe.SAdd(ctx, roomInvitesKey(inv.RoomId), inv.ID)
+		pipe.Expire(ctx, roomInvitesKey(inv.RoomId), ttl)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*Invite, error) {
+	fields, err := s.rdb.HGetAll(ctx, inviteKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrInviteNotFound
+	}
+
+	var inv Invite
+	if err := json.Unmarshal([]byte(fields["data"]), &inv); err != nil {
+		return nil, err
+	}
+	inv.Uses, _ = strconv.Atoi(fields["uses"])
+	inv.Revoked = fields["revoked"] == "1"
+	return &inv, nil
+}
+
+func (s *RedisStore) List(ctx context.Context, roomId string) ([]*Invite, error) {
+	ids, err := s.rdb.SMembers(ctx, roomInvitesKey(roomId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*Invite
+	for _, id := range ids {
+		inv, err := s.Get(ctx, id)
+		if errors.Is(err, ErrInviteNotFound) {
+			s.rdb.SRem(ctx, roomInvitesKey(roomId), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, inv)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (s *RedisStore) Revoke(ctx context.Context, id string) error {
+	n, err := s.rdb.Exists(ctx, inviteKey(id)).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrInviteNotFound
+	}
+	return s.rdb.HSet(ctx, inviteKey(id), "revoked", 1).Err()
+}
+
+func (s *RedisStore) Use(ctx context.Context, id string) error {
+	res, err := useScript.Run(ctx, s.rdb, []string{inviteKey(id)}).Int()
+	if err != nil {
+		return err
+	}
+	switch res {
+	case -1:
+		return ErrInviteNotFound
+	case -2:
+		return ErrInviteRevoked
+	case -3:
+		return ErrInviteExhausted
+	}
+	return nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,144 @@
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrNoKeys = errors.New("no LiveKit API keys configured")
+
+// KeyPair LiveKit API 키/시크릿
+type KeyPair struct {
+	Key    string
+	Secret string
+}
+
+// File 키 파일 형식
+//
+//	primary: APIkey2         # 토큰 서명 및 LiveKit API 호출에 사용
+//	keys:
+//	  APIkey1: secret1       # 교체 중인 이전 키 (검증만 허용)
+//	  APIkey2: secret2
+type File struct {
+	Primary string            `yaml:"primary"`
+	Keys    map[string]string `yaml:"keys"`
+}
+
+// Ring LiveKit API 키 묶음
+// primary 키로 토큰을 서명하고, 나머지 키는 웹훅/토큰 검증에만 허용
+// Reload 시 파일을 다시 읽어 세션 중단 없이 키를 교체
+type Ring struct {
+	path     string
+	fallback KeyPair // 키 파일이 없을 때 사용하는 단일 키 (LIVEKIT_API_KEY/SECRET)
+
+	mu      sync.RWMutex
+	primary KeyPair
+	secrets map[string]string
+}
+
+// New 생성자 - path가 비어 있으면 fallback 단일 키만 사용
+func New(path string, fallback KeyPair) (*Ring, error) {
+	r := &Ring{
+		path:     path,
+		fallback: fallback,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload 키 파일 다시 읽기 (실패 시 기존 키 유지)
+func (r *Ring) Reload() error {
+	primary, secrets, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.primary = primary
+	r.secrets = secrets
+	return nil
+}
+
+func (r *Ring) load() (KeyPair, map[string]string, error) {
+	if r.path == "" {
+		if r.fallback.Key == "" || r.fallback.Secret == "" {
+			return KeyPair{}, nil, ErrNoKeys
+		}
+		return r.fallback, map[string]string{r.fallback.Key: r.fallback.Secret}, nil
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return KeyPair{}, nil, err
+	}
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return KeyPair{}, nil, fmt.Errorf("parse %s: %w", r.path, err)
+	}
+	if len(f.Keys) == 0 {
+		return KeyPair{}, nil, ErrNoKeys
+	}
+	for key, secret := range f.Keys {
+		if key == "" || secret == "" {
+			return KeyPair{}, nil, fmt.Errorf("key %q has an empty secret", key)
+		}
+	}
+
+	// primary 미지정 시 키가 하나뿐일 때만 자동 선택
+	primary := f.Primary
+	if primary == "" {
+		if len(f.Keys) != 1 {
+			return KeyPair{}, nil, errors.New("primary key must be set when multiple keys are configured")
+		}
+		for key := range f.Keys {
+			primary = key
+		}
+	}
+	secret, ok := f.Keys[primary]
+	if !ok {
+		return KeyPair{}, nil, fmt.Errorf("primary key %q is not in keys", primary)
+	}
+	return KeyPair{Key: primary, Secret: secret}, f.Keys, nil
+}
+
+// Primary 토큰 서명 및 API 호출용 키
+func (r *Ring) Primary() KeyPair {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.primary
+}
+
+// GetSecret 검증용 키 조회 (없으면 빈 문자열, auth.KeyProvider 구현)
+func (r *Ring) GetSecret(key string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.secrets[key]
+}
+
+// NumKeys 등록된 키 수 (auth.KeyProvider 구현)
+func (r *Ring) NumKeys() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.secrets)
+}
+
+// Keys 등록된 키 이름 (시크릿 제외)
+func (r *Ring) Keys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]string, 0, len(r.secrets))
+	for key := range r.secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
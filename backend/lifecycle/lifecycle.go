@@ -0,0 +1,240 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/catalog"
+)
+
+// 스트림당 보관하는 최대 전이 기록 수
+const maxHistory = 50
+
+// 생성 시 기록하는 전이 원인
+const causeCreated = "created"
+
+// 인그레스 참가자 identity 접미사 (handlers.CreateIngress와 동일)
+const ingressIdentitySuffix = " (via OBS)"
+
+// EventType 상태 전이를 일으키는 이벤트 (LiveKit 웹훅 이벤트 이름과 동일하게 사용)
+type EventType string
+
+const (
+	EventProvisioned       EventType = "provisioned" // 스케줄러가 예약 스트림의 룸 생성
+	EventRoomStarted       EventType = "room_started"
+	EventRoomFinished      EventType = "room_finished"
+	EventParticipantJoined EventType = "participant_joined"
+	EventParticipantLeft   EventType = "participant_left"
+	EventTrackPublished    EventType = "track_published"
+	EventTrackUnpublished  EventType = "track_unpublished"
+	EventIngressStarted    EventType = "ingress_started"
+	EventIngressEnded      EventType = "ingress_ended"
+	EventEnded             EventType = "ended"   // 호스트/관리자가 스트림 삭제
+	EventNoShow            EventType = "no_show" // 예약 시각이 지나도 호스트가 나타나지 않음
+)
+
+// Event 상태 전이 입력
+type Event struct {
+	Type     EventType
+	Identity string // 참가자/트랙 이벤트의 참가자 identity
+	Ingress  bool   // 인그레스 참가자 여부
+	At       time.Time
+}
+
+// IsHost 이벤트 주체가 호스트(생성자 또는 생성자의 인그레스)인지
+func IsHost(stream *catalog.Stream, ev Event) bool {
+	if ev.Ingress {
+		return true
+	}
+	return ev.Identity != "" &&
+		(ev.Identity == stream.CreatorIdentity || ev.Identity == stream.CreatorIdentity+ingressIdentitySuffix)
+}
+
+// Apply 이벤트를 스트림에 반영하고 상태가 바뀌었는지 반환
+//
+//	scheduled        -> waiting-for-host (룸 생성)
+//	waiting-for-host -> live             (호스트 트랙 발행 / 인그레스 시작)
+//	live             -> paused           (호스트의 마지막 트랙 해제 / 호스트 퇴장 / 인그레스 종료)
+//	paused           -> live             (호스트 트랙 발행 / 인그레스 시작)
+//	*                -> ended            (룸 종료 / 삭제 / no-show)
+//
+// ended는 최종 상태이며 이후 이벤트는 무시한다.
+func Apply(stream *catalog.Stream, ev Event) bool {
+	current := stream.CurrentState()
+	if current == catalog.StateEnded {
+		return false
+	}
+
+	next := current
+	switch ev.Type {
+	case EventProvisioned, EventRoomStarted:
+		if current == catalog.StateScheduled {
+			next = catalog.StateWaitingForHost
+		}
+
+	case EventParticipantJoined:
+		if IsHost(stream, ev) && stream.HostJoinedAt == nil {
+			at := ev.At
+			stream.HostJoinedAt = &at
+		}
+
+	case EventParticipantLeft:
+		if !IsHost(stream, ev) {
+			break
+		}
+		stream.HostTracks = 0
+		if current == catalog.StateLive {
+			next = catalog.StatePaused
+		}
+
+	case EventTrackPublished:
+		if !IsHost(stream, ev) {
+			break
+		}
+		stream.HostTracks++
+		if current != catalog.StateLive {
+			next = catalog.StateLive
+		}
+
+	case EventTrackUnpublished:
+		if !IsHost(stream, ev) {
+			break
+		}
+		if stream.HostTracks > 0 {
+			stream.HostTracks--
+		}
+		if stream.HostTracks == 0 && current == catalog.StateLive {
+			next = catalog.StatePaused
+		}
+
+	case EventIngressStarted:
+		if current != catalog.StateLive {
+			next = catalog.StateLive
+		}
+
+	case EventIngressEnded:
+		if current == catalog.StateLive {
+			next = catalog.StatePaused
+		}
+
+	case EventRoomFinished, EventEnded, EventNoShow:
+		next = catalog.StateEnded
+		if stream.EndedAt == nil {
+			at := ev.At
+			stream.EndedAt = &at
+		}
+		if ev.Type == EventNoShow {
+			stream.NoShow = true
+		}
+	}
+
+	if next == current && stream.State != "" {
+		return false
+	}
+	stream.State = next
+	stream.StateHistory = append(stream.StateHistory, catalog.StateChange{
+		State: next,
+		At:    ev.At,
+		Cause: string(ev.Type),
+	})
+	if n := len(stream.StateHistory); n > maxHistory {
+		stream.StateHistory = stream.StateHistory[n-maxHistory:]
+	}
+	return true
+}
+
+// Start 새 스트림의 초기 상태 기록 (예약 스트림은 scheduled, 그 외 waiting-for-host)
+func Start(stream *catalog.Stream, at time.Time) {
+	state := catalog.StateWaitingForHost
+	if stream.ScheduledAt != nil && stream.ProvisionedAt == nil {
+		state = catalog.StateScheduled
+	}
+	stream.State = state
+	stream.StateHistory = []catalog.StateChange{{State: state, At: at, Cause: causeCreated}}
+}
+
+// Machine 카탈로그에 저장된 스트림에 이벤트 반영
+// 읽기-반영-저장은 catalog.Repository.Update로 원자적으로 수행하고,
+// 같은 인스턴스 안에서는 OnChange 호출 순서가 저장 순서와 같도록 직렬화한다.
+type Machine struct {
+	streams   catalog.Repository
+	mu        sync.Mutex
+	observers []func(ctx context.Context, stream *catalog.Stream)
+}
+
+// NewMachine 생성자
+func NewMachine(streams catalog.Repository) *Machine {
+	return &Machine{streams: streams}
+}
+
+// Create 새 스트림의 초기 상태를 기록해 저장 (OnChange 함수도 호출)
+// 같은 room_id의 기록이 이미 있으면 덮어쓰지 않고 catalog.ErrStreamExists
+func (m *Machine) Create(ctx context.Context, stream *catalog.Stream) error {
+	Start(stream, stream.CreatedAt)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.streams.Create(ctx, stream); err != nil {
+		return err
+	}
+	for _, observer := range m.observers {
+		observer(ctx, stream)
+	}
+	return nil
+}
+
+// Created 방금 생성되어 아직 전이가 없는 스트림인지
+func Created(stream *catalog.Stream) bool {
+	return len(stream.StateHistory) == 1 && stream.StateHistory[0].Cause == causeCreated
+}
+
+// OnChange 상태나 메타데이터가 바뀐 스트림을 저장한 뒤 호출할 함수 등록 (시작 전에 등록)
+func (m *Machine) OnChange(fn func(ctx context.Context, stream *catalog.Stream)) {
+	m.observers = append(m.observers, fn)
+}
+
+// Update 스트림을 읽어 fn으로 수정하고 저장 (fn이 catalog.ErrUnchanged를 반환하면 저장하지 않음)
+// 카탈로그에 없는 룸이면 catalog.ErrStreamNotFound
+func (m *Machine) Update(ctx context.Context, roomId string, fn func(stream *catalog.Stream) error) (*catalog.Stream, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var state catalog.State
+	var version int64
+	stream, err := m.streams.Update(ctx, roomId, func(stream *catalog.Stream) error {
+		state, version = stream.CurrentState(), stream.MetadataVersion
+		return fn(stream)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stream.CurrentState() != state || stream.MetadataVersion != version {
+		for _, observer := range m.observers {
+			observer(ctx, stream)
+		}
+	}
+	return stream, nil
+}
+
+// Apply 스트림을 읽어 이벤트를 반영하고 저장, 상태가 바뀌었는지 반환
+func (m *Machine) Apply(ctx context.Context, roomId string, ev Event) (*catalog.Stream, bool, error) {
+	if ev.At.IsZero() {
+		ev.At = time.Now()
+	}
+
+	changed := false
+	stream, err := m.Update(ctx, roomId, func(stream *catalog.Stream) error {
+		tracks, hostJoined := stream.HostTracks, stream.HostJoinedAt
+		changed = Apply(stream, ev)
+		if !changed && stream.HostTracks == tracks && stream.HostJoinedAt == hostJoined {
+			return catalog.ErrUnchanged
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return stream, changed, nil
+}
@@ -0,0 +1,45 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/catalog"
+	"backend/events"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+)
+
+// HandleWebhook 웹훅 구독자 - LiveKit 이벤트로 스트림 상태 전이
+// 카탈로그에 없는 룸(인그레스로 직접 만든 룸 등)의 이벤트는 무시
+func (m *Machine) HandleWebhook(ctx context.Context, event *livekit.WebhookEvent) error {
+	ev := Event{Type: EventType(event.Event)}
+	if event.CreatedAt > 0 {
+		ev.At = time.Unix(event.CreatedAt, 0)
+	}
+	switch event.Event {
+	case webhook.EventParticipantJoined, webhook.EventParticipantLeft,
+		webhook.EventTrackPublished, webhook.EventTrackUnpublished:
+		if event.Participant == nil {
+			return nil
+		}
+		ev.Identity = event.Participant.Identity
+		ev.Ingress = event.Participant.Kind == livekit.ParticipantInfo_INGRESS
+	case webhook.EventRoomStarted, webhook.EventRoomFinished,
+		webhook.EventIngressStarted, webhook.EventIngressEnded:
+	default:
+		return nil
+	}
+
+	roomId := events.RoomName(event)
+	if roomId == "" {
+		return nil
+	}
+	_, _, err := m.Apply(ctx, roomId, ev)
+	if errors.Is(err, catalog.ErrStreamNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,170 @@
+package lobby
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 로비 이벤트 종류
+const (
+	TypeStreamCreated  = "stream.created"
+	TypeStreamUpdated  = "stream.updated" // 상태/메타데이터 변경
+	TypeStreamEnded    = "stream.ended"
+	TypeViewersChanged = "viewers.changed"
+	TypeReset          = "reset" // 재개할 수 없을 만큼 오래된 Last-Event-ID (목록을 다시 조회해야 함)
+)
+
+// 재개용으로 보관하는 최근 이벤트 수
+const retainEvents = 1000
+
+// ErrGap Last-Event-ID 이후 이벤트 일부가 이미 정리됨
+var ErrGap = errors.New("events since last event id are no longer retained")
+
+// Event 로비 이벤트
+// ID는 "<unix ms>-<seq>" 형식 (Redis Stream ID와 동일)으로 인스턴스 간 순서 비교 가능
+type Event struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	RoomId string          `json:"room_id"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	At     time.Time       `json:"at"`
+
+	// 구독자별 노출 여부 판단용 (클라이언트에는 보내지 않음)
+	Private         bool   `json:"private,omitempty"`
+	CreatorIdentity string `json:"creator_identity,omitempty"`
+}
+
+// Broker 이벤트 저장 및 인스턴스 간 전달
+type Broker interface {
+	// Publish ID를 부여해 저장하고 모든 인스턴스의 Run으로 전달
+	Publish(ctx context.Context, event *Event) error
+	// Since lastID 이후 보관 중인 이벤트 (오래된 순), 정리된 구간이 있으면 ErrGap
+	Since(ctx context.Context, lastID string) ([]*Event, error)
+	// Run ctx가 끝날 때까지 새 이벤트를 deliver로 전달
+	Run(ctx context.Context, deliver func(*Event))
+}
+
+// compareID 이벤트 ID 비교 (-1, 0, 1), 형식이 잘못되면 가장 오래된 것으로 취급
+func compareID(a, b string) int {
+	am, as := parseID(a)
+	bm, bs := parseID(b)
+	switch {
+	case am < bm || (am == bm && as < bs):
+		return -1
+	case am == bm && as == bs:
+		return 0
+	}
+	return 1
+}
+
+func parseID(id string) (int64, int64) {
+	ms, seq, _ := strings.Cut(id, "-")
+	m, _ := strconv.ParseInt(ms, 10, 64)
+	s, _ := strconv.ParseInt(seq, 10, 64)
+	return m, s
+}
+
+// Subscription 구독 (Events가 닫히면 너무 느린 구독자로 끊긴 것)
+type Subscription struct {
+	Events <-chan *Event
+	events chan *Event
+	hub    *Hub
+}
+
+// Close 구독 해제
+func (s *Subscription) Close() {
+	s.hub.remove(s)
+}
+
+// Hub 인스턴스 내 구독자에게 이벤트 전달
+type Hub struct {
+	broker Broker
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+}
+
+// NewHub 생성자
+func NewHub(broker Broker) *Hub {
+	return &Hub{
+		broker:      broker,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Run 브로커에서 받은 이벤트를 구독자에게 전달 (ctx가 끝날 때까지)
+func (h *Hub) Run(ctx context.Context) {
+	h.broker.Run(ctx, h.deliver)
+}
+
+// Publish 이벤트 발행 (data는 JSON으로 직렬화)
+func (h *Hub) Publish(ctx context.Context, event *Event, data interface{}) error {
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		event.Data = raw
+	}
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	return h.broker.Publish(ctx, event)
+}
+
+// Subscribe 구독 시작, lastID가 있으면 그 이후 보관 중인 이벤트를 함께 반환
+// 구독을 먼저 등록한 뒤 보관 이벤트를 읽으므로 그 사이 발행된 이벤트가 양쪽에 있을 수 있다.
+// 호출자는 backlog의 마지막 ID 이하인 실시간 이벤트를 건너뛰어야 한다 (Skip 참고).
+func (h *Hub) Subscribe(ctx context.Context, lastID string) (*Subscription, []*Event, error) {
+	events := make(chan *Event, 64)
+	sub := &Subscription{Events: events, events: events, hub: h}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	if lastID == "" {
+		return sub, nil, nil
+	}
+	backlog, err := h.broker.Since(ctx, lastID)
+	if err != nil && !errors.Is(err, ErrGap) {
+		sub.Close()
+		return nil, nil, err
+	}
+	return sub, backlog, err
+}
+
+// Skip 이미 전달한 ID 이하의 이벤트인지
+func Skip(event *Event, lastID string) bool {
+	return lastID != "" && compareID(event.ID, lastID) <= 0
+}
+
+func (h *Hub) deliver(event *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			// 따라오지 못하는 구독자는 끊고 Last-Event-ID로 재접속하게 함
+			delete(h.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
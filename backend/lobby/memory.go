@@ -0,0 +1,76 @@
+package lobby
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MemoryBroker 인메모리 이벤트 보관 (단일 인스턴스용)
+type MemoryBroker struct {
+	mu      sync.Mutex
+	events  []*Event
+	trimmed string // 마지막으로 정리된 이벤트 ID
+	lastMs  int64
+	seq     int64
+	deliver func(*Event)
+}
+
+// NewMemoryBroker 생성자
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, event *Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ms := time.Now().UnixMilli()
+	if ms <= b.lastMs {
+		ms = b.lastMs
+		b.seq++
+	} else {
+		b.seq = 0
+	}
+	b.lastMs = ms
+	event.ID = fmt.Sprintf("%d-%d", ms, b.seq)
+
+	b.events = append(b.events, event)
+	if len(b.events) > retainEvents {
+		b.trimmed = b.events[0].ID
+		b.events = b.events[1:]
+	}
+	if b.deliver != nil {
+		b.deliver(event)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Since(ctx context.Context, lastID string) ([]*Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.trimmed != "" && compareID(lastID, b.trimmed) < 0 {
+		return nil, ErrGap
+	}
+	var events []*Event
+	for _, event := range b.events {
+		if compareID(event.ID, lastID) > 0 {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (b *MemoryBroker) Run(ctx context.Context, deliver func(*Event)) {
+	b.mu.Lock()
+	b.deliver = deliver
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	b.deliver = nil
+	b.mu.Unlock()
+}
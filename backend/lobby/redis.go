@@ -0,0 +1,99 @@
+package lobby
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Stream 키 (모든 인스턴스가 같은 스트림을 읽음)
+const streamKey = "lobby:events"
+
+// RedisBroker Redis Stream 기반 이벤트 보관/전달 (인스턴스 간 공유, ID는 Redis Stream ID)
+type RedisBroker struct {
+	rdb *redis.Client
+}
+
+// NewRedisBroker 생성자
+func NewRedisBroker(rdb *redis.Client) *RedisBroker {
+	return &RedisBroker{rdb: rdb}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	id, err := b.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		MaxLen: retainEvents,
+		Approx: true,
+		Values: map[string]interface{}{"event": data},
+	}).Result()
+	if err != nil {
+		return err
+	}
+	event.ID = id
+	return nil
+}
+
+func (b *RedisBroker) Since(ctx context.Context, lastID string) ([]*Event, error) {
+	first, err := b.rdb.XRangeN(ctx, streamKey, "-", "+", 1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(first) > 0 && compareID(lastID, first[0].ID) < 0 {
+		return nil, ErrGap
+	}
+
+	messages, err := b.rdb.XRange(ctx, streamKey, "("+lastID, "+").Result()
+	if err != nil {
+		return nil, err
+	}
+	return decode(messages), nil
+}
+
+func (b *RedisBroker) Run(ctx context.Context, deliver func(*Event)) {
+	lastID := "$"
+	for ctx.Err() == nil {
+		streams, err := b.rdb.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{streamKey, lastID},
+			Block:   5 * time.Second,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Println("lobby event read failed:", err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+		for _, stream := range streams {
+			for _, event := range decode(stream.Messages) {
+				deliver(event)
+			}
+			if n := len(stream.Messages); n > 0 {
+				lastID = stream.Messages[n-1].ID
+			}
+		}
+	}
+}
+
+func decode(messages []redis.XMessage) []*Event {
+	events := make([]*Event, 0, len(messages))
+	for _, message := range messages {
+		raw, _ := message.Values["event"].(string)
+		var event Event
+		if err := json.Unmarshal([]byte(raw), &event); err != nil {
+			continue
+		}
+		event.ID = message.ID
+		events = append(events, &event)
+	}
+	return events
+}
@@ -1,15 +1,46 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"backend/account"
+	"backend/analytics"
+	"backend/audit"
+	"backend/authz"
+	"backend/ban"
+	"backend/catalog"
+	"backend/chat"
+	"backend/chatmod"
+	"backend/events"
 	"backend/handlers"
+	"backend/invite"
+	"backend/keyring"
+	"backend/lifecycle"
+	"backend/lobby"
+	"backend/oidc"
+	"backend/passcode"
+	"backend/permission"
+	"backend/policy"
+	"backend/ratelimit"
+	"backend/roster"
 	"backend/routes"
+	"backend/scheduler"
+	"backend/search"
+	"backend/stage"
+	"backend/waitlist"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/livekit/protocol/webhook"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -22,13 +53,19 @@ func main() {
 
 		e.DefaultHTTPErrorHandler(err, c)
 	}
-	// 미들웨어 설정
-	e.Use(middleware.Logger())
+	// 미들웨어 설정 (쿼리 문자열의 티켓 등이 접근 로그에 남지 않도록 uri 대신 path 기록)
+	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
+		Format: `{"time":"${time_rfc3339_nano}","id":"${id}","remote_ip":"${remote_ip}",` +
+			`"host":"${host}","method":"${method}","path":"${path}","user_agent":"${user_agent}",` +
+			`"status":${status},"error":"${error}","latency":${latency},"latency_human":"${latency_human}"` +
+			`,"bytes_in":${bytes_in},"bytes_out":${bytes_out}}` + "\n",
+	}))
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept},
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{echo.GET, echo.POST, echo.PUT, echo.PATCH, echo.DELETE, echo.OPTIONS},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, handlers.HeaderIfMatch, authz.HeaderAdminKey},
+		ExposeHeaders: []string{echo.HeaderRetryAfter, handlers.HeaderETag},
 	}))
 
 	// 환경 변수 가져오기
@@ -42,25 +79,272 @@ func main() {
 		clientWSURL = "ws://localhost:7880"
 	}
 
+	// 클라이언트 IP (IP별 요청 제한, IP 차단, 비밀번호 시도 제한에 사용)
+	// X-Forwarded-For는 TRUSTED_PROXIES(쉼표로 구분한 CIDR)에서 온 요청만 신뢰, 미설정 시 접속한 주소 사용
+	ipExtractor, err := newIPExtractor(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES: ", err)
+	}
+	e.IPExtractor = ipExtractor
+
 	fmt.Println("hostURL", hostURL)
 	fmt.Println("clientWSURL", clientWSURL)
-	fmt.Println("apiKey", apiKey)
-	fmt.Println("apiSecret", apiSecret)
 
 	// 환경 변수 검증
-	if hostURL == "" || apiKey == "" || apiSecret == "" {
+	if hostURL == "" {
 		log.Fatal("LiveKit environment variables not configured")
 	}
 
+	// LiveKit API 키 묶음 (LIVEKIT_KEYS_FILE 미설정 시 LIVEKIT_API_KEY/SECRET 단일 키 사용)
+	keys, err := keyring.New(os.Getenv("LIVEKIT_KEYS_FILE"), keyring.KeyPair{Key: apiKey, Secret: apiSecret})
+	if err != nil {
+		log.Fatal("Failed to load LiveKit API keys: ", err)
+	}
+	fmt.Println("apiKeys", keys.Keys(), "primary", keys.Primary().Key)
+
+	// 역할별 권한 정책 로드 (GRANT_POLICY_FILE 미설정 시 기본 정책 사용)
+	policies, err := policy.NewEngine(os.Getenv("GRANT_POLICY_FILE"))
+	if err != nil {
+		log.Fatal("Failed to load grant policies: ", err)
+	}
+
+	// 채팅 관리 규칙 (CHAT_MODERATION_FILE 미설정 시 필터 없이 반복 위반 음소거 기본값만 사용)
+	chatRules, err := chatmod.NewRules(os.Getenv("CHAT_MODERATION_FILE"))
+	if err != nil {
+		log.Fatal("Failed to load chat moderation rules: ", err)
+	}
+	go reloadOnSignal(policies.Reload, keys.Reload, chatRules.Reload)
+
+	// Redis 연결 (REDIS_URL 미설정 시 인메모리 저장소 사용)
+	var rdb *redis.Client
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		opts, err := redis.ParseURL(redisURL)
+		if err != nil {
+			log.Fatal("Invalid REDIS_URL: ", err)
+		}
+		rdb = redis.NewClient(opts)
+	}
+
+	// 계정 서비스 생성
+	sessionSecret := os.Getenv("SESSION_SECRET")
+	if sessionSecret == "" {
+		log.Fatal("SESSION_SECRET not configured")
+	}
+	var accountStore account.Store = account.NewMemoryStore()
+	if rdb != nil {
+		accountStore = account.NewRedisStore(rdb)
+	}
+	sessions := account.NewSessionIssuer(sessionSecret, 24*time.Hour)
+	accounts := account.NewService(accountStore, sessions)
+	// SSE/WebSocket 쿼리 문자열 인증용 1회용 스트림 티켓 (세션 토큰을 URL에 넣지 않음)
+	var ticketStore account.TicketStore = account.NewMemoryTicketStore()
+	if rdb != nil {
+		ticketStore = account.NewRedisTicketStore(rdb)
+	}
+	tickets := account.NewTickets(ticketStore, account.DefaultTicketTTL)
+
+	// OIDC provider 설정 로드 (OIDC_CONFIG_FILE 미설정 시 로컬 계정만 사용)
+	var oidcConfig *oidc.Config
+	if path := os.Getenv("OIDC_CONFIG_FILE"); path != "" {
+		oidcConfig, err = oidc.LoadFile(path)
+		if err != nil {
+			log.Fatal("Failed to load OIDC config: ", err)
+		}
+	}
+
+	// 비공개 스트림 초대 서비스 (INVITE_SECRET 미설정 시 세션 서명 키 사용)
+	inviteSecret := os.Getenv("INVITE_SECRET")
+	if inviteSecret == "" {
+		inviteSecret = sessionSecret
+	}
+	var inviteStore invite.Store = invite.NewMemoryStore()
+	if rdb != nil {
+		inviteStore = invite.NewRedisStore(rdb)
+	}
+	invites := invite.NewService(inviteStore, inviteSecret)
+
+	// 룸 비밀번호/PIN 서비스 (실패 횟수 기반 잠금 포함)
+	var passcodeStore passcode.Store = passcode.NewMemoryStore()
+	var passcodeThrottle passcode.Throttle = passcode.NewMemoryThrottle(passcode.DefaultThrottleConfig())
+	if rdb != nil {
+		passcodeStore = passcode.NewRedisStore(rdb)
+		passcodeThrottle = passcode.NewRedisThrottle(rdb, passcode.DefaultThrottleConfig())
+	}
+	passcodes := passcode.NewService(passcodeStore, passcodeThrottle)
+
+	// 차단 목록 서비스
+	var banStore ban.Store = ban.NewMemoryStore()
+	if rdb != nil {
+		banStore = ban.NewRedisStore(rdb)
+	}
+	bans := ban.NewService(banStore)
+
+	// 스트림 카탈로그 (룸이 사라진 뒤에도 스트림 기록 유지)
+	var streams catalog.Repository = catalog.NewMemoryRepository()
+	if rdb != nil {
+		redisStreams := catalog.NewRedisRepository(rdb)
+		// 상태별/예약 인덱스 도입 전에 저장된 기록도 목록과 스케줄러에 나오도록 재구성
+		if err := redisStreams.Reindex(context.Background()); err != nil {
+			log.Fatal("Failed to index stream catalog: ", err)
+		}
+		streams = redisStreams
+	}
+	// 스트림 상태 전이 (scheduled, waiting-for-host, live, paused, ended)
+	states := lifecycle.NewMachine(streams)
+
+	// 예약 스트림 스케줄러 (Redis 사용 시 한 인스턴스에서만 실행)
+	var schedulerLock scheduler.Locker
+	if rdb != nil {
+		schedulerLock = scheduler.NewRedisLocker(rdb)
+	}
+	provisioner := scheduler.NewLiveKitProvisioner(hostURL, keys)
+	go scheduler.New(streams, states, provisioner, schedulerLock, scheduler.DefaultConfig()).Run(context.Background())
+
+	// 토큰/룸 생성 요청 제한 (Redis 사용 시 인스턴스 간 공유)
+	var limiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+	if rdb != nil {
+		limiter = ratelimit.NewRedisLimiter(rdb)
+	}
+
+	// 관리자 API 키 (쉼표로 구분, 스트림/인그레스 삭제 등 관리 작업용)
+	adminKeys := authz.NewAdminKeys(strings.Split(os.Getenv("ADMIN_API_KEYS"), ","))
+
 	// 핸들러 생성
-	ingressHandler := handlers.NewIngressHandler(hostURL, apiKey, apiSecret)
-	tokenHandler := handlers.NewTokenHandler(apiKey, apiSecret)
-	streamHandler := handlers.NewStreamHandler(hostURL, clientWSURL, apiKey, apiSecret)
+	ingressHandler := handlers.NewIngressHandler(hostURL, keys, policies, streams, states)
+	// 진행자가 바꾼 참가자 권한 (다시 참여하거나 토큰을 갱신해도 유지)
+	var permissionStore permission.Store = permission.NewMemoryStore()
+	if rdb != nil {
+		permissionStore = permission.NewRedisStore(rdb)
+	}
+	permissions := permission.NewService(permissionStore)
+	participants := roster.NewCache(roster.DefaultConfig())
+	// 정원이 찬 룸의 시청자 대기열
+	var waitlistStore waitlist.Store = waitlist.NewMemoryStore()
+	if rdb != nil {
+		waitlistStore = waitlist.NewRedisStore(rdb)
+	}
+	waiting := waitlist.NewService(waitlistStore, waitlist.DefaultConfig())
+	tokenHandler := handlers.NewTokenHandler(hostURL, keys, policies, accounts, bans, streams, waiting, permissions)
+	streamHandler := handlers.NewStreamHandler(hostURL, clientWSURL, keys, policies, invites, passcodes, bans, streams, states, participants, waiting, permissions)
+	accountHandler := handlers.NewAccountHandler(accounts, tickets)
+	inviteHandler := handlers.NewInviteHandler(hostURL, keys, streams, invites)
+	banHandler := handlers.NewBanHandler(hostURL, keys, bans)
+	oidcHandler := handlers.NewOIDCHandler(oidc.NewRegistry(oidcConfig), oidc.NewMemoryStateStore(), sessions)
+
+	// LiveKit 웹훅 구독자 (LiveKit 서버 설정의 webhook.urls에 /webhooks/livekit 등록 필요)
+	var seenEvents events.Deduper = events.NewMemoryDeduper()
+	if rdb != nil {
+		seenEvents = events.NewRedisDeduper(rdb)
+	}
+	dispatcher := events.NewDispatcher()
+	dispatcher.Subscribe("lifecycle", states.HandleWebhook)
+	dispatcher.Subscribe("bans", banHandler.EnforceBans, webhook.EventParticipantJoined)
+	dispatcher.Subscribe("participants", participants.HandleWebhook)
+	dispatcher.Subscribe("permissions", permissions.HandleWebhook, webhook.EventRoomFinished)
+	dispatcher.Subscribe("waitlist", streamHandler.AdmitWaiting, webhook.EventParticipantJoined, webhook.EventParticipantLeft, webhook.EventRoomFinished)
+	webhookHandler := handlers.NewWebhookHandler(keys, dispatcher, seenEvents)
+
+	// 로비 실시간 이벤트 (Redis 사용 시 인스턴스 간 공유 및 재개 지원)
+	var lobbyBroker lobby.Broker = lobby.NewMemoryBroker()
+	if rdb != nil {
+		lobbyBroker = lobby.NewRedisBroker(rdb)
+	}
+	hub := lobby.NewHub(lobbyBroker)
+	go hub.Run(context.Background())
+	lobbyHandler := handlers.NewLobbyHandler(hub)
+	states.OnChange(lobbyHandler.StreamChanged)
+	dispatcher.Subscribe("lobby", lobbyHandler.HandleWebhook, webhook.EventParticipantJoined, webhook.EventParticipantLeft)
+
+	// 스트림 검색 색인 (인스턴스별 인메모리, 로비 이벤트로 갱신)
+	searchIndex, err := search.NewIndex()
+	if err != nil {
+		log.Fatal("Failed to create search index: ", err)
+	}
+	searchHandler := handlers.NewSearchHandler(searchIndex, streams)
+	go searchHandler.Sync(context.Background(), hub)
+
+	// 시청 통계 (시청자 입장/퇴장을 웹훅으로 받아 스트림별 집계 갱신)
+	var analyticsStore analytics.Store = analytics.NewMemoryStore()
+	if rdb != nil {
+		analyticsStore = analytics.NewRedisStore(rdb)
+	}
+	recorder := analytics.NewRecorder(analyticsStore, streams)
+	dispatcher.Subscribe("analytics", recorder.HandleWebhook, webhook.EventParticipantJoined, webhook.EventParticipantLeft, webhook.EventRoomFinished)
+	analyticsHandler := handlers.NewAnalyticsHandler(recorder, streams)
+
+	// 참가자 관리 (음소거, 내보내기, 권한 변경) 및 관리 기록
+	var auditStore audit.Store = audit.NewMemoryStore()
+	if rdb != nil {
+		auditStore = audit.NewRedisStore(rdb)
+	}
+	auditLog := audit.NewLog(auditStore)
+	moderationHandler := handlers.NewModerationHandler(hostURL, keys, streams, auditLog, permissions)
+
+	// 무대 요청 (시청자 손들기, 진행자 승인 시 발언자 권한 부여)
+	var stageStore stage.Store = stage.NewMemoryStore()
+	if rdb != nil {
+		stageStore = stage.NewRedisStore(rdb)
+	}
+	stageHandler := handlers.NewStageHandler(hostURL, keys, policies, streams, stage.NewQueue(stageStore), auditLog)
+	dispatcher.Subscribe("stage", stageHandler.ClearFinished, webhook.EventParticipantLeft, webhook.EventRoomFinished)
+
+	// 채팅 기록 (열린 룸마다 숨김 봇이 접속해 채팅 메시지 저장)
+	var chatStore chat.Store = chat.NewMemoryStore()
+	// 인스턴스가 여러 개면 룸마다 임대를 잡은 인스턴스의 봇만 접속 (같은 identity로 서로 내보내지 않도록)
+	var chatLease chat.Lease = chat.NewMemoryLease()
+	if rdb != nil {
+		chatStore = chat.NewRedisStore(rdb)
+		chatLease = chat.NewRedisLease(rdb)
+	}
+	chatRecorder := chat.NewRecorder(chatStore, chat.NewLiveKitConnector(hostURL, keys, chat.RecorderIdentity, "Chat Recorder"), chatLease)
+	dispatcher.Subscribe("chat", chatRecorder.HandleWebhook, webhook.EventRoomStarted, webhook.EventParticipantJoined, webhook.EventRoomFinished)
+
+	// 채팅 관리 봇 (열린 룸마다 별도 숨김 봇이 접속해 검사: 금지어/정규식/링크, 슬로 모드, 반복 위반 시 일시 음소거)
+	// 슬로 모드/위반 기록과 음소거는 Redis에 TTL로 저장 (음소거 중에는 토큰을 새로 받아도 데이터 전송 불가)
+	var chatmodStore chatmod.Store = chatmod.NewMemoryStore()
+	if rdb != nil {
+		chatmodStore = chatmod.NewRedisStore(rdb)
+	}
+	chatModerator := chatmod.NewModerator(chatRules, hostURL, keys, auditLog, chatmodStore, permissions, chatRecorder,
+		chat.NewLiveKitConnector(hostURL, keys, chatmod.ActorName, "Chat Moderator"), chatLease)
+	dispatcher.Subscribe("chatmod", chatModerator.HandleWebhook, webhook.EventRoomStarted, webhook.EventParticipantJoined, webhook.EventRoomFinished)
+	chatHandler := handlers.NewChatHandler(hostURL, keys, streams, chatRecorder)
 
 	// 라우트 설정
-	routes.SetupRoutes(e, ingressHandler, tokenHandler, streamHandler)
+	routes.SetupRoutes(e, ingressHandler, tokenHandler, streamHandler, accountHandler, oidcHandler, inviteHandler, banHandler, webhookHandler, lobbyHandler, searchHandler, analyticsHandler, moderationHandler, stageHandler, chatHandler, limiter, account.RequireAuth(sessions), account.OptionalAuth(sessions), authz.RequireActor(sessions, adminKeys), account.TicketFromQuery(tickets))
 
 	// 서버 시작
 	log.Println("Server starting on :8080")
 	e.Logger.Fatal(e.Start(":8080"))
 }
+
+// newIPExtractor 신뢰하는 프록시 대역이 없으면 접속 주소만, 있으면 그 대역에서 온 X-Forwarded-For만 사용
+// (기본으로 신뢰하는 루프백/사설 대역도 명시하지 않으면 신뢰하지 않음)
+func newIPExtractor(trusted string) (echo.IPExtractor, error) {
+	if strings.TrimSpace(trusted) == "" {
+		return echo.ExtractIPDirect(), nil
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range strings.Split(trusted, ",") {
+		_, ipRange, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// reloadOnSignal SIGHUP 수신 시 설정 재로드 (재배포 없이 정책 변경, API 키 교체)
+func reloadOnSignal(reloaders ...func() error) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	for range sig {
+		for _, reload := range reloaders {
+			if err := reload(); err != nil {
+				log.Println("config reload failed:", err)
+			}
+		}
+		log.Println("config reloaded")
+	}
+}
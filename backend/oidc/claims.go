@@ -0,0 +1,75 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend/account"
+)
+
+var ErrMissingIdentityClaim = errors.New("id token has no identity claim")
+
+// Principal ID 토큰 클레임을 백엔드 인증 주체로 변환
+func (p *Provider) Principal(claims map[string]interface{}) (*account.Principal, error) {
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, ErrMissingIdentityClaim
+	}
+
+	identity := claimStrings(claims, p.cfg.Claims.Identity)
+	if len(identity) == 0 || identity[0] == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingIdentityClaim, p.cfg.Claims.Identity)
+	}
+
+	name := identity[0]
+	if names := claimStrings(claims, p.cfg.Claims.Name); len(names) > 0 && names[0] != "" {
+		name = names[0]
+	}
+
+	return &account.Principal{
+		UserID:      p.name + ":" + sub,
+		Identity:    *p.cfg.IdentityPrefix + identity[0],
+		DisplayName: name,
+		Role:        p.mapRole(claims),
+		Provider:    "oidc:" + p.name,
+	}, nil
+}
+
+// mapRole 역할 클레임 값 중 role_mapping에 처음 일치하는 역할 선택
+func (p *Provider) mapRole(claims map[string]interface{}) string {
+	if p.cfg.Claims.Roles != "" {
+		for _, v := range claimStrings(claims, p.cfg.Claims.Roles) {
+			if role, ok := p.cfg.RoleMapping[v]; ok {
+				return role
+			}
+		}
+	}
+	return p.cfg.DefaultRole
+}
+
+// claimStrings 점으로 구분된 경로의 클레임 값을 문자열 목록으로 조회
+func claimStrings(claims map[string]interface{}, path string) []string {
+	var cur interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		cur = m[part]
+	}
+
+	switch v := cur.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
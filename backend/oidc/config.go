@@ -0,0 +1,75 @@
+package oidc
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config OIDC 설정 파일 구조체
+type Config struct {
+	// 로그인 완료 후 세션 토큰을 fragment로 붙여 이동할 프론트엔드 주소 (비어있으면 JSON 응답)
+	PostLoginRedirect string                    `yaml:"post_login_redirect"`
+	Providers         map[string]ProviderConfig `yaml:"providers"`
+}
+
+// ProviderConfig IdP별 설정
+type ProviderConfig struct {
+	Issuer       string   `yaml:"issuer"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url"`
+	Scopes       []string `yaml:"scopes"`
+
+	Claims ClaimMapping `yaml:"claims"`
+	// IdP 역할/그룹 값 -> 백엔드 역할 (예: livekit-moderator -> moderator)
+	RoleMapping map[string]string `yaml:"role_mapping"`
+	// 매핑되는 값이 없을 때의 역할 (비어있으면 일반 사용자)
+	DefaultRole string `yaml:"default_role"`
+	// LiveKit identity 접두사, 미설정 시 "<provider>:" (로컬 계정과 identity 충돌 방지)
+	IdentityPrefix *string `yaml:"identity_prefix"`
+}
+
+// ClaimMapping ID 토큰 클레임 경로 (점으로 중첩 경로 지정, 예: realm_access.roles)
+type ClaimMapping struct {
+	Identity string `yaml:"identity"`
+	Name     string `yaml:"name"`
+	Roles    string `yaml:"roles"`
+}
+
+// LoadFile YAML 설정 파일 로드
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read oidc config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse oidc config: %w", err)
+	}
+	for name, p := range cfg.Providers {
+		if p.Issuer == "" || p.ClientID == "" || p.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc provider %s: issuer, client_id and redirect_url are required", name)
+		}
+	}
+	return &cfg, nil
+}
+
+func (p ProviderConfig) withDefaults(name string) ProviderConfig {
+	if len(p.Scopes) == 0 {
+		p.Scopes = []string{"openid", "profile", "email"}
+	}
+	if p.Claims.Identity == "" {
+		p.Claims.Identity = "sub"
+	}
+	if p.Claims.Name == "" {
+		p.Claims.Name = "name"
+	}
+	if p.IdentityPrefix == nil {
+		prefix := name + ":"
+		p.IdentityPrefix = &prefix
+	}
+	return p
+}
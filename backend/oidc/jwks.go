@@ -0,0 +1,92 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+)
+
+const (
+	jwksTTL             = time.Hour
+	jwksMinRefreshDelay = time.Minute // 알 수 없는 kid로 인한 과도한 재조회 방지
+)
+
+// keySet JWKS 캐시 - TTL 만료 또는 처음 보는 kid일 때 재조회
+type keySet struct {
+	client *http.Client
+	uri    string
+
+	mu        sync.Mutex
+	keys      jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+func newKeySet(client *http.Client, uri string) *keySet {
+	return &keySet{client: client, uri: uri}
+}
+
+// key kid에 해당하는 공개키 조회
+func (s *keySet) key(ctx context.Context, kid string) (*jose.JSONWebKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stale := time.Since(s.fetchedAt) > jwksTTL
+	if !stale {
+		if k := s.lookup(kid); k != nil {
+			return k, nil
+		}
+	}
+	if !stale && time.Since(s.fetchedAt) < jwksMinRefreshDelay {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+
+	if err := s.fetch(ctx); err != nil {
+		return nil, err
+	}
+	if k := s.lookup(kid); k != nil {
+		return k, nil
+	}
+	return nil, fmt.Errorf("unknown signing key: %s", kid)
+}
+
+func (s *keySet) lookup(kid string) *jose.JSONWebKey {
+	// kid가 없는 토큰은 키가 하나뿐인 경우에만 허용
+	if kid == "" {
+		if len(s.keys.Keys) == 1 {
+			return &s.keys.Keys[0]
+		}
+		return nil
+	}
+	if keys := s.keys.Key(kid); len(keys) > 0 {
+		return &keys[0]
+	}
+	return nil
+}
+
+func (s *keySet) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.uri, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return fmt.Errorf("decode jwks: %w", err)
+	}
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,45 @@
+package oidc
+
+import (
+	"sort"
+)
+
+// Registry 설정된 IdP 목록
+type Registry struct {
+	providers         map[string]*Provider
+	postLoginRedirect string
+}
+
+// NewRegistry 생성자 - cfg가 nil이면 provider 없이 생성
+func NewRegistry(cfg *Config) *Registry {
+	r := &Registry{providers: make(map[string]*Provider)}
+	if cfg == nil {
+		return r
+	}
+	for name, pc := range cfg.Providers {
+		r.providers[name] = NewProvider(name, pc, nil)
+	}
+	r.postLoginRedirect = cfg.PostLoginRedirect
+	return r
+}
+
+// Provider 이름으로 provider 조회
+func (r *Registry) Provider(name string) (*Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Names 등록된 provider 이름 (정렬)
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PostLoginRedirect 로그인 완료 후 이동할 프론트엔드 주소
+func (r *Registry) PostLoginRedirect() string {
+	return r.postLoginRedirect
+}
@@ -0,0 +1,84 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+const stateTTL = 10 * time.Minute
+
+// LoginState 로그인 시작 시 저장하고 콜백에서 한 번만 꺼내는 값
+type LoginState struct {
+	Provider     string
+	CodeVerifier string
+	Nonce        string
+	ExpiresAt    time.Time
+}
+
+// StateStore 로그인 state 저장소
+type StateStore interface {
+	Put(state string, ls LoginState)
+	// Take state 조회 후 삭제 (재사용 불가)
+	Take(state string) (LoginState, bool)
+}
+
+// MemoryStateStore 인메모리 state 저장소
+type MemoryStateStore struct {
+	mu     sync.Mutex
+	states map[string]LoginState
+}
+
+// NewMemoryStateStore 생성자
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: make(map[string]LoginState)}
+}
+
+func (s *MemoryStateStore) Put(state string, ls LoginState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 만료된 state 정리
+	now := time.Now()
+	for k, v := range s.states {
+		if now.After(v.ExpiresAt) {
+			delete(s.states, k)
+		}
+	}
+	s.states[state] = ls
+}
+
+func (s *MemoryStateStore) Take(state string) (LoginState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ls, ok := s.states[state]
+	if !ok {
+		return LoginState{}, false
+	}
+	delete(s.states, state)
+	if time.Now().After(ls.ExpiresAt) {
+		return LoginState{}, false
+	}
+	return ls, true
+}
+
+// NewLoginState 새 state 키와 PKCE verifier/nonce 생성, code_challenge 함께 반환
+func NewLoginState(provider string) (state string, ls LoginState, codeChallenge string) {
+	ls = LoginState{
+		Provider:     provider,
+		CodeVerifier: randomString(32),
+		Nonce:        randomString(16),
+		ExpiresAt:    time.Now().Add(stateTTL),
+	}
+	sum := sha256.Sum256([]byte(ls.CodeVerifier))
+	return randomString(16), ls, base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,111 @@
+package passcode
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore 인메모리 룸 비밀번호 저장소
+type MemoryStore struct {
+	mu      sync.RWMutex
+	secrets map[string]*Secret
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{secrets: make(map[string]*Secret)}
+}
+
+func (s *MemoryStore) Set(ctx context.Context, roomId string, secret *Secret) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *secret
+	s.secrets[roomId] = &copied
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, roomId string) (*Secret, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	secret, ok := s.secrets[roomId]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *secret
+	return &copied, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, roomId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.secrets, roomId)
+	return nil
+}
+
+type attempts struct {
+	count       int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// MemoryThrottle 인메모리 시도 제한
+type MemoryThrottle struct {
+	cfg ThrottleConfig
+
+	mu      sync.Mutex
+	entries map[string]*attempts
+}
+
+// NewMemoryThrottle 생성자
+func NewMemoryThrottle(cfg ThrottleConfig) *MemoryThrottle {
+	return &MemoryThrottle{
+		cfg:     cfg,
+		entries: make(map[string]*attempts),
+	}
+}
+
+func (t *MemoryThrottle) Locked(ctx context.Context, key string) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	a, ok := t.entries[key]
+	if !ok {
+		return 0, nil
+	}
+	if remaining := time.Until(a.lockedUntil); remaining > 0 {
+		return remaining, nil
+	}
+	return 0, nil
+}
+
+func (t *MemoryThrottle) Fail(ctx context.Context, key string) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	a, ok := t.entries[key]
+	if !ok || now.Sub(a.windowStart) > t.cfg.Window {
+		a = &attempts{windowStart: now}
+		t.entries[key] = a
+	}
+	a.count++
+	if a.count >= t.cfg.MaxAttempts {
+		a.lockedUntil = now.Add(t.cfg.Lockout)
+		a.count = 0
+		a.windowStart = now
+		return t.cfg.Lockout, nil
+	}
+	return 0, nil
+}
+
+func (t *MemoryThrottle) Reset(ctx context.Context, key string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.entries, key)
+	return nil
+}
@@ -0,0 +1,206 @@
+package passcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Kind 룸 보호 방식
+type Kind string
+
+const (
+	KindPassword Kind = "password"
+	KindPIN      Kind = "pin" // 숫자 PIN (다이얼인 입력 가능)
+)
+
+var (
+	ErrNotFound      = errors.New("room has no passcode")
+	ErrInvalidPIN    = errors.New("pin must be 4-8 digits")
+	ErrWeakPassword  = errors.New("password must be 4-128 characters")
+	ErrWrongPasscode = errors.New("incorrect room passcode")
+	ErrRequired      = errors.New("room passcode is required")
+)
+
+var pinPattern = regexp.MustCompile(`^[0-9]{4,8}$`)
+
+// LockedError 시도 횟수 초과로 잠긴 상태
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed attempts, retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+// Secret 해시된 룸 비밀번호/PIN (룸 메타데이터에는 저장하지 않음)
+type Secret struct {
+	Kind      Kind      `json:"kind"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store 룸 비밀번호 저장소
+type Store interface {
+	Set(ctx context.Context, roomId string, secret *Secret) error
+	Get(ctx context.Context, roomId string) (*Secret, error)
+	Delete(ctx context.Context, roomId string) error
+}
+
+// Throttle 실패 횟수 기반 잠금
+type Throttle interface {
+	// Locked 잠겨 있으면 남은 시간 반환
+	Locked(ctx context.Context, key string) (time.Duration, error)
+	// Fail 실패 기록, 한도 초과 시 잠그고 잠금 시간 반환
+	Fail(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// ThrottleConfig 시도 제한 설정
+type ThrottleConfig struct {
+	MaxAttempts int           // window 내 허용 실패 횟수
+	Window      time.Duration // 실패 횟수 집계 구간
+	Lockout     time.Duration // 한도 초과 시 잠금 시간
+}
+
+// DefaultThrottleConfig 15분 내 5회 실패 시 15분 잠금
+func DefaultThrottleConfig() ThrottleConfig {
+	return ThrottleConfig{
+		MaxAttempts: 5,
+		Window:      15 * time.Minute,
+		Lockout:     15 * time.Minute,
+	}
+}
+
+// Service 룸 비밀번호/PIN 설정 및 검증
+type Service struct {
+	store    Store
+	throttle Throttle
+}
+
+// NewService 생성자
+func NewService(store Store, throttle Throttle) *Service {
+	return &Service{
+		store:    store,
+		throttle: throttle,
+	}
+}
+
+// Validate 비밀번호/PIN 형식 검증 (룸 생성 전에 확인)
+func Validate(kind Kind, value string) error {
+	switch kind {
+	case KindPIN:
+		if !pinPattern.MatchString(value) {
+			return ErrInvalidPIN
+		}
+	case KindPassword:
+		if len(value) < 4 || len(value) > 128 {
+			return ErrWeakPassword
+		}
+	default:
+		return fmt.Errorf("unknown passcode kind: %s", kind)
+	}
+	return nil
+}
+
+// Set 룸 비밀번호/PIN 설정 (bcrypt 해시로 저장)
+func (s *Service) Set(ctx context.Context, roomId string, kind Kind, value string) error {
+	if err := Validate(kind, value); err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(value), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(ctx, roomId, &Secret{
+		Kind:      kind,
+		Hash:      string(hash),
+		CreatedAt: time.Now(),
+	})
+}
+
+// Protection 룸 보호 방식 조회 (보호되지 않은 룸이면 false)
+func (s *Service) Protection(ctx context.Context, roomId string) (Kind, bool, error) {
+	secret, err := s.store.Get(ctx, roomId)
+	if errors.Is(err, ErrNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return secret.Kind, true, nil
+}
+
+// Verify 비밀번호/PIN 확인 (보호되지 않은 룸이면 nil)
+// attemptKeys(예: identity, IP)별로 실패 횟수를 집계하며, 하나라도 잠겨 있으면 LockedError 반환
+func (s *Service) Verify(ctx context.Context, roomId, value string, attemptKeys ...string) error {
+	secret, err := s.store.Get(ctx, roomId)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// 입력하지 않은 경우는 실패 횟수에 포함하지 않음
+	if value == "" {
+		return ErrRequired
+	}
+
+	keys := make([]string, len(attemptKeys))
+	for i, k := range attemptKeys {
+		keys[i] = roomId + ":" + k
+	}
+
+	for _, key := range keys {
+		retryAfter, err := s.throttle.Locked(ctx, key)
+		if err != nil {
+			return err
+		}
+		if retryAfter > 0 {
+			return &LockedError{RetryAfter: retryAfter}
+		}
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(secret.Hash), []byte(value)) != nil {
+		var lockout time.Duration
+		for _, key := range keys {
+			d, err := s.throttle.Fail(ctx, key)
+			if err != nil {
+				return err
+			}
+			if d > lockout {
+				lockout = d
+			}
+		}
+		if lockout > 0 {
+			return &LockedError{RetryAfter: lockout}
+		}
+		return ErrWrongPasscode
+	}
+
+	for _, key := range keys {
+		s.throttle.Reset(ctx, key)
+	}
+	return nil
+}
+
+// VerifyPIN 다이얼인 등 숫자 입력 경로용 PIN 확인 (PIN으로 보호된 룸만 허용)
+func (s *Service) VerifyPIN(ctx context.Context, roomId, pin, callerKey string) error {
+	kind, ok, err := s.Protection(ctx, roomId)
+	if err != nil {
+		return err
+	}
+	if !ok || kind != KindPIN {
+		return ErrNotFound
+	}
+	return s.Verify(ctx, roomId, pin, callerKey)
+}
+
+// Clear 룸 삭제 시 비밀번호 제거
+func (s *Service) Clear(ctx context.Context, roomId string) error {
+	return s.store.Delete(ctx, roomId)
+}
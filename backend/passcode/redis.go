@@ -0,0 +1,116 @@
+package passcode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 룸이 사라진 뒤 남는 비밀번호는 일정 기간 후 자동 정리 (최대 30일 뒤 시작하는 예약 스트림 포함)
+const secretRetention = 60 * 24 * time.Hour
+
+// RedisStore Redis 기반 룸 비밀번호 저장소 (passcode:<room>)
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func secretKey(roomId string) string {
+	return "passcode:" + roomId
+}
+
+func (s *RedisStore) Set(ctx context.Context, roomId string, secret *Secret) error {
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, secretKey(roomId), data, secretRetention).Err()
+}
+
+func (s *RedisStore) Get(ctx context.Context, roomId string) (*Secret, error) {
+	data, err := s.rdb.Get(ctx, secretKey(roomId)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var secret Secret
+	if err := json.Unmarshal(data, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, roomId string) error {
+	return s.rdb.Del(ctx, secretKey(roomId)).Err()
+}
+
+// failScript 실패 횟수 증가와 기간 설정을 한 번에 (TTL이 없는 카운터가 남아 영구 잠금되지 않도록)
+// 최대 횟수에 도달하면 잠금을 걸고 카운터 삭제
+//
+//	KEYS[1]  passcode:fail:<key>
+//	KEYS[2]  passcode:lock:<key>
+//	ARGV     window(ms), max attempts, lockout(ms)
+//	반환     잠금을 걸었으면 1
+var failScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if redis.call('PTTL', KEYS[1]) < 0 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+if count < tonumber(ARGV[2]) then
+  return 0
+end
+redis.call('SET', KEYS[2], 1, 'PX', ARGV[3])
+redis.call('DEL', KEYS[1])
+return 1
+`)
+
+// RedisThrottle Redis 기반 시도 제한
+//
+//	passcode:fail:<key>   window 동안의 실패 횟수
+//	passcode:lock:<key>   잠금 (TTL = 남은 잠금 시간)
+type RedisThrottle struct {
+	rdb *redis.Client
+	cfg ThrottleConfig
+}
+
+// NewRedisThrottle 생성자
+func NewRedisThrottle(rdb *redis.Client, cfg ThrottleConfig) *RedisThrottle {
+	return &RedisThrottle{rdb: rdb, cfg: cfg}
+}
+
+func (t *RedisThrottle) Locked(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := t.rdb.PTTL(ctx, "passcode:lock:"+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (t *RedisThrottle) Fail(ctx context.Context, key string) (time.Duration, error) {
+	keys := []string{"passcode:fail:" + key, "passcode:lock:" + key}
+	locked, err := failScript.Run(ctx, t.rdb, keys, t.cfg.Window.Milliseconds(), t.cfg.MaxAttempts, t.cfg.Lockout.Milliseconds()).Int()
+	if err != nil {
+		return 0, err
+	}
+	if locked == 0 {
+		return 0, nil
+	}
+	return t.cfg.Lockout, nil
+}
+
+func (t *RedisThrottle) Reset(ctx context.Context, key string) error {
+	return t.rdb.Del(ctx, "passcode:fail:"+key).Err()
+}
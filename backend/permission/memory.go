@@ -0,0 +1,101 @@
+package permission
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore 인메모리 권한 변경 저장소 (단일 인스턴스/테스트용)
+type MemoryStore struct {
+	mu        sync.Mutex
+	overrides map[string]map[string]Override // room -> identity -> 변경 내용
+	mutes     map[string]map[string]memoryMute
+}
+
+type memoryMute struct {
+	mute    Mute
+	expires time.Time
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{overrides: map[string]map[string]Override{}, mutes: map[string]map[string]memoryMute{}}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, roomId, identity string) (*Override, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.overrides[roomId][identity]
+	if !ok {
+		return nil, nil
+	}
+	return &o, nil
+}
+
+func (s *MemoryStore) Save(ctx context.Context, roomId, identity string, o *Override) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.overrides[roomId]
+	if !ok {
+		room = map[string]Override{}
+		s.overrides[roomId] = room
+	}
+	room[identity] = *o
+	return nil
+}
+
+func (s *MemoryStore) Clear(ctx context.Context, roomId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.overrides, roomId)
+	delete(s.mutes, roomId)
+	return nil
+}
+
+func (s *MemoryStore) SetMute(ctx context.Context, roomId, identity string, m *Mute, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.mutes[roomId]
+	if !ok {
+		room = map[string]memoryMute{}
+		s.mutes[roomId] = room
+	}
+	room[identity] = memoryMute{mute: *m, expires: time.Now().Add(ttl)}
+	return nil
+}
+
+func (s *MemoryStore) GetMute(ctx context.Context, roomId, identity string) (*Mute, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mute(roomId, identity, false), nil
+}
+
+func (s *MemoryStore) TakeMute(ctx context.Context, roomId, identity string) (*Mute, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mute(roomId, identity, true), nil
+}
+
+// mute 만료되지 않은 음소거 조회 (만료되었거나 take이면 삭제)
+func (s *MemoryStore) mute(roomId, identity string, take bool) *Mute {
+	room := s.mutes[roomId]
+	entry, ok := room[identity]
+	if !ok {
+		return nil
+	}
+	expired := !time.Now().Before(entry.expires)
+	if expired || take {
+		delete(room, identity)
+		if len(room) == 0 {
+			delete(s.mutes, roomId)
+		}
+	}
+	if expired {
+		return nil
+	}
+	return &entry.mute
+}
@@ -0,0 +1,158 @@
+package permission
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+)
+
+// Override 진행자가 참가자별로 바꾼 권한 (nil 항목은 역할 정책을 따름)
+// 다시 참여하거나 토큰을 갱신해도 룸이 끝날 때까지 유지된다.
+type Override struct {
+	CanSubscribe      *bool     `json:"can_subscribe,omitempty"`
+	CanPublish        *bool     `json:"can_publish,omitempty"`
+	CanPublishData    *bool     `json:"can_publish_data,omitempty"`
+	CanPublishSources *[]string `json:"can_publish_sources,omitempty"` // 빈 배열이면 모든 소스 허용
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// Mute 채팅 관리 봇의 일시 음소거 (Until이 지나면 저장소에서도 만료)
+type Mute struct {
+	Until   time.Time `json:"until"`
+	Revoked bool      `json:"revoked"` // 음소거하면서 데이터 전송 권한을 회수했는지 (해제 시 이것만 복구)
+}
+
+// muteGrace 음소거 기록을 해제 시각보다 조금 더 보관 (해제 예약이 늦게 실행되어도 복구할 수 있도록)
+const muteGrace = time.Minute
+
+// Store 룸별 참가자 권한 변경 저장소
+type Store interface {
+	// Get 참가자의 변경 내용 (없으면 nil)
+	Get(ctx context.Context, roomId, identity string) (*Override, error)
+	Save(ctx context.Context, roomId, identity string, o *Override) error
+	Clear(ctx context.Context, roomId string) error
+	// SetMute 음소거 저장 (ttl이 지나면 삭제)
+	SetMute(ctx context.Context, roomId, identity string, m *Mute, ttl time.Duration) error
+	// GetMute 음소거 조회 (없으면 nil)
+	GetMute(ctx context.Context, roomId, identity string) (*Mute, error)
+	// TakeMute 음소거를 조회하고 삭제 (없으면 nil)
+	TakeMute(ctx context.Context, roomId, identity string) (*Mute, error)
+}
+
+// Service 참가자 권한 변경 기록과 토큰 발급 시 적용
+type Service struct {
+	store Store
+}
+
+// NewService 생성자
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Get 참가자의 변경 내용 (없으면 nil)
+func (s *Service) Get(ctx context.Context, roomId, identity string) (*Override, error) {
+	return s.store.Get(ctx, roomId, identity)
+}
+
+// Update 지정한 항목만 기존 변경 내용에 덮어써 저장
+func (s *Service) Update(ctx context.Context, roomId, identity string, change Override) (*Override, error) {
+	o, err := s.store.Get(ctx, roomId, identity)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		o = &Override{}
+	}
+	if change.CanSubscribe != nil {
+		o.CanSubscribe = change.CanSubscribe
+	}
+	if change.CanPublish != nil {
+		o.CanPublish = change.CanPublish
+	}
+	if change.CanPublishData != nil {
+		o.CanPublishData = change.CanPublishData
+	}
+	if change.CanPublishSources != nil {
+		o.CanPublishSources = change.CanPublishSources
+	}
+	o.UpdatedAt = time.Now()
+	if err := s.store.Save(ctx, roomId, identity, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Mute 채팅 일시 음소거 기록 (until까지 발급하는 토큰에는 데이터 전송 권한 없음)
+func (s *Service) Mute(ctx context.Context, roomId, identity string, until time.Time, revoked bool) error {
+	return s.store.SetMute(ctx, roomId, identity, &Mute{Until: until, Revoked: revoked}, time.Until(until)+muteGrace)
+}
+
+// Muted 음소거 중이면 기록 반환 (없거나 해제 시각이 지났으면 nil)
+func (s *Service) Muted(ctx context.Context, roomId, identity string) (*Mute, error) {
+	m, err := s.store.GetMute(ctx, roomId, identity)
+	if err != nil || m == nil || !time.Now().Before(m.Until) {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Unmute 음소거 기록 삭제 후 반환 (없으면 nil, 여러 인스턴스 중 하나만 받음)
+func (s *Service) Unmute(ctx context.Context, roomId, identity string) (*Mute, error) {
+	return s.store.TakeMute(ctx, roomId, identity)
+}
+
+// Apply 역할 정책으로 만든 토큰에 참가자의 변경 내용과 음소거 적용
+func (s *Service) Apply(ctx context.Context, at *auth.AccessToken, roomId, identity string) error {
+	o, err := s.store.Get(ctx, roomId, identity)
+	if err != nil {
+		return err
+	}
+	if o != nil {
+		o.Apply(at.GetGrants().Video)
+	}
+	muted, err := s.Muted(ctx, roomId, identity)
+	if err != nil {
+		return err
+	}
+	if muted != nil && at.GetGrants().Video != nil {
+		at.GetGrants().Video.SetCanPublishData(false)
+	}
+	return nil
+}
+
+// HandleWebhook 종료된 룸의 변경 내용 삭제
+func (s *Service) HandleWebhook(ctx context.Context, event *livekit.WebhookEvent) error {
+	if event.Event != webhook.EventRoomFinished || event.GetRoom().GetName() == "" {
+		return nil
+	}
+	return s.store.Clear(ctx, event.GetRoom().GetName())
+}
+
+// Apply VideoGrant에 변경 내용 적용 (지정하지 않은 항목은 그대로)
+func (o *Override) Apply(grant *auth.VideoGrant) {
+	if grant == nil {
+		return
+	}
+	if o.CanSubscribe != nil {
+		grant.SetCanSubscribe(*o.CanSubscribe)
+	}
+	if o.CanPublish != nil {
+		grant.SetCanPublish(*o.CanPublish)
+	}
+	if o.CanPublishData != nil {
+		grant.SetCanPublishData(*o.CanPublishData)
+	}
+	if o.CanPublishSources != nil {
+		sources := make([]livekit.TrackSource, 0, len(*o.CanPublishSources))
+		for _, name := range *o.CanPublishSources {
+			if value, ok := livekit.TrackSource_value[strings.ToUpper(name)]; ok {
+				sources = append(sources, livekit.TrackSource(value))
+			}
+		}
+		grant.SetCanPublishSources(sources)
+	}
+}
@@ -0,0 +1,94 @@
+package permission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 마지막 변경 후 보관 기간 (종료 이벤트를 놓친 룸 정리)
+const overrideRetention = 24 * time.Hour
+
+// RedisStore Redis 기반 권한 변경 저장소
+// (permission:<room>:overrides hash, identity -> 변경 내용 / permission:<room>:mute:<identity>, 해제 시각에 맞춘 TTL)
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func overridesKey(roomId string) string {
+	return "permission:" + roomId + ":overrides"
+}
+
+func muteKey(roomId, identity string) string {
+	return "permission:" + roomId + ":mute:" + identity
+}
+
+func (s *RedisStore) Get(ctx context.Context, roomId, identity string) (*Override, error) {
+	data, err := s.rdb.HGet(ctx, overridesKey(roomId), identity).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var o Override
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (s *RedisStore) Save(ctx context.Context, roomId, identity string, o *Override) error {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, overridesKey(roomId), identity, data)
+		pipe.Expire(ctx, overridesKey(roomId), overrideRetention)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) Clear(ctx context.Context, roomId string) error {
+	return s.rdb.Del(ctx, overridesKey(roomId)).Err()
+}
+
+func (s *RedisStore) SetMute(ctx context.Context, roomId, identity string, m *Mute, ttl time.Duration) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, muteKey(roomId, identity), data, ttl).Err()
+}
+
+func (s *RedisStore) GetMute(ctx context.Context, roomId, identity string) (*Mute, error) {
+	return decodeMute(s.rdb.Get(ctx, muteKey(roomId, identity)).Bytes())
+}
+
+func (s *RedisStore) TakeMute(ctx context.Context, roomId, identity string) (*Mute, error) {
+	return decodeMute(s.rdb.GetDel(ctx, muteKey(roomId, identity)).Bytes())
+}
+
+func decodeMute(data []byte, err error) (*Mute, error) {
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Mute
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,259 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"gopkg.in/yaml.v3"
+)
+
+// Role 참가자 역할
+type Role string
+
+const (
+	RoleHost      Role = "host"      // 방송 생성자
+	RoleCoHost    Role = "co-host"   // 공동 진행자
+	RoleSpeaker   Role = "speaker"   // 무대에 오른 발언자
+	RoleViewer    Role = "viewer"    // 일반 시청자
+	RoleModerator Role = "moderator" // 운영자
+	RoleBot       Role = "bot"       // 에이전트, 인그레스 등 봇 참가자
+)
+
+// AttributeRole 토큰 참가자 속성에 기록되는 역할 키
+const AttributeRole = "role"
+
+var ErrUnknownRole = errors.New("unknown role")
+
+// Policy 역할별 권한 정책
+type Policy struct {
+	CanPublish           bool              `yaml:"can_publish"`
+	CanSubscribe         bool              `yaml:"can_subscribe"`
+	CanPublishData       bool              `yaml:"can_publish_data"`
+	CanUpdateOwnMetadata bool              `yaml:"can_update_own_metadata"`
+	CanPublishSources    []string          `yaml:"can_publish_sources"` // camera, microphone, screen_share, screen_share_audio
+	RoomAdmin            bool              `yaml:"room_admin"`
+	Hidden               bool              `yaml:"hidden"`
+	TTL                  time.Duration     `yaml:"ttl"`
+	Attributes           map[string]string `yaml:"attributes"`
+}
+
+// Config 정책 설정 파일 구조체
+type Config struct {
+	Roles map[Role]Policy `yaml:"roles"`
+}
+
+// DefaultPolicies 설정 파일이 없을 때 사용하는 기본 정책
+func DefaultPolicies() map[Role]Policy {
+	return map[Role]Policy{
+		RoleHost: {
+			CanPublish:           true,
+			CanSubscribe:         true,
+			CanPublishData:       true,
+			CanUpdateOwnMetadata: true,
+			TTL:                  time.Hour,
+		},
+		RoleCoHost: {
+			CanPublish:     true,
+			CanSubscribe:   true,
+			CanPublishData: true,
+			TTL:            time.Hour,
+		},
+		RoleSpeaker: {
+			CanPublish:        true,
+			CanSubscribe:      true,
+			CanPublishData:    true,
+			CanPublishSources: []string{"camera", "microphone"},
+			TTL:               time.Hour,
+		},
+		RoleViewer: {
+			CanSubscribe:   true,
+			CanPublishData: true, // 채팅/반응 가능
+			TTL:            time.Hour,
+		},
+		RoleModerator: {
+			CanSubscribe:   true,
+			CanPublishData: true,
+			RoomAdmin:      true,
+			TTL:            time.Hour,
+		},
+		RoleBot: {
+			CanPublish:     true,
+			CanSubscribe:   true,
+			CanPublishData: true,
+			Hidden:         true,
+			TTL:            24 * time.Hour,
+		},
+	}
+}
+
+// Engine 역할 정책 조회 및 토큰 발급
+type Engine struct {
+	mu       sync.RWMutex
+	path     string
+	policies map[Role]Policy
+}
+
+// NewEngine 생성자 - path가 비어있으면 기본 정책만 사용
+func NewEngine(path string) (*Engine, error) {
+	e := &Engine{path: path}
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload 설정 파일을 다시 읽어 정책 교체 (파일에 없는 역할은 기본값 유지)
+func (e *Engine) Reload() error {
+	policies := DefaultPolicies()
+	if e.path != "" {
+		loaded, err := LoadFile(e.path)
+		if err != nil {
+			return err
+		}
+		for role, p := range loaded {
+			policies[role] = p
+		}
+	}
+
+	e.mu.Lock()
+	e.policies = policies
+	e.mu.Unlock()
+	return nil
+}
+
+// LoadFile YAML(JSON 포함) 정책 파일 로드
+func LoadFile(path string) (map[Role]Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read policy file: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse policy file: %w", err)
+	}
+
+	for role, p := range cfg.Roles {
+		if _, err := parseSources(p.CanPublishSources); err != nil {
+			return nil, fmt.Errorf("role %s: %w", role, err)
+		}
+		if p.TTL < 0 {
+			return nil, fmt.Errorf("role %s: ttl must not be negative", role)
+		}
+	}
+	return cfg.Roles, nil
+}
+
+// Lookup 역할에 해당하는 정책 조회
+func (e *Engine) Lookup(role Role) (Policy, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	p, ok := e.policies[role]
+	if !ok {
+		return Policy{}, fmt.Errorf("%w: %s", ErrUnknownRole, role)
+	}
+	return p, nil
+}
+
+// Grant 역할 정책을 특정 룸에 대한 VideoGrant로 변환
+func (e *Engine) Grant(role Role, room string) (*auth.VideoGrant, error) {
+	p, err := e.Lookup(role)
+	if err != nil {
+		return nil, err
+	}
+	return p.grant(room)
+}
+
+// NewAccessToken 역할 정책이 적용된 LiveKit 토큰 생성
+func (e *Engine) NewAccessToken(apiKey, apiSecret string, role Role, room, identity string) (*auth.AccessToken, error) {
+	p, err := e.Lookup(role)
+	if err != nil {
+		return nil, err
+	}
+	grant, err := p.grant(room)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]string, len(p.Attributes)+1)
+	for k, v := range p.Attributes {
+		attributes[k] = v
+	}
+	attributes[AttributeRole] = string(role)
+
+	at := auth.NewAccessToken(apiKey, apiSecret)
+	at.SetIdentity(identity).
+		SetVideoGrant(grant).
+		SetAttributes(attributes).
+		SetValidFor(p.ValidFor())
+	return at, nil
+}
+
+// ValidFor 토큰 유효 기간 (TTL 미설정 시 1시간)
+func (p Policy) ValidFor() time.Duration {
+	if p.TTL == 0 {
+		return time.Hour
+	}
+	return p.TTL
+}
+
+func (p Policy) grant(room string) (*auth.VideoGrant, error) {
+	grant := &auth.VideoGrant{
+		Room:      room,
+		RoomJoin:  true,
+		RoomAdmin: p.RoomAdmin,
+		Hidden:    p.Hidden,
+	}
+	grant.SetCanPublish(p.CanPublish)
+	grant.SetCanSubscribe(p.CanSubscribe)
+	grant.SetCanPublishData(p.CanPublishData)
+	grant.SetCanUpdateOwnMetadata(p.CanUpdateOwnMetadata)
+
+	if p.CanPublish && len(p.CanPublishSources) > 0 {
+		sources, err := parseSources(p.CanPublishSources)
+		if err != nil {
+			return nil, err
+		}
+		grant.SetCanPublishSources(sources)
+	}
+	return grant, nil
+}
+
+// Permission 이미 접속한 참가자에게 적용할 권한 (UpdateParticipant용, 역할 변경 시 사용)
+func (p Policy) Permission() (*livekit.ParticipantPermission, error) {
+	permission := &livekit.ParticipantPermission{
+		CanSubscribe:      p.CanSubscribe,
+		CanPublish:        p.CanPublish,
+		CanPublishData:    p.CanPublishData,
+		CanUpdateMetadata: p.CanUpdateOwnMetadata,
+		Hidden:            p.Hidden,
+	}
+	if p.CanPublish && len(p.CanPublishSources) > 0 {
+		sources, err := parseSources(p.CanPublishSources)
+		if err != nil {
+			return nil, err
+		}
+		permission.CanPublishSources = sources
+	}
+	return permission, nil
+}
+
+// parseSources "camera" 같은 소스 이름을 livekit.TrackSource로 변환
+func parseSources(names []string) ([]livekit.TrackSource, error) {
+	sources := make([]livekit.TrackSource, 0, len(names))
+	for _, name := range names {
+		v, ok := livekit.TrackSource_value[strings.ToUpper(name)]
+		if !ok || livekit.TrackSource(v) == livekit.TrackSource_UNKNOWN {
+			return nil, fmt.Errorf("invalid publish source: %s", name)
+		}
+		sources = append(sources, livekit.TrackSource(v))
+	}
+	return sources, nil
+}
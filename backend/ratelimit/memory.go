@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 버킷 수가 이 값을 넘으면 가득 찬(유휴) 버킷 정리
+const sweepThreshold = 10000
+
+type bucket struct {
+	tokens  float64
+	last    time.Time
+	expires time.Time
+}
+
+// MemoryLimiter 인메모리 토큰 버킷 (단일 인스턴스용)
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewMemoryLimiter 생성자
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*bucket)}
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if len(l.buckets) > sweepThreshold {
+		for k, b := range l.buckets {
+			if now.After(b.expires) {
+				delete(l.buckets, k)
+			}
+		}
+	}
+
+	b, ok := l.buckets[key]
+	if !ok || now.After(b.expires) {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	tokens, allowed, wait := take(b.tokens, b.last, now, limit)
+	b.tokens = tokens
+	b.last = now
+	b.expires = now.Add(idleTTL(limit))
+	return allowed, wait, nil
+}
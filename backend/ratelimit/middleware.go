@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/account"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Policy 엔드포인트별 제한 (0인 Limit은 적용하지 않음)
+type Policy struct {
+	Name    string // 키 접두사
+	PerIP   Limit
+	PerUser Limit
+	PerRoom Limit
+	// Room 요청에서 룸 이름 추출 (없으면 룸 단위 제한 생략)
+	Room func(c echo.Context) string
+}
+
+// Middleware IP, 로그인 사용자, 룸 단위 토큰 버킷 검사 (초과 시 429 + Retry-After)
+// 사용자 키를 쓰려면 인증 미들웨어 뒤에 둬야 함
+func Middleware(limiter Limiter, p Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			type check struct {
+				key   string
+				limit Limit
+			}
+			checks := []check{{p.Name + ":ip:" + c.RealIP(), p.PerIP}}
+			if principal := account.PrincipalFrom(c); principal != nil {
+				checks = append(checks, check{p.Name + ":user:" + principal.UserID, p.PerUser})
+			}
+			if p.Room != nil {
+				if room := p.Room(c); room != "" {
+					checks = append(checks, check{p.Name + ":room:" + room, p.PerRoom})
+				}
+			}
+
+			for _, ch := range checks {
+				if !ch.limit.Enabled() {
+					continue
+				}
+				allowed, wait, err := limiter.Allow(c.Request().Context(), ch.key, ch.limit)
+				if err != nil {
+					// 저장소 장애 시에는 요청을 막지 않음
+					log.Printf("rate limit check failed for %s: %v", ch.key, err)
+					continue
+				}
+				if !allowed {
+					c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfterSeconds(wait)))
+					return echo.NewHTTPError(http.StatusTooManyRequests, "Too many requests")
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+func retryAfterSeconds(wait time.Duration) int {
+	return int(math.Max(1, math.Ceil(wait.Seconds())))
+}
+
+// RoomFromQuery 쿼리 파라미터의 룸 이름
+func RoomFromQuery(name string) func(c echo.Context) string {
+	return func(c echo.Context) string {
+		return c.QueryParam(name)
+	}
+}
+
+// RoomFromJSON JSON 본문 필드의 룸 이름 (핸들러가 다시 읽을 수 있도록 본문 복원)
+func RoomFromJSON(field string) func(c echo.Context) string {
+	return func(c echo.Context) string {
+		req := c.Request()
+		if req.Body == nil {
+			return ""
+		}
+		body, err := io.ReadAll(io.LimitReader(req.Body, 1<<20))
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return ""
+		}
+
+		var fields map[string]interface{}
+		if json.Unmarshal(body, &fields) != nil {
+			return ""
+		}
+		room, _ := fields[field].(string)
+		return room
+	}
+}
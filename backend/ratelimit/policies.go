@@ -0,0 +1,38 @@
+package ratelimit
+
+// 엔드포인트별 기본 제한
+var (
+	// GetTokenPolicy /getToken - 회의 토큰 발급
+	GetTokenPolicy = Policy{
+		Name:    "get_token",
+		PerIP:   PerMinute(60, 20),
+		PerUser: PerMinute(30, 10),
+		PerRoom: PerMinute(300, 100),
+		Room:    RoomFromQuery("room"),
+	}
+
+	// JoinStreamPolicy /api/join_stream - 시청자 토큰 발급
+	JoinStreamPolicy = Policy{
+		Name:    "join_stream",
+		PerIP:   PerMinute(60, 20),
+		PerUser: PerMinute(30, 10),
+		PerRoom: PerMinute(600, 200),
+		Room:    RoomFromJSON("room_id"),
+	}
+
+	// CreateStreamPolicy /api/create_stream - 룸 생성
+	CreateStreamPolicy = Policy{
+		Name:    "create_stream",
+		PerIP:   PerHour(30, 5),
+		PerUser: PerHour(20, 3),
+	}
+
+	// CreateIngressPolicy /api/create_ingress - 인그레스 생성
+	CreateIngressPolicy = Policy{
+		Name:    "create_ingress",
+		PerIP:   PerHour(30, 5),
+		PerUser: PerHour(20, 3),
+		PerRoom: PerHour(10, 2),
+		Room:    RoomFromJSON("room_name"),
+	}
+)
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit 토큰 버킷 설정 (Rate: 초당 충전 토큰 수, Burst: 버킷 크기)
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute 분당 n회, 순간 최대 burst회
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// PerHour 시간당 n회, 순간 최대 burst회
+func PerHour(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 3600, Burst: burst}
+}
+
+// Enabled 0이면 제한하지 않음
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Limiter 키별 토큰 버킷
+type Limiter interface {
+	// Allow 토큰 1개 소비, 부족하면 false와 다음 토큰까지 대기 시간 반환
+	Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+// take 버킷 상태 갱신 (메모리/Redis 구현 공통 계산)
+func take(tokens float64, last, now time.Time, limit Limit) (float64, bool, time.Duration) {
+	elapsed := now.Sub(last).Seconds()
+	if elapsed > 0 {
+		tokens = math.Min(float64(limit.Burst), tokens+elapsed*limit.Rate)
+	}
+	if tokens >= 1 {
+		return tokens - 1, true, 0
+	}
+	wait := time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+	return tokens, false, wait
+}
+
+// idleTTL 버킷이 가득 찰 때까지 걸리는 시간 (이후에는 상태를 버려도 동일)
+func idleTTL(limit Limit) time.Duration {
+	return time.Duration(float64(limit.Burst)/limit.Rate*float64(time.Second)) + time.Second
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// bucketScript 토큰 버킷 갱신을 원자적으로 수행 (여러 백엔드 인스턴스가 같은 버킷 공유)
+//
+//	KEYS[1]  ratelimit:<key> hash (tokens, ts)
+//	ARGV     rate, burst, now(ms), ttl(ms)
+//	반환     {허용 여부(1/0), 대기 시간(ms)}
+var bucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil then
+  tokens = burst
+  ts = now
+end
+
+local elapsed = math.max(0, now - ts) / 1000
+tokens = math.min(burst, tokens + elapsed * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], ttl)
+return {allowed, wait}
+`)
+
+// RedisLimiter Redis 기반 토큰 버킷
+type RedisLimiter struct {
+	rdb *redis.Client
+}
+
+// NewRedisLimiter 생성자
+func NewRedisLimiter(rdb *redis.Client) *RedisLimiter {
+	return &RedisLimiter{rdb: rdb}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	res, err := bucketScript.Run(ctx, l.rdb, []string{"ratelimit:" + key},
+		limit.Rate,
+		limit.Burst,
+		time.Now().UnixMilli(),
+		idleTTL(limit).Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,159 @@
+package roster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+)
+
+// Fetcher 룸 참가자 조회 (LiveKit ListParticipants)
+type Fetcher func(ctx context.Context, roomId string) ([]*livekit.ParticipantInfo, error)
+
+// Config 참가자 조회 설정
+type Config struct {
+	TTL         time.Duration // 스냅샷 유지 시간
+	Concurrency int           // 동시에 조회하는 룸 수
+	Timeout     time.Duration // 요청 하나의 전체 조회 제한 시간
+}
+
+// DefaultConfig 5초 캐시, 동시 8개, 2초 제한
+func DefaultConfig() Config {
+	return Config{
+		TTL:         5 * time.Second,
+		Concurrency: 8,
+		Timeout:     2 * time.Second,
+	}
+}
+
+// 스냅샷 수가 이 값을 넘으면 만료된 항목 정리
+const sweepThreshold = 1000
+
+type snapshot struct {
+	participants  []*livekit.ParticipantInfo
+	valid         bool
+	expires       time.Time
+	invalidatedAt time.Time // 이 시각 이전에 시작한 조회 결과는 저장하지 않음
+}
+
+// Cache 룸별 참가자 스냅샷 (인스턴스별, 웹훅 이벤트로 무효화)
+// 웹훅을 받지 않은 다른 인스턴스의 스냅샷은 TTL이 지나야 갱신된다.
+type Cache struct {
+	cfg Config
+
+	mu        sync.Mutex
+	snapshots map[string]snapshot
+}
+
+// NewCache 생성자
+func NewCache(cfg Config) *Cache {
+	return &Cache{
+		cfg:       cfg,
+		snapshots: make(map[string]snapshot),
+	}
+}
+
+// Collect 여러 룸의 참가자를 동시에 조회 (캐시에 있으면 재사용)
+// 제한 시간 안에 조회하지 못했거나 실패한 룸은 failed로 반환한다.
+func (c *Cache) Collect(ctx context.Context, roomIds []string, fetch Fetcher) (map[string][]*livekit.ParticipantInfo, []string) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	result := make(map[string][]*livekit.ParticipantInfo, len(roomIds))
+	var failed []string
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(c.cfg.Concurrency, 1))
+
+	now := time.Now()
+	for _, roomId := range roomIds {
+		if participants, ok := c.get(roomId, now); ok {
+			mu.Lock()
+			result[roomId] = participants
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(roomId string) {
+			defer wg.Done()
+
+			var participants []*livekit.ParticipantInfo
+			var err error
+			startedAt := time.Now()
+			select {
+			case sem <- struct{}{}:
+				participants, err = fetch(ctx, roomId)
+				<-sem
+			case <-ctx.Done():
+				// 차례를 기다리다 제한 시간이 지난 룸은 실패 (빈 룸으로 캐시하지 않음)
+				err = ctx.Err()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed = append(failed, roomId)
+				return
+			}
+			result[roomId] = participants
+			c.put(roomId, participants, startedAt)
+		}(roomId)
+	}
+	wg.Wait()
+	return result, failed
+}
+
+func (c *Cache) get(roomId string, now time.Time) ([]*livekit.ParticipantInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.snapshots[roomId]
+	if !ok || !s.valid || now.After(s.expires) {
+		return nil, false
+	}
+	return s.participants, true
+}
+
+func (c *Cache) put(roomId string, participants []*livekit.ParticipantInfo, startedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	// 사라진 룸의 스냅샷이 쌓이지 않도록 만료된 항목 정리
+	if len(c.snapshots) > sweepThreshold {
+		for id, s := range c.snapshots {
+			if now.After(s.expires) {
+				delete(c.snapshots, id)
+			}
+		}
+	}
+	// 조회 중에 무효화되었으면 이전 상태일 수 있으므로 저장하지 않음
+	if s, ok := c.snapshots[roomId]; ok && !s.invalidatedAt.Before(startedAt) {
+		return
+	}
+	c.snapshots[roomId] = snapshot{participants: participants, valid: true, expires: now.Add(c.cfg.TTL)}
+}
+
+// Invalidate 룸 스냅샷 무효화
+func (c *Cache) Invalidate(roomId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.snapshots[roomId] = snapshot{expires: now.Add(c.cfg.TTL), invalidatedAt: now}
+}
+
+// HandleWebhook 웹훅 구독자 - 참가자/트랙 변경이나 룸 종료 시 스냅샷 무효화
+func (c *Cache) HandleWebhook(ctx context.Context, event *livekit.WebhookEvent) error {
+	switch event.Event {
+	case webhook.EventParticipantJoined, webhook.EventParticipantLeft,
+		webhook.EventTrackPublished, webhook.EventTrackUnpublished, webhook.EventRoomFinished:
+		if event.Room != nil {
+			c.Invalidate(event.Room.Name)
+		}
+	}
+	return nil
+}
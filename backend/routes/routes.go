@@ -2,28 +2,93 @@ package routes
 
 import (
 	"backend/handlers"
+	"backend/ratelimit"
 
 	"github.com/labstack/echo/v4"
 )
 
 // SetupRoutes 라우터 설정
-func SetupRoutes(e *echo.Echo, ingressHandler *handlers.IngressHandler, tokenHandler *handlers.TokenHandler, streamHandler *handlers.StreamHandler) {
+func SetupRoutes(e *echo.Echo, ingressHandler *handlers.IngressHandler, tokenHandler *handlers.TokenHandler, streamHandler *handlers.StreamHandler, accountHandler *handlers.AccountHandler, oidcHandler *handlers.OIDCHandler, inviteHandler *handlers.InviteHandler, banHandler *handlers.BanHandler, webhookHandler *handlers.WebhookHandler, lobbyHandler *handlers.LobbyHandler, searchHandler *handlers.SearchHandler, analyticsHandler *handlers.AnalyticsHandler, moderationHandler *handlers.ModerationHandler, stageHandler *handlers.StageHandler, chatHandler *handlers.ChatHandler, limiter ratelimit.Limiter, requireAuth, optionalAuth, requireActor, ticketAuth echo.MiddlewareFunc) {
 	// API 그룹
 	api := e.Group("/api")
 
+	// 토큰/룸 생성 요청 제한 (사용자 단위 제한을 위해 인증 미들웨어 뒤에 적용)
+	limitGetToken := ratelimit.Middleware(limiter, ratelimit.GetTokenPolicy)
+	limitJoinStream := ratelimit.Middleware(limiter, ratelimit.JoinStreamPolicy)
+	limitCreateStream := ratelimit.Middleware(limiter, ratelimit.CreateStreamPolicy)
+	limitCreateIngress := ratelimit.Middleware(limiter, ratelimit.CreateIngressPolicy)
+
+	// LiveKit 웹훅 (서명으로 인증)
+	e.POST("/webhooks/livekit", webhookHandler.ReceiveLiveKit)
+
+	// 계정 관련 라우트
+	api.POST("/auth/signup", accountHandler.Signup)                                // 회원 가입
+	api.POST("/auth/login", accountHandler.Login)                                  // 로그인 (세션 토큰 발급)
+	api.GET("/auth/me", accountHandler.Me, requireAuth)                            // 현재 사용자 조회
+	api.POST("/auth/stream-ticket", accountHandler.IssueStreamTicket, requireAuth) // SSE/WebSocket 연결용 1회용 티켓 (?ticket=)
+
+	// OIDC 로그인 라우트
+	api.GET("/auth/oidc", oidcHandler.ListProviders)               // IdP 목록
+	api.GET("/auth/oidc/:provider/login", oidcHandler.Login)       // IdP 로그인 페이지로 이동
+	api.GET("/auth/oidc/:provider/callback", oidcHandler.Callback) // 인가 코드 콜백 (세션 발급)
+
 	// Ingress 관련 라우트
-	api.POST("/create_ingress", ingressHandler.CreateIngress)
-	api.GET("/ingress", ingressHandler.ListIngress)                 // 모든 Ingress 조회
-	api.GET("/ingress/:ingressId", ingressHandler.GetIngress)       // 특정 Ingress 조회
-	api.DELETE("/ingress/:ingressId", ingressHandler.DeleteIngress) // Ingress 삭제
+	api.POST("/create_ingress", ingressHandler.CreateIngress, requireAuth, limitCreateIngress)
+	api.GET("/ingress", ingressHandler.ListIngress)                               // 모든 Ingress 조회
+	api.GET("/ingress/:ingressId", ingressHandler.GetIngress)                     // 특정 Ingress 조회
+	api.DELETE("/ingress/:ingressId", ingressHandler.DeleteIngress, requireActor) // Ingress 삭제
 
 	// 토큰 관련 라우트
-	e.GET("/getToken", tokenHandler.GetToken)
+	e.GET("/getToken", tokenHandler.GetToken, requireAuth, limitGetToken)
+	api.POST("/token/refresh", tokenHandler.RefreshToken, requireAuth) // 만료 전 LiveKit 토큰 재발급
 
 	// 스트림 관련 라우트
-	api.POST("/create_stream", streamHandler.CreateStream)      // 스트림 생성
-	api.POST("/join_stream", streamHandler.JoinStream)          // 스트림 참여
-	api.GET("/streams", streamHandler.ListStreams)              // 모든 스트림 조회
-	api.GET("/streams/:room_id", streamHandler.GetStream)       // 특정 스트림 조회
-	api.DELETE("/streams/:room_id", streamHandler.DeleteStream) // 스트림 삭제
+	api.POST("/create_stream", streamHandler.CreateStream, requireAuth, limitCreateStream) // 스트림 생성
+	api.POST("/join_stream", streamHandler.JoinStream, requireAuth, limitJoinStream)       // 스트림 참여
+	api.POST("/streams", streamHandler.ScheduleStream, requireAuth, limitCreateStream)     // 예약 스트림 생성 (scheduled_at)
+	api.GET("/streams", streamHandler.ListStreams, optionalAuth)                           // 모든 스트림 조회 (비공개 스트림은 생성자/운영자에게만)
+	api.GET("/streams/search", searchHandler.SearchStreams, optionalAuth)                  // 제목/설명/태그 검색
+	api.GET("/streams/events", lobbyHandler.StreamEvents, ticketAuth, optionalAuth)        // 로비 이벤트 (SSE)
+	api.GET("/streams/events/ws", lobbyHandler.StreamEventsWS, ticketAuth, optionalAuth)   // 로비 이벤트 (WebSocket)
+	api.GET("/streams/:room_id", streamHandler.GetStream, optionalAuth)                    // 특정 스트림 조회
+	api.PATCH("/streams/:room_id", streamHandler.UpdateStream, requireActor)               // 스트림 메타데이터 수정 (If-Match)
+	api.DELETE("/streams/:room_id", streamHandler.DeleteStream, requireActor)              // 스트림 삭제
+
+	// 정원이 찬 룸의 대기열 라우트 (join_stream에 wait=true로 등록)
+	api.GET("/streams/:room_id/waitlist", streamHandler.GetWaitlist, requireAuth)                       // 대기 순번 조회 (허가 시 토큰)
+	api.GET("/streams/:room_id/waitlist/events", streamHandler.WaitlistEvents, ticketAuth, requireAuth) // 대기 순번 구독 (SSE)
+	api.DELETE("/streams/:room_id/waitlist", streamHandler.LeaveWaitlist, requireAuth)                  // 대기 포기
+
+	// 시청 통계 라우트 (생성자/운영자/관리자, 종료 후에도 조회 가능)
+	api.GET("/streams/:room_id/analytics", analyticsHandler.GetStreamAnalytics, requireActor) // 최대/고유 시청자, 시청 시간, 동시 시청자 시계열
+
+	// 참가자 관리 라우트 (생성자/운영자/관리자, 모든 작업은 관리 기록에 남음)
+	api.POST("/streams/:room_id/participants/:identity/mute", moderationHandler.MuteParticipant, requireActor)           // 트랙 음소거/해제
+	api.PATCH("/streams/:room_id/participants/:identity/permissions", moderationHandler.UpdatePermissions, requireActor) // 발행/구독/데이터 권한 변경
+	api.DELETE("/streams/:room_id/participants/:identity", moderationHandler.RemoveParticipant, requireActor)            // 참가자 내보내기
+	api.GET("/streams/:room_id/audit", moderationHandler.ListAudit, requireActor)                                        // 관리 기록
+
+	// 무대 라우트 (시청자는 구독만 가능, 승인되면 발언자 권한, 이벤트는 토픽 "stage" 데이터 메시지)
+	api.POST("/streams/:room_id/stage/requests", stageHandler.RaiseHand, requireAuth)                         // 손들기
+	api.GET("/streams/:room_id/stage/requests", stageHandler.ListRequests, requireActor)                      // 요청 목록 (생성자/운영자/관리자)
+	api.DELETE("/streams/:room_id/stage/requests/:identity", stageHandler.LowerHand, requireActor)            // 요청 취소 (본인 또는 진행자)
+	api.POST("/streams/:room_id/stage/requests/:identity/approve", stageHandler.ApproveRequest, requireActor) // 승인 (발언자로 승격)
+	api.POST("/streams/:room_id/stage/requests/:identity/deny", stageHandler.DenyRequest, requireActor)       // 거절
+	api.DELETE("/streams/:room_id/stage/speakers/:identity", stageHandler.Demote, requireActor)               // 무대에서 내리기 (본인 또는 진행자)
+
+	// 채팅 기록 라우트 (종료 후에도 조회 가능, 비공개 스트림은 생성자/운영자/접속 중인 참가자)
+	api.GET("/streams/:room_id/chat", chatHandler.GetChatHistory, optionalAuth) // 스크롤백/내보내기 (before, limit)
+
+	// 비공개 스트림 초대 라우트 (생성자/운영자/관리자)
+	api.POST("/streams/:room_id/invites", inviteHandler.CreateInvite, requireActor)              // 초대 코드 발급
+	api.GET("/streams/:room_id/invites", inviteHandler.ListInvites, requireActor)                // 초대 목록
+	api.DELETE("/streams/:room_id/invites/:invite_id", inviteHandler.RevokeInvite, requireActor) // 초대 회수
+
+	// 차단 라우트 (룸 차단: 생성자/운영자/관리자, 전체 차단: 운영자/관리자)
+	api.POST("/streams/:room_id/bans", banHandler.CreateRoomBan, requireActor)           // 강제 퇴장 및 룸 차단
+	api.GET("/streams/:room_id/bans", banHandler.ListRoomBans, requireActor)             // 룸 차단 목록
+	api.DELETE("/streams/:room_id/bans/:ban_id", banHandler.DeleteRoomBan, requireActor) // 룸 차단 해제
+	api.POST("/bans", banHandler.CreateGlobalBan, requireActor)                          // 전체 차단
+	api.GET("/bans", banHandler.ListGlobalBans, requireActor)                            // 전체 차단 목록
+	api.DELETE("/bans/:ban_id", banHandler.DeleteGlobalBan, requireActor)                // 전체 차단 해제
 }
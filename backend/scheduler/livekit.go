@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/catalog"
+	"backend/keyring"
+
+	"github.com/livekit/protocol/livekit"
+	lksdk "github.com/livekit/server-sdk-go/v2"
+)
+
+// 인그레스 참가자 identity 접미사 (handlers.CreateIngress와 동일)
+const ingressIdentitySuffix = " (via OBS)"
+
+// LiveKitProvisioner LiveKit 서버에 룸/인그레스 생성
+type LiveKitProvisioner struct {
+	hostURL string
+	keys    *keyring.Ring
+}
+
+// NewLiveKitProvisioner 생성자
+func NewLiveKitProvisioner(hostURL string, keys *keyring.Ring) *LiveKitProvisioner {
+	return &LiveKitProvisioner{
+		hostURL: hostURL,
+		keys:    keys,
+	}
+}
+
+func (p *LiveKitProvisioner) roomClient() *lksdk.RoomServiceClient {
+	primary := p.keys.Primary()
+	return lksdk.NewRoomServiceClient(p.hostURL, primary.Key, primary.Secret)
+}
+
+func (p *LiveKitProvisioner) ingressClient() *lksdk.IngressClient {
+	primary := p.keys.Primary()
+	return lksdk.NewIngressClient(p.hostURL, primary.Key, primary.Secret)
+}
+
+func (p *LiveKitProvisioner) Provision(ctx context.Context, stream *catalog.Stream, emptyTimeout time.Duration) (*catalog.Ingress, error) {
+	metadata, err := stream.MetadataJSON()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.roomClient().CreateRoom(ctx, &livekit.CreateRoomRequest{
+		Name:             stream.RoomId,
+		Metadata:         metadata,
+		EmptyTimeout:     uint32(emptyTimeout.Seconds()),
+		DepartureTimeout: 300,
+		MaxParticipants:  stream.RoomMaxParticipants(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create room: %w", err)
+	}
+
+	if stream.Ingress == nil {
+		return nil, nil
+	}
+
+	identity := stream.CreatorIdentity + ingressIdentitySuffix
+	// 이전 점검에서 만들었지만 기록하지 못한 인그레스가 있으면 재사용
+	existing, err := p.ingressClient().ListIngress(ctx, &livekit.ListIngressRequest{RoomName: stream.RoomId})
+	if err != nil {
+		return nil, fmt.Errorf("list ingress: %w", err)
+	}
+	for _, ingress := range existing.Items {
+		if ingress.ParticipantIdentity == identity {
+			return &catalog.Ingress{
+				Type:      stream.Ingress.Type,
+				IngressId: ingress.IngressId,
+				URL:       ingress.Url,
+				StreamKey: ingress.StreamKey,
+			}, nil
+		}
+	}
+
+	req := &livekit.CreateIngressRequest{
+		Name:                stream.RoomId,
+		RoomName:            stream.RoomId,
+		ParticipantName:     identity,
+		ParticipantIdentity: identity,
+	}
+	if stream.Ingress.Type == "whip" {
+		req.InputType = livekit.IngressInput_WHIP_INPUT
+		req.BypassTranscoding = true
+	} else {
+		req.InputType = livekit.IngressInput_RTMP_INPUT
+		req.Video = &livekit.IngressVideoOptions{Source: livekit.TrackSource_CAMERA}
+		req.Audio = &livekit.IngressAudioOptions{Source: livekit.TrackSource_MICROPHONE}
+	}
+
+	ingress, err := p.ingressClient().CreateIngress(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("create ingress: %w", err)
+	}
+	return &catalog.Ingress{
+		Type:      stream.Ingress.Type,
+		IngressId: ingress.IngressId,
+		URL:       ingress.Url,
+		StreamKey: ingress.StreamKey,
+	}, nil
+}
+
+func (p *LiveKitProvisioner) HostPresent(ctx context.Context, stream *catalog.Stream) (bool, error) {
+	resp, err := p.roomClient().ListParticipants(ctx, &livekit.ListParticipantsRequest{
+		Room: stream.RoomId,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, participant := range resp.Participants {
+		if participant.Identity == stream.CreatorIdentity || participant.Kind == livekit.ParticipantInfo_INGRESS {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *LiveKitProvisioner) Teardown(ctx context.Context, stream *catalog.Stream) error {
+	if stream.Ingress != nil && stream.Ingress.IngressId != "" {
+		p.ingressClient().DeleteIngress(ctx, &livekit.DeleteIngressRequest{
+			IngressId: stream.Ingress.IngressId,
+		})
+	}
+	// 이미 닫힌 룸이면 무시
+	p.roomClient().DeleteRoom(ctx, &livekit.DeleteRoomRequest{
+		Room: stream.RoomId,
+	})
+	return nil
+}
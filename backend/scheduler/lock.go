@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisLocker Redis 기반 소유자 잠금 (scheduler:lock = 인스턴스 토큰)
+// 보유 중인 인스턴스는 주기마다 연장하고, 멈추면 TTL이 지난 뒤 다른 인스턴스가 이어받는다.
+type RedisLocker struct {
+	rdb   *redis.Client
+	token string
+}
+
+// NewRedisLocker 생성자
+func NewRedisLocker(rdb *redis.Client) *RedisLocker {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return &RedisLocker{rdb: rdb, token: hex.EncodeToString(b)}
+}
+
+// lockScript 비어 있으면 확보, 자기 토큰이면 연장
+//
+//	KEYS[1] 잠금 키
+//	ARGV[1] 인스턴스 토큰, ARGV[2] TTL ms
+var lockScript = redis.NewScript(`
+local owner = redis.call('GET', KEYS[1])
+if owner == ARGV[1] then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+  return 1
+end
+if owner then return 0 end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return 1
+`)
+
+func (l *RedisLocker) TryLock(ctx context.Context, ttl time.Duration) (bool, error) {
+	ok, err := lockScript.Run(ctx, l.rdb, []string{"scheduler:lock"}, l.token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return ok == 1, nil
+}
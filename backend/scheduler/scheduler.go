@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"backend/catalog"
+	"backend/lifecycle"
+)
+
+// Provisioner 예약 스트림의 LiveKit 리소스 생성/확인/정리
+type Provisioner interface {
+	// Provision 룸 생성 (stream.Ingress가 있으면 인그레스도 생성해 결과 반환)
+	// 결과 저장에 실패해 다시 호출해도 이미 만든 룸/인그레스를 재사용 (중복 생성하지 않음)
+	Provision(ctx context.Context, stream *catalog.Stream, emptyTimeout time.Duration) (*catalog.Ingress, error)
+	// HostPresent 호스트 또는 인그레스가 룸에 접속했는지
+	HostPresent(ctx context.Context, stream *catalog.Stream) (bool, error)
+	// Teardown 룸과 인그레스 삭제
+	Teardown(ctx context.Context, stream *catalog.Stream) error
+}
+
+// Locker 여러 백엔드 인스턴스 중 한 곳에서만 스케줄링하도록 잠금
+type Locker interface {
+	// TryLock 잠금 확보 (이미 이 인스턴스가 보유 중이면 ttl만큼 연장)
+	TryLock(ctx context.Context, ttl time.Duration) (bool, error)
+}
+
+// Config 스케줄러 설정
+type Config struct {
+	Interval    time.Duration // 확인 주기
+	Lead        time.Duration // 시작 시각보다 얼마나 먼저 룸을 만들지
+	NoShowAfter time.Duration // 시작 시각 이후 호스트를 기다리는 시간
+	LockTTL     time.Duration // 잠금 유지 시간이자 한 번 점검의 제한 시간 (점검이 끝나기 전에 다른 인스턴스가 같은 스트림을 처리하지 않도록)
+}
+
+// DefaultConfig 15초마다 확인, 시작 5분 전 룸 생성, 15분 동안 호스트가 없으면 no-show, 잠금 2분
+func DefaultConfig() Config {
+	return Config{
+		Interval:    15 * time.Second,
+		Lead:        5 * time.Minute,
+		NoShowAfter: 15 * time.Minute,
+		LockTTL:     2 * time.Minute,
+	}
+}
+
+// Scheduler 예약 스트림 룸 자동 생성 및 no-show 처리
+type Scheduler struct {
+	streams     catalog.Repository
+	states      *lifecycle.Machine // 웹훅 이벤트와 같은 잠금으로 스트림 갱신
+	provisioner Provisioner
+	locker      Locker // nil이면 잠금 없이 실행 (단일 인스턴스)
+	cfg         Config
+}
+
+// New 생성자
+func New(streams catalog.Repository, states *lifecycle.Machine, provisioner Provisioner, locker Locker, cfg Config) *Scheduler {
+	return &Scheduler{
+		streams:     streams,
+		states:      states,
+		provisioner: provisioner,
+		locker:      locker,
+		cfg:         cfg,
+	}
+}
+
+// Run ctx가 끝날 때까지 주기적으로 Tick 실행
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if s.acquire(ctx) {
+			tickCtx, cancel := context.WithTimeout(ctx, s.cfg.LockTTL)
+			if err := s.Tick(tickCtx, time.Now()); err != nil {
+				log.Println("scheduler tick failed:", err)
+			}
+			cancel()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// acquire 이번 주기의 실행 권한 확보 (보유 중인 인스턴스는 주기마다 연장)
+func (s *Scheduler) acquire(ctx context.Context) bool {
+	if s.locker == nil {
+		return true
+	}
+	ok, err := s.locker.TryLock(ctx, s.cfg.LockTTL)
+	if err != nil {
+		log.Println("scheduler lock failed:", err)
+		return false
+	}
+	return ok
+}
+
+// Tick 예약 스트림 한 번 점검
+//   - 시작 Lead 전: 룸(및 인그레스) 생성
+//   - 생성 후: 호스트 접속 여부 기록
+//   - 시작 NoShowAfter 후에도 호스트가 없으면: no-show로 종료하고 리소스 정리
+//
+// 전체 카탈로그 대신 호스트를 기다리는 예약 스트림 인덱스에서 Lead 이내에 시작하는 기록만 읽음
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) error {
+	streams, err := s.streams.ListAwaitingHost(ctx, now.Add(s.cfg.Lead))
+	if err != nil {
+		return err
+	}
+
+	for _, stream := range streams {
+		if stream.ScheduledAt == nil || stream.Ended() || stream.HostJoinedAt != nil {
+			continue
+		}
+		if err := s.step(ctx, stream, now); err != nil {
+			log.Printf("scheduler: stream %s: %v", stream.RoomId, err)
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) step(ctx context.Context, stream *catalog.Stream, now time.Time) error {
+	startAt := *stream.ScheduledAt
+	deadline := startAt.Add(s.cfg.NoShowAfter)
+
+	if stream.ProvisionedAt == nil {
+		if now.Before(startAt.Add(-s.cfg.Lead)) {
+			return nil
+		}
+		// 호스트가 들어오기 전에 룸이 닫히지 않도록 no-show 시각까지 유지
+		ingress, err := s.provisioner.Provision(ctx, stream, deadline.Sub(now)+s.cfg.Interval)
+		if err != nil {
+			return err
+		}
+		_, err = s.states.Update(ctx, stream.RoomId, func(stream *catalog.Stream) error {
+			stream.ProvisionedAt = &now
+			if ingress != nil {
+				stream.Ingress = ingress
+			}
+			lifecycle.Apply(stream, lifecycle.Event{Type: lifecycle.EventProvisioned, At: now})
+			return nil
+		})
+		return err
+	}
+
+	present, err := s.provisioner.HostPresent(ctx, stream)
+	if err != nil {
+		return err
+	}
+	if present {
+		_, err := s.states.Update(ctx, stream.RoomId, func(stream *catalog.Stream) error {
+			if stream.HostJoinedAt != nil {
+				return catalog.ErrUnchanged
+			}
+			stream.HostJoinedAt = &now
+			return nil
+		})
+		return err
+	}
+
+	if now.After(deadline) {
+		if err := s.provisioner.Teardown(ctx, stream); err != nil {
+			return err
+		}
+		_, _, err := s.states.Apply(ctx, stream.RoomId, lifecycle.Event{Type: lifecycle.EventNoShow, At: now})
+		return err
+	}
+	return nil
+}
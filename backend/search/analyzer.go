@@ -0,0 +1,81 @@
+package search
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	_ "github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	unicodetokenizer "github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+const (
+	analyzerName      = "ko_en"
+	hangulBigramsName = "hangul_bigrams"
+)
+
+// hangulBigrams 한글 토큰에 음절 바이그램을 같은 위치로 추가
+// 한국어는 띄어쓰기 단위 토큰에 조사가 붙으므로("방송을") 원래 토큰만으로는 "방송"이 맞지 않는다.
+// 영어 토큰은 그대로 둔다.
+type hangulBigrams struct{}
+
+func (hangulBigrams) Filter(input analysis.TokenStream) analysis.TokenStream {
+	output := make(analysis.TokenStream, 0, len(input))
+	for _, token := range input {
+		output = append(output, token)
+		if !isHangul(token.Term) || utf8.RuneCount(token.Term) < 3 {
+			continue
+		}
+		runes := []rune(string(token.Term))
+		for i := 0; i+1 < len(runes); i++ {
+			output = append(output, &analysis.Token{
+				Term:     []byte(string(runes[i : i+2])),
+				Start:    token.Start,
+				End:      token.End,
+				Position: token.Position,
+				Type:     token.Type,
+			})
+		}
+	}
+	return output
+}
+
+func isHangul(term []byte) bool {
+	r, _ := utf8.DecodeRune(term)
+	return unicode.Is(unicode.Hangul, r)
+}
+
+func init() {
+	registry.RegisterTokenFilter(hangulBigramsName, func(config map[string]interface{}, cache *registry.Cache) (analysis.TokenFilter, error) {
+		return hangulBigrams{}, nil
+	})
+}
+
+// newMapping 제목/설명/태그를 한국어+영어 분석기로 색인하는 매핑
+func newMapping() (mapping.IndexMapping, error) {
+	m := mapping.NewIndexMapping()
+	err := m.AddCustomAnalyzer(analyzerName, map[string]interface{}{
+		"type":          "custom",
+		"tokenizer":     unicodetokenizer.Name,
+		"token_filters": []string{lowercase.Name, hangulBigramsName},
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.DefaultAnalyzer = analyzerName
+
+	text := mapping.NewTextFieldMapping()
+	text.Analyzer = analyzerName
+	text.Store = false
+	text.IncludeInAll = false
+
+	doc := mapping.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("title", text)
+	doc.AddFieldMappingsAt("description", text)
+	doc.AddFieldMappingsAt("tags", text)
+	m.DefaultMapping = doc
+	return m, nil
+}
@@ -0,0 +1,235 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"backend/catalog"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// 필드별 가중치
+const (
+	titleBoost       = 5.0
+	tagsBoost        = 2.0
+	descriptionBoost = 1.0
+)
+
+// 가시성 필터를 적용하기 전 bleve에서 가져오는 최대 결과 수
+const maxCandidates = 1000
+
+// Document 색인 대상 스트림 (룸 메타데이터의 title, description, tags)
+type Document struct {
+	RoomId          string   `json:"-"`
+	Title           string   `json:"title"`
+	Description     string   `json:"description"`
+	Tags            []string `json:"tags"`
+	CreatorIdentity string   `json:"-"`
+	Private         bool     `json:"-"`
+	Ended           bool     `json:"-"`
+}
+
+// FromStream 카탈로그 기록으로 문서 생성
+func FromStream(stream *catalog.Stream) *Document {
+	doc := &Document{
+		RoomId:          stream.RoomId,
+		CreatorIdentity: stream.CreatorIdentity,
+		Private:         stream.IsPrivate(),
+		Ended:           stream.CurrentState() == catalog.StateEnded,
+	}
+	doc.Title, _ = stream.Metadata["title"].(string)
+	doc.Description, _ = stream.Metadata["description"].(string)
+	// tags는 배열 또는 쉼표로 구분한 문자열
+	switch tags := stream.Metadata["tags"].(type) {
+	case []interface{}:
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				doc.Tags = append(doc.Tags, s)
+			}
+		}
+	case string:
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				doc.Tags = append(doc.Tags, tag)
+			}
+		}
+	}
+	return doc
+}
+
+// Hit 검색 결과
+type Hit struct {
+	RoomId string
+	Score  float64
+}
+
+// Index 인메모리 스트림 검색 색인
+// 인스턴스마다 따로 두고 시작 시 카탈로그로 채운 뒤 스트림 변경 이벤트로 갱신한다.
+type Index struct {
+	index bleve.Index
+
+	mu   sync.RWMutex
+	docs map[string]*Document // 가시성 판단용
+}
+
+// NewIndex 생성자
+func NewIndex() (*Index, error) {
+	m, err := newMapping()
+	if err != nil {
+		return nil, err
+	}
+	index, err := bleve.NewMemOnly(m)
+	if err != nil {
+		return nil, err
+	}
+	return &Index{
+		index: index,
+		docs:  make(map[string]*Document),
+	}, nil
+}
+
+// Put 문서 추가 또는 교체
+func (i *Index) Put(doc *Document) error {
+	if err := i.index.Index(doc.RoomId, doc); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	i.docs[doc.RoomId] = doc
+	i.mu.Unlock()
+	return nil
+}
+
+// Delete 문서 제거 (카탈로그에서 사라진 스트림)
+func (i *Index) Delete(roomId string) error {
+	if err := i.index.Delete(roomId); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	delete(i.docs, roomId)
+	i.mu.Unlock()
+	return nil
+}
+
+// Rebuild 카탈로그 전체로 색인을 통째로 교체 (카탈로그에 없는 문서는 제거)
+func (i *Index) Rebuild(ctx context.Context, streams catalog.Repository) error {
+	list, err := streams.List(ctx)
+	if err != nil {
+		return err
+	}
+	batch := i.index.NewBatch()
+	docs := make(map[string]*Document, len(list))
+	for _, stream := range list {
+		doc := FromStream(stream)
+		if err := batch.Index(doc.RoomId, doc); err != nil {
+			return err
+		}
+		docs[doc.RoomId] = doc
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for roomId := range i.docs {
+		if _, ok := docs[roomId]; !ok {
+			batch.Delete(roomId)
+		}
+	}
+	if err := i.index.Batch(batch); err != nil {
+		return err
+	}
+	i.docs = docs
+	return nil
+}
+
+// Search 관련도 순 검색 (visible이 false인 문서는 제외)
+//   - 한국어: 음절 바이그램으로 조사/붙여쓰기와 무관하게 일치
+//   - 마지막 단어: 접두어 일치 (입력 중 검색)
+//   - 4자 이상 영어 단어: 오타 1자(8자 이상은 2자) 허용
+func (i *Index) Search(ctx context.Context, text string, limit int, visible func(*Document) bool) ([]Hit, int, error) {
+	q := buildQuery(text)
+	if q == nil {
+		return []Hit{}, 0, nil
+	}
+	req := bleve.NewSearchRequestOptions(q, maxCandidates, 0, false)
+	res, err := i.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	hits := []Hit{}
+	total := 0
+	for _, match := range res.Hits {
+		doc, ok := i.docs[match.ID]
+		if !ok || !visible(doc) {
+			continue
+		}
+		total++
+		if len(hits) < limit {
+			hits = append(hits, Hit{RoomId: match.ID, Score: match.Score})
+		}
+	}
+	return hits, total, nil
+}
+
+func buildQuery(text string) query.Query {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return nil
+	}
+
+	fields := []struct {
+		name  string
+		boost float64
+	}{
+		{"title", titleBoost},
+		{"tags", tagsBoost},
+		{"description", descriptionBoost},
+	}
+
+	var queries []query.Query
+	for _, field := range fields {
+		match := bleve.NewMatchQuery(text)
+		match.SetField(field.name)
+		match.SetBoost(field.boost)
+		queries = append(queries, match)
+
+		for n, word := range words {
+			if fuzziness := typoTolerance(word); fuzziness > 0 {
+				fuzzy := bleve.NewFuzzyQuery(word)
+				fuzzy.SetField(field.name)
+				fuzzy.SetFuzziness(fuzziness)
+				fuzzy.SetBoost(field.boost * 0.5)
+				queries = append(queries, fuzzy)
+			}
+			if n == len(words)-1 {
+				prefix := bleve.NewPrefixQuery(word)
+				prefix.SetField(field.name)
+				prefix.SetBoost(field.boost * 0.5)
+				queries = append(queries, prefix)
+			}
+		}
+	}
+	return bleve.NewDisjunctionQuery(queries...)
+}
+
+// typoTolerance 영어 단어 길이에 따른 허용 편집 거리 (한글은 바이그램으로 대신함)
+func typoTolerance(word string) int {
+	for _, r := range word {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return 0
+		}
+	}
+	switch n := utf8.RuneCountInString(word); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+	return 0
+}
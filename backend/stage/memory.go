@@ -0,0 +1,68 @@
+package stage
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore 인메모리 무대 요청 저장소 (단일 인스턴스/테스트용)
+type MemoryStore struct {
+	mu       sync.Mutex
+	requests map[string]map[string]*Request // room -> identity -> 요청
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{requests: map[string]map[string]*Request{}}
+}
+
+func (s *MemoryStore) Add(ctx context.Context, r *Request) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, ok := s.requests[r.RoomId]
+	if !ok {
+		room = map[string]*Request{}
+		s.requests[r.RoomId] = room
+	}
+	if _, ok := room[r.Identity]; ok {
+		return false, nil
+	}
+	copied := *r
+	room[r.Identity] = &copied
+	return true, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, roomId string) ([]*Request, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]*Request, 0, len(s.requests[roomId]))
+	for _, r := range s.requests[roomId] {
+		copied := *r
+		requests = append(requests, &copied)
+	}
+	return requests, nil
+}
+
+func (s *MemoryStore) Remove(ctx context.Context, roomId, identity string) (*Request, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.requests[roomId][identity]
+	if !ok {
+		return nil, ErrRequestNotFound
+	}
+	delete(s.requests[roomId], identity)
+	if len(s.requests[roomId]) == 0 {
+		delete(s.requests, roomId)
+	}
+	return r, nil
+}
+
+func (s *MemoryStore) Clear(ctx context.Context, roomId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.requests, roomId)
+	return nil
+}
@@ -0,0 +1,88 @@
+package stage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 마지막 요청 후 보관 기간 (종료 이벤트를 놓친 룸 정리)
+const requestRetention = 24 * time.Hour
+
+// removeScript 요청 조회와 삭제를 원자적으로 수행 (두 운영자가 동시에 승인해도 한 번만 처리)
+var removeScript = redis.NewScript(`
+local data = redis.call('HGET', KEYS[1], ARGV[1])
+if data then
+  redis.call('HDEL', KEYS[1], ARGV[1])
+end
+return data
+`)
+
+// RedisStore Redis 기반 무대 요청 저장소 (stage:<room>:requests hash, identity -> 요청)
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func requestsKey(roomId string) string {
+	return "stage:" + roomId + ":requests"
+}
+
+func (s *RedisStore) Add(ctx context.Context, r *Request) (bool, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return false, err
+	}
+	var added *redis.BoolCmd
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		added = pipe.HSetNX(ctx, requestsKey(r.RoomId), r.Identity, data)
+		pipe.Expire(ctx, requestsKey(r.RoomId), requestRetention)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return added.Val(), nil
+}
+
+func (s *RedisStore) List(ctx context.Context, roomId string) ([]*Request, error) {
+	values, err := s.rdb.HVals(ctx, requestsKey(roomId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]*Request, 0, len(values))
+	for _, value := range values {
+		var r Request
+		if err := json.Unmarshal([]byte(value), &r); err != nil {
+			continue
+		}
+		requests = append(requests, &r)
+	}
+	return requests, nil
+}
+
+func (s *RedisStore) Remove(ctx context.Context, roomId, identity string) (*Request, error) {
+	data, err := removeScript.Run(ctx, s.rdb, []string{requestsKey(roomId)}, identity).Text()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var r Request
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (s *RedisStore) Clear(ctx context.Context, roomId string) error {
+	return s.rdb.Del(ctx, requestsKey(roomId)).Err()
+}
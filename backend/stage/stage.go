@@ -0,0 +1,84 @@
+package stage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+var ErrRequestNotFound = errors.New("stage request not found")
+
+// Request 무대 요청 (손들기)
+type Request struct {
+	RoomId      string    `json:"room_id"`
+	Identity    string    `json:"identity"`
+	Name        string    `json:"name,omitempty"`
+	Message     string    `json:"message,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// Store 룸별 무대 요청 저장소 (참가자당 하나)
+type Store interface {
+	// Add 요청 추가 (이미 있으면 기존 요청 유지, false 반환)
+	Add(ctx context.Context, r *Request) (bool, error)
+	List(ctx context.Context, roomId string) ([]*Request, error)
+	// Remove 요청 삭제 후 반환 (없으면 ErrRequestNotFound)
+	Remove(ctx context.Context, roomId, identity string) (*Request, error)
+	Clear(ctx context.Context, roomId string) error
+}
+
+// Queue 무대 요청 대기열 (요청 순)
+type Queue struct {
+	store Store
+}
+
+// NewQueue 생성자
+func NewQueue(store Store) *Queue {
+	return &Queue{store: store}
+}
+
+// Raise 손들기 (이미 들었으면 기존 요청과 순서 유지)
+func (q *Queue) Raise(ctx context.Context, roomId, identity, name, message string) (*Request, bool, error) {
+	r := &Request{
+		RoomId:      roomId,
+		Identity:    identity,
+		Name:        name,
+		Message:     message,
+		RequestedAt: time.Now(),
+	}
+	created, err := q.store.Add(ctx, r)
+	if err != nil || created {
+		return r, created, err
+	}
+	requests, err := q.List(ctx, roomId)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, existing := range requests {
+		if existing.Identity == identity {
+			return existing, false, nil
+		}
+	}
+	return r, false, nil
+}
+
+// List 요청 순 목록
+func (q *Queue) List(ctx context.Context, roomId string) ([]*Request, error) {
+	requests, err := q.store.List(ctx, roomId)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(requests, func(i, j int) bool { return requests[i].RequestedAt.Before(requests[j].RequestedAt) })
+	return requests, nil
+}
+
+// Take 요청을 꺼냄 (승인/거절/손내리기)
+func (q *Queue) Take(ctx context.Context, roomId, identity string) (*Request, error) {
+	return q.store.Remove(ctx, roomId, identity)
+}
+
+// Clear 종료된 룸의 요청 삭제
+func (q *Queue) Clear(ctx context.Context, roomId string) error {
+	return q.store.Clear(ctx, roomId)
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/account"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zeebo/assert"
+)
+
+// 가입 -> 로그인 -> 세션 검증 흐름
+func TestAccountSignupLogin(t *testing.T) {
+	ctx := context.Background()
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	svc := account.NewService(account.NewMemoryStore(), sessions)
+
+	user, err := svc.Signup(ctx, "host123", "correct-horse", "Host")
+	assert.NoError(t, err)
+	assert.Equal(t, user.DisplayName, "Host")
+	assert.True(t, user.PasswordHash != "correct-horse")
+
+	_, err = svc.Signup(ctx, "HOST123", "another-password", "")
+	assert.Equal(t, err, account.ErrUserExists)
+
+	_, err = svc.Signup(ctx, "ab", "correct-horse", "")
+	assert.Equal(t, err, account.ErrInvalidUsername)
+
+	_, _, err = svc.Login(ctx, "host123", "wrong-password")
+	assert.Equal(t, err, account.ErrInvalidCredentials)
+	_, _, err = svc.Login(ctx, "nobody", "whatever-password")
+	assert.Equal(t, err, account.ErrInvalidCredentials)
+
+	_, session, err := svc.Login(ctx, "host123", "correct-horse")
+	assert.NoError(t, err)
+
+	p, err := sessions.Verify(session.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, p.Identity, "host123")
+	assert.Equal(t, p.UserID, user.ID)
+	assert.Equal(t, p.Provider, account.ProviderLocal)
+
+	// 다른 키로 서명된 토큰은 거부
+	other := account.NewSessionIssuer("another-secret-0123456789abcdef0123", time.Hour)
+	_, err = other.Verify(session.Token)
+	assert.Equal(t, err, account.ErrInvalidSession)
+}
+
+// 스트림 티켓: 한 번만 사용 가능, 만료 후 거부, 세션 토큰은 쿼리 문자열로 받지 않음
+func TestStreamTicket(t *testing.T) {
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	tickets := account.NewTickets(account.NewMemoryTicketStore(), time.Minute)
+	principal := &account.Principal{UserID: "u1", Identity: "viewer1"}
+	session, err := sessions.Issue(principal)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	e.GET("/events", func(c echo.Context) error {
+		return c.String(http.StatusOK, account.PrincipalFrom(c).Identity)
+	}, account.TicketFromQuery(tickets), account.RequireAuth(sessions))
+	get := func(query string) (int, string) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events?"+query, nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	ticket, err := tickets.Issue(context.Background(), principal)
+	assert.NoError(t, err)
+	code, body := get("ticket=" + ticket.Ticket)
+	assert.Equal(t, code, http.StatusOK)
+	assert.Equal(t, body, "viewer1")
+	code, _ = get("ticket=" + ticket.Ticket)
+	assert.Equal(t, code, http.StatusUnauthorized)
+
+	code, _ = get("access_token=" + session.Token)
+	assert.Equal(t, code, http.StatusUnauthorized)
+
+	expiring := account.NewTickets(account.NewMemoryTicketStore(), 10*time.Millisecond)
+	ticket, err = expiring.Issue(context.Background(), principal)
+	assert.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = expiring.Redeem(context.Background(), ticket.Ticket)
+	assert.Equal(t, err, account.ErrInvalidTicket)
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/analytics"
+	"backend/catalog"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+	"github.com/zeebo/assert"
+)
+
+// 통계: 호스트 제외, 재접속은 고유 시청자 1명, 룸 종료 시 남은 시청자 시청 종료, 분 단위 동시 시청자
+func TestStreamAnalytics(t *testing.T) {
+	ctx := context.Background()
+	streams := catalog.NewMemoryRepository()
+	recorder := analytics.NewRecorder(analytics.NewMemoryStore(), streams)
+	start := time.Date(2030, 1, 1, 20, 0, 0, 0, time.UTC)
+	stream := &catalog.Stream{RoomId: "room-1", CreatorIdentity: "host1", CreatedAt: start}
+	assert.NoError(t, streams.Create(ctx, stream))
+	record := func(event, identity string, offset time.Duration) {
+		err := recorder.HandleWebhook(ctx, &livekit.WebhookEvent{
+			Event:       event,
+			Room:        &livekit.Room{Name: stream.RoomId},
+			Participant: &livekit.ParticipantInfo{Identity: identity},
+			CreatedAt:   start.Add(offset).Unix(),
+		})
+		assert.NoError(t, err)
+	}
+
+	record(webhook.EventParticipantJoined, "host1", 0)
+	record(webhook.EventParticipantJoined, "alice", 0)
+	record(webhook.EventParticipantJoined, "bob", 30*time.Second)
+	record(webhook.EventParticipantLeft, "alice", 90*time.Second)
+	record(webhook.EventParticipantJoined, "alice", 150*time.Second) // 재접속
+	record(webhook.EventParticipantJoined, "carol", 150*time.Second)
+	record(webhook.EventRoomFinished, "", 4*time.Minute)
+
+	report, err := recorder.Report(ctx, stream, start.Add(4*time.Minute), time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, report.PeakViewers, 3)
+	assert.Equal(t, report.PeakAt.Unix(), start.Add(150*time.Second).Unix())
+	assert.Equal(t, report.UniqueViewers, 3)
+	assert.Equal(t, report.Sessions, 4)
+	assert.Equal(t, report.CurrentViewers, 0)
+	// alice 90+90, bob 210, carol 90
+	assert.Equal(t, report.TotalWatchSeconds, int64(480))
+	assert.Equal(t, report.AverageWatchSeconds, int64(160))
+
+	viewers := []int{}
+	for _, point := range report.Series {
+		viewers = append(viewers, point.Viewers)
+	}
+	assert.DeepEqual(t, viewers, []int{2, 2, 3, 3, 0})
+
+	// 같은 룸 이름으로 다시 만든 스트림은 이전 통계와 섞이지 않음, 카탈로그에 없는 회의 룸은 기록하지 않음
+	reused := &catalog.Stream{RoomId: "room-1", CreatorIdentity: "host1", CreatedAt: start.Add(time.Hour)}
+	report, err = recorder.Report(ctx, reused, start.Add(2*time.Hour), time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, report.Sessions, 0)
+	assert.Equal(t, len(report.Series), 0)
+	assert.NoError(t, recorder.HandleWebhook(ctx, &livekit.WebhookEvent{
+		Event:       webhook.EventParticipantJoined,
+		Room:        &livekit.Room{Name: "my-room"},
+		Participant: &livekit.ParticipantInfo{Identity: "alice"},
+	}))
+}
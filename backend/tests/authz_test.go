@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/authz"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zeebo/assert"
+)
+
+// 관리 권한: 생성자, 운영자, 관리자 키만 허용
+func TestActorCanManage(t *testing.T) {
+	creator := &authz.Actor{Principal: &account.Principal{Identity: "host123"}}
+	other := &authz.Actor{Principal: &account.Principal{Identity: "viewer1"}}
+	moderator := &authz.Actor{Principal: &account.Principal{Identity: "mod1", Role: "moderator"}}
+	admin := &authz.Actor{Admin: true}
+
+	assert.True(t, creator.CanManage("host123"))
+	assert.False(t, other.CanManage("host123"))
+	assert.False(t, other.CanManage(""))
+	assert.True(t, moderator.CanManage("host123"))
+	assert.True(t, admin.CanManage("host123"))
+}
+
+// RequireActor: 인증 없음 401, 잘못된 키 401, 관리자 키/세션 통과
+func TestRequireActorMiddleware(t *testing.T) {
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	mw := authz.RequireActor(sessions, authz.NewAdminKeys([]string{"admin-key", ""}))
+	e := echo.New()
+
+	serve := func(setup func(r *http.Request)) (int, *authz.Actor) {
+		req := httptest.NewRequest(http.MethodDelete, "/api/streams/room-1", nil)
+		setup(req)
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		var actor *authz.Actor
+		err := mw(func(c echo.Context) error {
+			actor = authz.ActorFrom(c)
+			return c.NoContent(http.StatusOK)
+		})(c)
+		if he, ok := err.(*echo.HTTPError); ok {
+			return he.Code, nil
+		}
+		return http.StatusOK, actor
+	}
+
+	code, _ := serve(func(r *http.Request) {})
+	assert.Equal(t, code, http.StatusUnauthorized)
+
+	code, _ = serve(func(r *http.Request) { r.Header.Set(authz.HeaderAdminKey, "wrong") })
+	assert.Equal(t, code, http.StatusUnauthorized)
+
+	code, actor := serve(func(r *http.Request) { r.Header.Set(authz.HeaderAdminKey, "admin-key") })
+	assert.Equal(t, code, http.StatusOK)
+	assert.True(t, actor.Admin)
+
+	session, err := sessions.Issue(&account.Principal{UserID: "u1", Identity: "host123"})
+	assert.NoError(t, err)
+	code, actor = serve(func(r *http.Request) { r.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token) })
+	assert.Equal(t, code, http.StatusOK)
+	assert.False(t, actor.Admin)
+	assert.Equal(t, actor.Principal.Identity, "host123")
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/ban"
+
+	"github.com/zeebo/assert"
+)
+
+// 룸/전체 차단 등록, 만료, 해제
+func TestBanCheck(t *testing.T) {
+	ctx := context.Background()
+	svc := ban.NewService(ban.NewMemoryStore())
+
+	viewer := ban.Subject{Identity: "viewer1", UserID: "u-1", IP: "10.0.0.1"}
+
+	b, err := svc.Check(ctx, "room-1", viewer)
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	roomBan, err := svc.Ban(ctx, "room-1", ban.KindIdentity, "viewer1", "spam", "host123", 0)
+	assert.NoError(t, err)
+
+	b, err = svc.Check(ctx, "room-1", viewer)
+	assert.NoError(t, err)
+	assert.Equal(t, b.ID, roomBan.ID)
+
+	// 다른 룸에는 영향 없음
+	b, err = svc.Check(ctx, "room-2", viewer)
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	// IP 전체 차단은 모든 룸에 적용 (표기 정규화)
+	_, err = svc.Ban(ctx, "", ban.KindIP, "::ffff:10.0.0.1", "", "admin", time.Hour)
+	assert.NoError(t, err)
+	b, err = svc.Check(ctx, "room-2", viewer)
+	assert.NoError(t, err)
+	assert.That(t, b.Global())
+
+	_, err = svc.Ban(ctx, "", ban.KindIP, "not-an-ip", "", "admin", 0)
+	assert.Error(t, err)
+
+	// 만료된 차단은 무시
+	_, err = svc.Ban(ctx, "room-3", ban.KindAccount, "u-2", "", "host123", time.Nanosecond)
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	b, err = svc.Check(ctx, "room-3", ban.Subject{Identity: "viewer2", UserID: "u-2"})
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	// 다른 룸 범위로는 해제 불가
+	assert.Equal(t, svc.Unban(ctx, "room-2", roomBan.ID), ban.ErrBanNotFound)
+	assert.NoError(t, svc.Unban(ctx, "room-1", roomBan.ID))
+	list, err := svc.List(ctx, "room-1")
+	assert.NoError(t, err)
+	assert.Equal(t, len(list), 0)
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/catalog"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 스트림 기록 저장, 최신순 조회, 종료 후에도 기록 유지
+func TestCatalogRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := catalog.NewMemoryRepository()
+
+	now := time.Now()
+	assert.NoError(t, repo.Save(ctx, &catalog.Stream{
+		RoomId:          "room-1",
+		CreatorIdentity: "host123",
+		Metadata:        map[string]interface{}{"title": "첫 방송", "isPrivate": "true"},
+		CreatedAt:       now.Add(-time.Hour),
+	}))
+	assert.NoError(t, repo.Save(ctx, &catalog.Stream{
+		RoomId:          "room-2",
+		CreatorIdentity: "host456",
+		Metadata:        map[string]interface{}{"title": "두 번째 방송"},
+		CreatedAt:       now,
+	}))
+
+	list, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, len(list), 2)
+	assert.Equal(t, list[0].RoomId, "room-2")
+	assert.That(t, list[1].IsPrivate())
+
+	// 조회 결과를 수정해도 저장된 값은 그대로
+	list[0].Metadata["title"] = "changed"
+	stream, err := repo.Get(ctx, "room-2")
+	assert.NoError(t, err)
+	assert.Equal(t, stream.Metadata["title"], "두 번째 방송")
+
+	_, err = repo.Update(ctx, "room-1", func(s *catalog.Stream) error {
+		s.EndedAt = &now
+		return nil
+	})
+	assert.NoError(t, err)
+	stream, err = repo.Get(ctx, "room-1")
+	assert.NoError(t, err)
+	assert.That(t, stream.Ended())
+	assert.Equal(t, stream.CreatorIdentity, "host123")
+
+	// Create는 기존 기록을 덮어쓰지 않음 (생성자/호스트 권한 유지)
+	err = repo.Create(ctx, &catalog.Stream{RoomId: "room-2", CreatorIdentity: "intruder", CreatedAt: now})
+	assert.Equal(t, err, catalog.ErrStreamExists)
+	stream, err = repo.Get(ctx, "room-2")
+	assert.NoError(t, err)
+	assert.Equal(t, stream.CreatorIdentity, "host456")
+	assert.NoError(t, repo.Create(ctx, &catalog.Stream{RoomId: "room-4", CreatorIdentity: "host789", CreatedAt: now}))
+
+	// 상태별 조회 (종료된 room-1 제외)
+	active, err := repo.ListByState(ctx, catalog.StateWaitingForHost, catalog.StateLive)
+	assert.NoError(t, err)
+	assert.Equal(t, len(active), 2)
+	for _, s := range active {
+		assert.That(t, !s.Ended())
+	}
+	ended, err := repo.ListByState(ctx, catalog.StateEnded)
+	assert.NoError(t, err)
+	assert.Equal(t, len(ended), 1)
+	assert.Equal(t, ended[0].RoomId, "room-1")
+
+	_, err = repo.Get(ctx, "missing")
+	assert.Equal(t, err, catalog.ErrStreamNotFound)
+
+	// 카탈로그에 없는 룸은 메타데이터로 복원
+	restored := catalog.FromRoom(&livekit.Room{Name: "room-3", Metadata: `{"creator_identity":"obs-host","isPrivate":true}`})
+	assert.Equal(t, restored.CreatorIdentity, "obs-host")
+	assert.That(t, restored.IsPrivate())
+}
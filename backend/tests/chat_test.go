@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/catalog"
+	"backend/chat"
+	"backend/handlers"
+	"backend/keyring"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+	"github.com/zeebo/assert"
+)
+
+// fakeChatConnector 기록 봇 대역 (접속하면 record 함수를 넘겨줌)
+type fakeChatConnector struct {
+	mu        sync.Mutex
+	connected chan func(*chat.Message)
+	closed    int
+}
+
+func (f *fakeChatConnector) Connect(roomId string, record func(*chat.Message), closed func()) (chat.Session, error) {
+	f.connected <- record
+	return f, nil
+}
+
+func (f *fakeChatConnector) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed++
+}
+
+func (f *fakeChatConnector) closedCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+// 채팅 기록: 룸이 열리면 봇 접속, 같은 ID는 한 번만 저장, 삭제한 메시지는 제외, 종료 후에도 before/limit으로 스크롤백
+func TestChatHistory(t *testing.T) {
+	connector := &fakeChatConnector{connected: make(chan func(*chat.Message), 1)}
+	recorder := chat.NewRecorder(chat.NewMemoryStore(), connector, chat.NewMemoryLease())
+	ctx := context.Background()
+	room := &livekit.Room{Name: "room-1"}
+
+	assert.NoError(t, recorder.HandleWebhook(ctx, &livekit.WebhookEvent{Event: webhook.EventRoomStarted, Room: room}))
+	assert.NoError(t, recorder.HandleWebhook(ctx, &livekit.WebhookEvent{Event: webhook.EventParticipantJoined, Room: room, Participant: &livekit.ParticipantInfo{Identity: "viewer1"}}))
+	var record func(*chat.Message)
+	select {
+	case record = <-connector.connected:
+	case <-time.After(time.Second):
+		t.Fatal("recorder did not connect")
+	}
+
+	base := time.Now().Add(-time.Minute)
+	for i, text := range []string{"hello", "hi", "welcome", "bye"} {
+		record(&chat.Message{ID: string(rune('a' + i)), Identity: "viewer1", Message: text, Timestamp: base.Add(time.Duration(i) * time.Second)})
+	}
+	record(&chat.Message{ID: "a", Identity: "viewer1", Message: "hello"}) // 텍스트 스트림과 이전 형식 메시지 중복
+
+	// 저장 후 삭제, 저장 전에 삭제된 메시지는 이후에 받아도 저장하지 않음
+	assert.NoError(t, recorder.Redact(ctx, "room-1", "c"))
+	assert.NoError(t, recorder.Redact(ctx, "room-1", "e"))
+	record(&chat.Message{ID: "e", Identity: "viewer1", Message: "spam"})
+
+	// 접속이 끝나기 전에 종료되어도 연결은 닫힘
+	assert.NoError(t, recorder.HandleWebhook(ctx, &livekit.WebhookEvent{Event: webhook.EventRoomFinished, Room: room}))
+	for deadline := time.Now().Add(time.Second); connector.closedCount() == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, connector.closedCount(), 1)
+
+	// 룸이 종료된 뒤에도 카탈로그 스트림이면 조회 가능
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-1", CreatorIdentity: "host1", State: catalog.StateEnded}))
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: "secret-secret-secret-secret-secret"})
+	assert.NoError(t, err)
+	h := handlers.NewChatHandler(newFakeLiveKit(t, &fakeRoomService{}), keys, streams, recorder)
+
+	get := func(query string) (int, handlers.ChatHistoryResponse) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("room_id")
+		c.SetParamValues("room-1")
+		if err := h.GetChatHistory(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		var res handlers.ChatHistoryResponse
+		json.Unmarshal(rec.Body.Bytes(), &res)
+		return rec.Code, res
+	}
+
+	code, res := get("limit=2")
+	assert.Equal(t, code, http.StatusOK)
+	assert.Equal(t, len(res.Messages), 2)
+	assert.Equal(t, res.Messages[0].Message, "hi")
+	assert.Equal(t, res.Messages[1].Message, "bye")
+	assert.True(t, res.HasMore)
+
+	_, res = get("limit=3&before=2")
+	assert.Equal(t, len(res.Messages), 1)
+	assert.Equal(t, res.Messages[0].Message, "hello")
+	assert.Equal(t, res.Messages[0].Identity, "viewer1")
+	assert.False(t, res.HasMore)
+
+	code, _ = get("before=abc")
+	assert.Equal(t, code, http.StatusBadRequest)
+}
+
+// 기록 봇 임대: 인스턴스가 여러 개여도 룸마다 한 인스턴스만 접속, 해제되면 다른 인스턴스가 이어서 접속
+func TestChatRecorderLease(t *testing.T) {
+	lease := chat.NewMemoryLease()
+	store := chat.NewMemoryStore()
+	first := &fakeChatConnector{connected: make(chan func(*chat.Message), 1)}
+	second := &fakeChatConnector{connected: make(chan func(*chat.Message), 1)}
+	recorders := []*chat.Recorder{chat.NewRecorder(store, first, lease), chat.NewRecorder(store, second, lease)}
+	ctx := context.Background()
+	room := &livekit.Room{Name: "room-1"}
+
+	for _, recorder := range recorders {
+		assert.NoError(t, recorder.HandleWebhook(ctx, &livekit.WebhookEvent{Event: webhook.EventRoomStarted, Room: room}))
+	}
+	connected := func(connector *fakeChatConnector) bool {
+		select {
+		case <-connector.connected:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}
+	holder, other, waiting := recorders[0], recorders[1], second
+	if !connected(first) {
+		holder, other, waiting = recorders[1], recorders[0], first
+		assert.True(t, connected(second))
+	} else {
+		assert.False(t, connected(second))
+	}
+
+	// 임대를 잡은 인스턴스가 룸을 떠나면 다른 인스턴스가 다음 참가 이벤트에 접속
+	assert.NoError(t, holder.HandleWebhook(ctx, &livekit.WebhookEvent{Event: webhook.EventRoomFinished, Room: room}))
+	assert.NoError(t, other.HandleWebhook(ctx, &livekit.WebhookEvent{Event: webhook.EventParticipantJoined, Room: room, Participant: &livekit.ParticipantInfo{Identity: "viewer1"}}))
+	assert.True(t, connected(waiting))
+}
@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"backend/audit"
+	"backend/chat"
+	"backend/chatmod"
+	"backend/keyring"
+	"backend/permission"
+	"backend/policy"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+	"github.com/zeebo/assert"
+)
+
+// 예제 설정 파일이 그대로 로드되는지
+func TestChatModerationExampleConfig(t *testing.T) {
+	cfg, err := chatmod.LoadFile("../chat-moderation.example.yaml")
+	assert.NoError(t, err)
+	assert.True(t, cfg.BlockLinks)
+	assert.Equal(t, cfg.SlowMode, 3*time.Second)
+
+	path := filepath.Join(t.TempDir(), "chat-moderation.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("patterns: ['(unclosed']\n"), 0o644))
+	_, err = chatmod.NewRules(path)
+	assert.Error(t, err)
+}
+
+// 채팅 관리: 별도 봇이 룸에 접속해 검사, 금지어/링크/슬로 모드 위반은 기록에서 지우고 삭제 이벤트와 경고,
+// 반복 위반은 일시 음소거 (토큰을 갱신해도 유지) 후 자동 해제 - 회수했던 권한만 복구, 진행자가 회수한 권한은 복구하지 않음
+func TestChatModerator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat-moderation.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+blocklist: [spam, 광고]
+block_links: true
+slow_mode: 1h
+max_strikes: 3
+mute_duration: 500ms
+`), 0o644))
+	rules, err := chatmod.NewRules(path)
+	assert.NoError(t, err)
+
+	for text, want := range map[string]chatmod.Violation{
+		"buy SPAM now":         chatmod.ViolationBlockedWord,
+		"무료 광고합니다":             chatmod.ViolationBlockedWord,
+		"visit example.com/x":  chatmod.ViolationLink,
+		"https://evil.test/ok": chatmod.ViolationLink,
+	} {
+		violation, ok := rules.Check(text)
+		assert.True(t, ok)
+		assert.Equal(t, violation, want)
+	}
+	_, ok := rules.Check("first class stream")
+	assert.False(t, ok)
+
+	granted := func(canPublishData bool) *livekit.ParticipantPermission {
+		return &livekit.ParticipantPermission{CanSubscribe: true, CanPublishData: canPublishData}
+	}
+	service := &fakeRoomService{
+		participants: map[string][]*livekit.ParticipantInfo{"room-1": {
+			{Identity: "viewer1", Permission: granted(true)},
+			{Identity: "viewer2", Permission: granted(false)}, // 처음부터 채팅 권한 없음
+			{Identity: "viewer3", Permission: granted(true)},  // 음소거 중 진행자가 채팅 권한 회수
+		}},
+	}
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: "secret-secret-secret-secret-secret"})
+	assert.NoError(t, err)
+	auditLog := audit.NewLog(audit.NewMemoryStore())
+	permissions := permission.NewService(permission.NewMemoryStore())
+	lease := chat.NewMemoryLease()
+	recorderConnector := &fakeChatConnector{connected: make(chan func(*chat.Message), 1)}
+	recorder := chat.NewRecorder(chat.NewMemoryStore(), recorderConnector, lease)
+	moderatorConnector := &fakeChatConnector{connected: make(chan func(*chat.Message), 1)}
+	moderator := chatmod.NewModerator(rules, newFakeLiveKit(t, service), keys, auditLog, chatmod.NewMemoryStore(), permissions, recorder, moderatorConnector, lease)
+
+	// 기록 봇과 채팅 관리 봇이 각각 접속
+	ctx := context.Background()
+	started := &livekit.WebhookEvent{Event: webhook.EventRoomStarted, Room: &livekit.Room{Name: "room-1"}}
+	assert.NoError(t, recorder.HandleWebhook(ctx, started))
+	assert.NoError(t, moderator.HandleWebhook(ctx, started))
+	connected := func(connector *fakeChatConnector) func(*chat.Message) {
+		select {
+		case receive := <-connector.connected:
+			return receive
+		case <-time.After(time.Second):
+			t.Fatal("chat bot did not connect")
+			return nil
+		}
+	}
+	record, review := connected(recorderConnector), connected(moderatorConnector)
+	sent := 0
+	send := func(identity, role, text string) {
+		sent++
+		id := string(rune('a' + sent))
+		record(&chat.Message{ID: id, Identity: identity, Role: role, Message: text})
+		review(&chat.Message{ID: id, Identity: identity, Role: role, Message: text})
+	}
+	send("viewer1", "viewer", "hello")
+	send("viewer1", "viewer", "hello again") // 슬로 모드
+	send("host1", "host", "spam spam")       // 진행자는 검사하지 않음
+	send("viewer1", "viewer", "spam")
+	send("viewer1", "viewer", "www.example.com") // 세 번째 위반 → 음소거
+	for _, identity := range []string{"viewer2", "viewer3"} {
+		for range 3 {
+			send(identity, "viewer", "광고")
+		}
+	}
+
+	events := func() map[string]int {
+		service.mu.Lock()
+		defer service.mu.Unlock()
+		counts := map[string]int{}
+		for _, sent := range service.sent {
+			var event chatmod.Event
+			assert.NoError(t, json.Unmarshal(sent.Data, &event))
+			if event.Type == chatmod.EventWarning {
+				assert.DeepEqual(t, sent.DestinationIdentities, []string{event.Identity})
+			}
+			counts[event.Type]++
+		}
+		return counts
+	}
+	waitFor := func(cond func() bool) {
+		for deadline := time.Now().Add(2 * time.Second); !cond() && time.Now().Before(deadline); {
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.True(t, cond())
+	}
+	canPublishData := func(index int) bool {
+		service.mu.Lock()
+		defer service.mu.Unlock()
+		return service.participants["room-1"][index].Permission.CanPublishData
+	}
+	waitFor(func() bool { return events()[chatmod.EventMuted] == 3 })
+
+	// 음소거 중에는 새로 발급하는 토큰에도 데이터 전송 권한 없음
+	muted, err := permissions.Muted(ctx, "room-1", "viewer1")
+	assert.NoError(t, err)
+	assert.NotNil(t, muted)
+	assert.True(t, muted.Revoked)
+	policies, err := policy.NewEngine("")
+	assert.NoError(t, err)
+	at, err := policies.NewAccessToken("APIkey", "secret-secret-secret-secret-secret", policy.RoleViewer, "room-1", "viewer1")
+	assert.NoError(t, err)
+	assert.NoError(t, permissions.Apply(ctx, at, "room-1", "viewer1"))
+	assert.False(t, at.GetGrants().Video.GetCanPublishData())
+	assert.False(t, canPublishData(0))
+
+	// 진행자가 음소거 중인 참가자의 채팅 권한 회수
+	_, err = permissions.Update(ctx, "room-1", "viewer3", permission.Override{CanPublishData: new(bool)})
+	assert.NoError(t, err)
+
+	waitFor(func() bool { return events()[chatmod.EventUnmuted] == 3 })
+	counts := events()
+	assert.Equal(t, counts[chatmod.EventDeleted], 9)
+	assert.Equal(t, counts[chatmod.EventWarning], 9)
+	assert.True(t, canPublishData(0))
+	assert.False(t, canPublishData(1))
+	assert.False(t, canPublishData(2))
+	muted, err = permissions.Muted(ctx, "room-1", "viewer1")
+	assert.NoError(t, err)
+	assert.Nil(t, muted)
+
+	// 위반한 메시지는 기록에서 삭제
+	history, _, err := recorder.History(ctx, "room-1", 0, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, len(history), 2)
+	assert.Equal(t, history[0].Message, "hello")
+	assert.Equal(t, history[1].Message, "spam spam")
+
+	entries, err := auditLog.List(ctx, "room-1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, len(entries), 3)
+	assert.Equal(t, entries[0].Action, audit.ActionChatMute)
+	assert.Equal(t, entries[0].Actor, chatmod.ActorName)
+}
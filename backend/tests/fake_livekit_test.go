@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"backend/ban"
+	"backend/catalog"
+	"backend/handlers"
+	"backend/invite"
+	"backend/keyring"
+	"backend/lifecycle"
+	"backend/passcode"
+	"backend/permission"
+	"backend/policy"
+	"backend/roster"
+	"backend/waitlist"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/twitchtv/twirp"
+	"github.com/zeebo/assert"
+)
+
+// fakeRoomService LiveKit RoomService 대역 (구현하지 않은 메서드는 호출 시 패닉)
+type fakeRoomService struct {
+	livekit.RoomService
+
+	mu           sync.Mutex
+	rooms        []*livekit.Room
+	participants map[string][]*livekit.ParticipantInfo
+	listCalls    int                        // ListParticipants 호출 수
+	sent         []*livekit.SendDataRequest // SendData로 보낸 데이터 메시지
+	lookupErr    error                      // 설정하면 GetParticipant가 이 에러 반환 (LiveKit 장애)
+}
+
+func (f *fakeRoomService) GetParticipant(ctx context.Context, req *livekit.RoomParticipantIdentity) (*livekit.ParticipantInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lookupErr != nil {
+		return nil, f.lookupErr
+	}
+	return f.participant(req.Room, req.Identity)
+}
+
+func (f *fakeRoomService) participant(room, identity string) (*livekit.ParticipantInfo, error) {
+	for _, participant := range f.participants[room] {
+		if participant.Identity == identity {
+			return participant, nil
+		}
+	}
+	return nil, twirp.NotFoundError("participant not found")
+}
+
+func (f *fakeRoomService) MutePublishedTrack(ctx context.Context, req *livekit.MuteRoomTrackRequest) (*livekit.MuteRoomTrackResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	participant, err := f.participant(req.Room, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+	for _, track := range participant.Tracks {
+		if track.Sid == req.TrackSid {
+			track.Muted = req.Muted
+			return &livekit.MuteRoomTrackResponse{Track: track}, nil
+		}
+	}
+	return nil, errors.New("track not found")
+}
+
+func (f *fakeRoomService) RemoveParticipant(ctx context.Context, req *livekit.RoomParticipantIdentity) (*livekit.RemoveParticipantResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	participants := f.participants[req.Room]
+	for i, participant := range participants {
+		if participant.Identity == req.Identity {
+			f.participants[req.Room] = append(participants[:i:i], participants[i+1:]...)
+			return &livekit.RemoveParticipantResponse{}, nil
+		}
+	}
+	return nil, errors.New("participant not found")
+}
+
+func (f *fakeRoomService) UpdateParticipant(ctx context.Context, req *livekit.UpdateParticipantRequest) (*livekit.ParticipantInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	participant, err := f.participant(req.Room, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+	if req.Permission != nil {
+		participant.Permission = req.Permission
+	}
+	if len(req.Attributes) > 0 && participant.Attributes == nil {
+		participant.Attributes = map[string]string{}
+	}
+	for k, v := range req.Attributes {
+		participant.Attributes[k] = v
+	}
+	return participant, nil
+}
+
+func (f *fakeRoomService) SendData(ctx context.Context, req *livekit.SendDataRequest) (*livekit.SendDataResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, req)
+	return &livekit.SendDataResponse{}, nil
+}
+
+func (f *fakeRoomService) UpdateRoomMetadata(ctx context.Context, req *livekit.UpdateRoomMetadataRequest) (*livekit.Room, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, room := range f.rooms {
+		if room.Name == req.Room {
+			room.Metadata = req.Metadata
+			return room, nil
+		}
+	}
+	return nil, errors.New("room not found")
+}
+
+func (f *fakeRoomService) ListRooms(ctx context.Context, req *livekit.ListRoomsRequest) (*livekit.ListRoomsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(req.Names) == 0 {
+		return &livekit.ListRoomsResponse{Rooms: f.rooms}, nil
+	}
+	rooms := []*livekit.Room{}
+	for _, room := range f.rooms {
+		if slices.Contains(req.Names, room.Name) {
+			rooms = append(rooms, room)
+		}
+	}
+	return &livekit.ListRoomsResponse{Rooms: rooms}, nil
+}
+
+func (f *fakeRoomService) ListParticipants(ctx context.Context, req *livekit.ListParticipantsRequest) (*livekit.ListParticipantsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listCalls++
+	return &livekit.ListParticipantsResponse{Participants: f.participants[req.Room]}, nil
+}
+
+// newFakeLiveKit 대역 서버 시작 (종료는 t.Cleanup)
+func newFakeLiveKit(t *testing.T, service *fakeRoomService) string {
+	server := httptest.NewServer(livekit.NewRoomServiceServer(service))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// newTestStreamHandler 인메모리 저장소로 구성한 StreamHandler
+func newTestStreamHandler(t *testing.T, hostURL string, streams catalog.Repository) *handlers.StreamHandler {
+	return newTestStreamHandlerWith(t, hostURL, streams, invite.NewService(invite.NewMemoryStore(), "invite-secret"), ban.NewService(ban.NewMemoryStore()),
+		waitlist.NewService(waitlist.NewMemoryStore(), waitlist.DefaultConfig()))
+}
+
+// newTestStreamHandlerWith 초대 코드, 차단, 대기열을 직접 다루는 테스트용
+func newTestStreamHandlerWith(t *testing.T, hostURL string, streams catalog.Repository, invites *invite.Service, bans *ban.Service, waiting *waitlist.Service) *handlers.StreamHandler {
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: "secret-secret-secret-secret-secret"})
+	assert.NoError(t, err)
+	policies, err := policy.NewEngine("")
+	assert.NoError(t, err)
+	return handlers.NewStreamHandler(hostURL, "ws://localhost:7880", keys, policies,
+		invites,
+		passcode.NewService(passcode.NewMemoryStore(), passcode.NewMemoryThrottle(passcode.DefaultThrottleConfig())),
+		bans,
+		streams, lifecycle.NewMachine(streams), roster.NewCache(roster.DefaultConfig()),
+		waiting, permission.NewService(permission.NewMemoryStore()))
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/catalog"
+	"backend/handlers"
+	"backend/keyring"
+	"backend/lifecycle"
+	"backend/policy"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 인그레스: room_name을 지정하면 본인이 만든 진행 중인 스트림에만 연결 (다른 사람의 룸/없는 룸/종료된 스트림 거절)
+func TestCreateIngressRoomOwnership(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1"}`}},
+	}
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-1", CreatorIdentity: "host1", State: catalog.StateLive}))
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-2", CreatorIdentity: "host1", State: catalog.StateEnded}))
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: testTokenSecret})
+	assert.NoError(t, err)
+	policies, err := policy.NewEngine("")
+	assert.NoError(t, err)
+	h := handlers.NewIngressHandler(newFakeLiveKit(t, service), keys, policies, streams, lifecycle.NewMachine(streams))
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	create := func(identity, roomName string) int {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/create_ingress", strings.NewReader(`{"room_name":"`+roomName+`","ingress_type":"rtmp"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if err := account.RequireAuth(sessions)(h.CreateIngress)(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec.Code
+	}
+
+	assert.Equal(t, create("intruder", "room-1"), http.StatusForbidden)
+	assert.Equal(t, create("host1", "missing"), http.StatusNotFound)
+	assert.Equal(t, create("host1", "room-2"), http.StatusConflict)
+}
@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/authz"
+	"backend/ban"
+	"backend/catalog"
+	"backend/handlers"
+	"backend/invite"
+	"backend/keyring"
+	"backend/waitlist"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 초대 코드 발급, 사용 횟수 제한, 회수, 위변조
+func TestInviteRedeem(t *testing.T) {
+	ctx := context.Background()
+	svc := invite.NewService(invite.NewMemoryStore(), "invite-secret")
+
+	inv, code, err := svc.Mint(ctx, "room-1", "host123", time.Hour, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, inv.RoomId, "room-1")
+
+	// 확인만 하면 사용 횟수는 그대로
+	assert.NoError(t, svc.Check(ctx, code, "room-1"))
+	assert.NoError(t, svc.Check(ctx, code, "room-1"))
+	assert.Equal(t, svc.Check(ctx, code, "room-2"), invite.ErrInvalidInvite)
+
+	assert.NoError(t, svc.Redeem(ctx, code, "room-1"))
+	assert.Equal(t, svc.Redeem(ctx, code, "room-2"), invite.ErrInvalidInvite)
+	assert.NoError(t, svc.Redeem(ctx, code, "room-1"))
+	assert.Equal(t, svc.Redeem(ctx, code, "room-1"), invite.ErrInviteExhausted)
+	assert.Equal(t, svc.Check(ctx, code, "room-1"), invite.ErrInviteExhausted)
+
+	// 서명이 다른 코드는 거부
+	other := invite.NewService(invite.NewMemoryStore(), "other-secret")
+	_, forged, err := other.Mint(ctx, "room-1", "attacker", time.Hour, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, svc.Redeem(ctx, forged, "room-1"), invite.ErrInvalidInvite)
+	assert.Equal(t, svc.Redeem(ctx, "garbage", "room-1"), invite.ErrInvalidInvite)
+
+	// 회수된 초대는 사용 불가, 다른 룸의 초대는 회수 불가
+	unlimited, code2, err := svc.Mint(ctx, "room-1", "host123", time.Hour, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, svc.Revoke(ctx, "room-2", unlimited.ID), invite.ErrInviteNotFound)
+	assert.NoError(t, svc.Revoke(ctx, "room-1", unlimited.ID))
+	assert.Equal(t, svc.Redeem(ctx, code2, "room-1"), invite.ErrInviteRevoked)
+
+	// 만료된 코드
+	_, expired, err := svc.Mint(ctx, "room-1", "host123", -time.Second, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, svc.Redeem(ctx, expired, "room-1"), invite.ErrInviteExpired)
+
+	list, err := svc.List(ctx, "room-1")
+	assert.NoError(t, err)
+	assert.Equal(t, len(list), 3)
+}
+
+// 초대 코드는 토큰을 발급할 때만 사용 처리 (이미 접속 중이라 409면 차감하지 않음)
+func TestJoinStreamInviteUse(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{
+		rooms:        []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1","isPrivate":true}`}},
+		participants: map[string][]*livekit.ParticipantInfo{"room-1": {{Identity: "viewer1"}}},
+	}
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{
+		RoomId: "room-1", CreatorIdentity: "host1", Metadata: map[string]interface{}{"isPrivate": true}, State: catalog.StateLive,
+	}))
+	invites := invite.NewService(invite.NewMemoryStore(), "invite-secret")
+	_, code, err := invites.Mint(ctx, "room-1", "host1", time.Hour, 1)
+	assert.NoError(t, err)
+	h := newTestStreamHandlerWith(t, newFakeLiveKit(t, service), streams, invites, ban.NewService(ban.NewMemoryStore()),
+		waitlist.NewService(waitlist.NewMemoryStore(), waitlist.DefaultConfig()))
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	join := account.RequireAuth(sessions)(h.JoinStream)
+	post := func(identity string) int {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/join_stream", strings.NewReader(`{"room_id":"room-1","invite_code":"`+code+`"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if err := join(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec.Code
+	}
+
+	assert.Equal(t, post("viewer1"), http.StatusConflict)
+	assert.Equal(t, post("viewer1"), http.StatusConflict)
+	assert.Equal(t, post("viewer2"), http.StatusOK)
+	assert.Equal(t, post("viewer3"), http.StatusForbidden)
+}
+
+// 아직 LiveKit 룸이 없는 예약 비공개 스트림도 생성자는 초대 코드 발급, 다른 사용자는 거절
+func TestCreateInviteScheduledStream(t *testing.T) {
+	ctx := context.Background()
+	streams := catalog.NewMemoryRepository()
+	start := time.Now().Add(time.Hour)
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{
+		RoomId:          "room-1",
+		CreatorIdentity: "host1",
+		Metadata:        map[string]interface{}{"isPrivate": true},
+		State:           catalog.StateScheduled,
+		ScheduledAt:     &start,
+	}))
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: testTokenSecret})
+	assert.NoError(t, err)
+	h := handlers.NewInviteHandler(newFakeLiveKit(t, &fakeRoomService{}), keys, streams, invite.NewService(invite.NewMemoryStore(), "invite-secret"))
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	create := func(identity, roomId string) int {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("room_id")
+		c.SetParamValues(roomId)
+		if err := authz.RequireActor(sessions, authz.NewAdminKeys(nil))(h.CreateInvite)(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec.Code
+	}
+
+	assert.Equal(t, create("host1", "room-1"), http.StatusCreated)
+	assert.Equal(t, create("viewer1", "room-1"), http.StatusForbidden)
+	assert.Equal(t, create("host1", "missing"), http.StatusNotFound)
+}
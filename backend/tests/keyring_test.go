@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"backend/keyring"
+
+	"github.com/zeebo/assert"
+)
+
+// 키 파일 재로드로 primary 교체, 이전 키는 검증용으로 유지
+func TestKeyRingRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	write := func(content string) {
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	write("keys:\n  old-key: old-secret\n")
+	ring, err := keyring.New(path, keyring.KeyPair{})
+	assert.NoError(t, err)
+	assert.Equal(t, ring.Primary().Key, "old-key")
+
+	write("primary: new-key\nkeys:\n  old-key: old-secret\n  new-key: new-secret\n")
+	assert.NoError(t, ring.Reload())
+	assert.Equal(t, ring.Primary(), keyring.KeyPair{Key: "new-key", Secret: "new-secret"})
+	assert.Equal(t, ring.GetSecret("old-key"), "old-secret")
+	assert.Equal(t, ring.NumKeys(), 2)
+
+	// 잘못된 파일은 거부하고 기존 키 유지
+	write("primary: missing\nkeys:\n  new-key: new-secret\n")
+	assert.Error(t, ring.Reload())
+	assert.Equal(t, ring.Primary().Key, "new-key")
+	assert.Equal(t, ring.GetSecret("old-key"), "old-secret")
+
+	// 키 파일이 없으면 환경 변수 단일 키
+	single, err := keyring.New("", keyring.KeyPair{Key: "env-key", Secret: "env-secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, single.Keys(), []string{"env-key"})
+	_, err = keyring.New("", keyring.KeyPair{})
+	assert.Equal(t, err, keyring.ErrNoKeys)
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/catalog"
+	"backend/lifecycle"
+
+	"github.com/zeebo/assert"
+)
+
+// 예약 -> 호스트 대기 -> 송출 -> 일시정지 -> 송출 -> 종료
+func TestLifecycleTransitions(t *testing.T) {
+	ctx := context.Background()
+	repo := catalog.NewMemoryRepository()
+	machine := lifecycle.NewMachine(repo)
+
+	now := time.Now()
+	start := now.Add(time.Hour)
+	stream := &catalog.Stream{RoomId: "room-1", CreatorIdentity: "host123", CreatedAt: now, ScheduledAt: &start}
+	lifecycle.Start(stream, now)
+	assert.Equal(t, stream.CurrentState(), catalog.StateScheduled)
+	assert.NoError(t, repo.Save(ctx, stream))
+
+	steps := []struct {
+		event    lifecycle.Event
+		expected catalog.State
+		changed  bool
+	}{
+		{lifecycle.Event{Type: lifecycle.EventRoomStarted}, catalog.StateWaitingForHost, true},
+		{lifecycle.Event{Type: lifecycle.EventParticipantJoined, Identity: "viewer"}, catalog.StateWaitingForHost, false},
+		{lifecycle.Event{Type: lifecycle.EventTrackPublished, Identity: "viewer"}, catalog.StateWaitingForHost, false},
+		{lifecycle.Event{Type: lifecycle.EventParticipantJoined, Identity: "host123"}, catalog.StateWaitingForHost, false},
+		{lifecycle.Event{Type: lifecycle.EventTrackPublished, Identity: "host123"}, catalog.StateLive, true},
+		{lifecycle.Event{Type: lifecycle.EventTrackPublished, Identity: "host123"}, catalog.StateLive, false},
+		{lifecycle.Event{Type: lifecycle.EventTrackUnpublished, Identity: "host123"}, catalog.StateLive, false},
+		{lifecycle.Event{Type: lifecycle.EventTrackUnpublished, Identity: "host123"}, catalog.StatePaused, true},
+		{lifecycle.Event{Type: lifecycle.EventIngressStarted}, catalog.StateLive, true},
+		{lifecycle.Event{Type: lifecycle.EventRoomFinished}, catalog.StateEnded, true},
+		{lifecycle.Event{Type: lifecycle.EventTrackPublished, Identity: "host123"}, catalog.StateEnded, false},
+	}
+	for i, step := range steps {
+		step.event.At = now.Add(time.Duration(i+1) * time.Second)
+		got, changed, err := machine.Apply(ctx, "room-1", step.event)
+		assert.NoError(t, err)
+		assert.Equal(t, got.CurrentState(), step.expected)
+		assert.Equal(t, changed, step.changed)
+	}
+
+	stored, err := repo.Get(ctx, "room-1")
+	assert.NoError(t, err)
+	assert.That(t, stored.Ended() && stored.HostJoinedAt != nil)
+	assert.Equal(t, len(stored.StateHistory), 6)
+	assert.Equal(t, stored.StateChangedAt(), now.Add(10*time.Second))
+
+	_, _, err = machine.Apply(ctx, "missing", lifecycle.Event{Type: lifecycle.EventRoomStarted})
+	assert.Equal(t, err, catalog.ErrStreamNotFound)
+}
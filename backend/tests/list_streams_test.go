@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"backend/catalog"
+	"backend/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+func listStreams(t *testing.T, h *handlers.StreamHandler, query string) (handlers.ListStreamsResponse, int) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	err := h.ListStreams(e.NewContext(httptest.NewRequest(http.MethodGet, "/api/streams?"+query, nil), rec))
+	if he, ok := err.(*echo.HTTPError); ok {
+		return handlers.ListStreamsResponse{}, he.Code
+	}
+	assert.NoError(t, err)
+
+	var res handlers.ListStreamsResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res, rec.Code
+}
+
+// 커서 페이지네이션 (중간에 룸이 추가/삭제되어도 중복/누락 없음), 필터, 시청자 수 정렬
+func TestListStreamsPagination(t *testing.T) {
+	service := &fakeRoomService{participants: map[string][]*livekit.ParticipantInfo{}}
+	for i := 1; i <= 5; i++ {
+		kind := "live"
+		if i%2 == 0 {
+			kind = "game"
+		}
+		service.rooms = append(service.rooms, &livekit.Room{
+			Name:            fmt.Sprintf("room-%d", i),
+			Metadata:        fmt.Sprintf(`{"type":%q,"creator_identity":"host%d"}`, kind, i),
+			CreationTime:    int64(1000 + i),
+			NumParticipants: uint32(i % 3),
+		})
+	}
+	service.rooms = append(service.rooms, &livekit.Room{
+		Name: "room-private", Metadata: `{"isPrivate":true,"creator_identity":"host9"}`, CreationTime: 1010,
+	})
+	h := newTestStreamHandler(t, newFakeLiveKit(t, service), catalog.NewMemoryRepository())
+
+	first, code := listStreams(t, h, "limit=2")
+	assert.Equal(t, code, http.StatusOK)
+	assert.Equal(t, first.Total, 5)
+	assert.Equal(t, first.Rooms[0].RoomId, "room-5")
+	assert.Equal(t, first.Rooms[1].RoomId, "room-4")
+	assert.That(t, first.NextCursor != "")
+
+	// 다음 페이지 조회 전에 새 룸 추가, 이미 본 룸 삭제
+	service.rooms = append(service.rooms[:4], service.rooms[5], &livekit.Room{Name: "room-6", CreationTime: 1006})
+	second, _ := listStreams(t, h, "limit=2&cursor="+first.NextCursor)
+	assert.Equal(t, second.Rooms[0].RoomId, "room-3")
+	assert.Equal(t, second.Rooms[1].RoomId, "room-2")
+	last, _ := listStreams(t, h, "limit=2&cursor="+second.NextCursor)
+	assert.Equal(t, len(last.Rooms), 1)
+	assert.Equal(t, last.Rooms[0].RoomId, "room-1")
+	assert.Equal(t, last.NextCursor, "")
+
+	games, _ := listStreams(t, h, "type=game")
+	assert.Equal(t, games.Total, 2)
+
+	busy, _ := listStreams(t, h, "sort=viewers&min_participants=1")
+	assert.Equal(t, busy.Rooms[0].NumParticipants, uint32(2))
+	for _, room := range busy.Rooms {
+		assert.That(t, room.NumParticipants >= 1)
+	}
+
+	mine, _ := listStreams(t, h, "creator_identity=host3&state=waiting-for-host")
+	assert.Equal(t, mine.Total, 1)
+
+	_, code = listStreams(t, h, "cursor="+first.NextCursor+"&sort=viewers")
+	assert.Equal(t, code, http.StatusBadRequest)
+	_, code = listStreams(t, h, "cursor="+first.NextCursor+"&order=asc")
+	assert.Equal(t, code, http.StatusBadRequest)
+	_, code = listStreams(t, h, "state=unknown")
+	assert.Equal(t, code, http.StatusBadRequest)
+}
+
+// 상태별 인덱스: 기본 목록에 종료/예약 기록이 섞이지 않고, 종료 후 남은 룸도 기록의 상태를 따름
+func TestListStreamsStateIndex(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{rooms: []*livekit.Room{
+		{Name: "room-live", Metadata: `{"creator_identity":"host1"}`, CreationTime: 1001, NumPublishers: 1},
+		{Name: "room-ended", Metadata: `{"creator_identity":"host2"}`, CreationTime: 1002},
+	}}
+	streams := catalog.NewMemoryRepository()
+	now := time.Now()
+	start := now.Add(time.Hour)
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-live", CreatorIdentity: "host1", State: catalog.StateLive, CreatedAt: now}))
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-ended", CreatorIdentity: "host2", State: catalog.StateEnded, EndedAt: &now, CreatedAt: now}))
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-scheduled", CreatorIdentity: "host3", State: catalog.StateScheduled, ScheduledAt: &start, CreatedAt: now}))
+	h := newTestStreamHandler(t, newFakeLiveKit(t, service), streams)
+
+	ids := func(res handlers.ListStreamsResponse) []string {
+		list := []string{}
+		for _, room := range res.Rooms {
+			list = append(list, room.RoomId)
+		}
+		sort.Strings(list)
+		return list
+	}
+	res, _ := listStreams(t, h, "")
+	assert.DeepEqual(t, ids(res), []string{"room-live"})
+	res, _ = listStreams(t, h, "include_ended=true")
+	assert.DeepEqual(t, ids(res), []string{"room-ended", "room-live"})
+	res, _ = listStreams(t, h, "state=scheduled")
+	assert.DeepEqual(t, ids(res), []string{"room-scheduled"})
+}
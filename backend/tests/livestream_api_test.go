@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -64,6 +65,7 @@ func TestLiveStreamAPIFlow(t *testing.T) {
 	ingressClient := lksdk.NewIngressClient(hostURL, apiKey, apiSecret)
 
 	ingressOptions := &livekit.CreateIngressRequest{
+		InputType:           livekit.IngressInput_RTMP_INPUT,
 		Name:                roomName,
 		RoomName:            roomName,
 		ParticipantName:     "test-publisher (via OBS)",
@@ -78,7 +80,7 @@ func TestLiveStreamAPIFlow(t *testing.T) {
 		},
 	}
 
-	ingress, err := ingressClient.CreateIngress(context.Background(), livekit.IngressInput_RTMP_INPUT, ingressOptions)
+	ingress, err := ingressClient.CreateIngress(context.Background(), ingressOptions)
 	assert.NoError(t, err)
 	assert.NotNil(t, ingress)
 	t.Logf("2. Created ingress - URL: %s, StreamKey: %s", ingress.Url, ingress.StreamKey)
@@ -137,7 +139,7 @@ func TestLiveStreamAPIFlow(t *testing.T) {
 	viewerRoom.Disconnect()
 
 	// 6. 방 정리
-	deletedResp, err := roomClient.DeleteRoom(context.Background(), &livekit.DeleteRoomRequest{
+	_, err = roomClient.DeleteRoom(context.Background(), &livekit.DeleteRoomRequest{
 		Room: roomName,
 	})
 	assert.NoError(t, err)
@@ -160,21 +162,26 @@ func TestOBSSettingsValidation(t *testing.T) {
 
 	roomName := fmt.Sprintf("obs-test-room-%d", time.Now().Unix())
 	ingressOptions := &livekit.CreateIngressRequest{
+		InputType:           livekit.IngressInput_RTMP_INPUT,
 		Name:                roomName,
 		RoomName:            roomName,
 		ParticipantName:     "obs-publisher",
 		ParticipantIdentity: "obs-publisher",
 		Video: &livekit.IngressVideoOptions{
 			Source: livekit.TrackSource_CAMERA,
-			Preset: livekit.IngressVideoEncodingPreset_H264_1080P_30FPS_3_LAYERS,
+			EncodingOptions: &livekit.IngressVideoOptions_Preset{
+				Preset: livekit.IngressVideoEncodingPreset_H264_1080P_30FPS_3_LAYERS,
+			},
 		},
 		Audio: &livekit.IngressAudioOptions{
 			Source: livekit.TrackSource_MICROPHONE,
-			Preset: livekit.IngressAudioEncodingPreset_OPUS_STEREO_96KBPS,
+			EncodingOptions: &livekit.IngressAudioOptions_Preset{
+				Preset: livekit.IngressAudioEncodingPreset_OPUS_STEREO_96KBPS,
+			},
 		},
 	}
 
-	ingress, err := ingressClient.CreateIngress(context.Background(), livekit.IngressInput_RTMP_INPUT, ingressOptions)
+	ingress, err := ingressClient.CreateIngress(context.Background(), ingressOptions)
 	assert.NoError(t, err)
 
 	// OBS 설정 정보 검증
@@ -183,8 +190,8 @@ func TestOBSSettingsValidation(t *testing.T) {
 	t.Logf("Stream Key: %s", ingress.StreamKey)
 
 	// URL 형식 검증
-	assert.Contains(t, ingress.Url, "rtmp://")
-	assert.NotEmpty(t, ingress.StreamKey)
+	assert.True(t, strings.Contains(ingress.Url, "rtmp://"))
+	assert.True(t, ingress.StreamKey != "")
 	assert.True(t, len(ingress.StreamKey) > 10) // Stream Key는 충분히 긴 문자열이어야 함
 
 	// 방 정리
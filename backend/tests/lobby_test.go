@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/catalog"
+	"backend/handlers"
+	"backend/lifecycle"
+	"backend/lobby"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zeebo/assert"
+)
+
+// Last-Event-ID 이후 이벤트 재전송, 보관 범위를 벗어나면 ErrGap
+func TestLobbyHubResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := lobby.NewHub(lobby.NewMemoryBroker())
+	go hub.Run(ctx)
+	time.Sleep(10 * time.Millisecond)
+
+	first := &lobby.Event{Type: lobby.TypeStreamCreated, RoomId: "room-1"}
+	second := &lobby.Event{Type: lobby.TypeViewersChanged, RoomId: "room-1"}
+	assert.NoError(t, hub.Publish(ctx, first, nil))
+	assert.NoError(t, hub.Publish(ctx, second, handlers.ViewersChanged{NumParticipants: 2}))
+
+	sub, backlog, err := hub.Subscribe(ctx, first.ID)
+	assert.NoError(t, err)
+	defer sub.Close()
+	assert.Equal(t, len(backlog), 1)
+	assert.Equal(t, backlog[0].ID, second.ID)
+	assert.Equal(t, string(backlog[0].Data), `{"num_participants":2}`)
+
+	third := &lobby.Event{Type: lobby.TypeStreamEnded, RoomId: "room-1"}
+	assert.NoError(t, hub.Publish(ctx, third, nil))
+	select {
+	case event := <-sub.Events:
+		assert.Equal(t, event.ID, third.ID)
+	case <-time.After(time.Second):
+		t.Fatal("live event not delivered")
+	}
+
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, hub.Publish(ctx, &lobby.Event{Type: lobby.TypeViewersChanged, RoomId: "room-2"}, nil))
+	}
+	gapSub, _, err := hub.Subscribe(ctx, first.ID)
+	assert.Equal(t, err, lobby.ErrGap)
+	gapSub.Close()
+}
+
+// SSE로 생성 이벤트 수신, 비공개 스트림은 다른 사용자에게 전달하지 않음
+func TestLobbySSE(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := lobby.NewHub(lobby.NewMemoryBroker())
+	go hub.Run(ctx)
+	lobbyHandler := handlers.NewLobbyHandler(hub)
+
+	machine := lifecycle.NewMachine(catalog.NewMemoryRepository())
+	machine.OnChange(lobbyHandler.StreamChanged)
+
+	e := echo.New()
+	e.GET("/api/streams/events", lobbyHandler.StreamEvents, account.TicketFromQuery(account.NewTickets(account.NewMemoryTicketStore(), account.DefaultTicketTTL)))
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/streams/events", nil)
+	assert.NoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, res.Header.Get(echo.HeaderContentType), "text/event-stream")
+
+	now := time.Now()
+	assert.NoError(t, machine.Create(ctx, &catalog.Stream{
+		RoomId: "room-private", CreatorIdentity: "host1", CreatedAt: now,
+		Metadata: map[string]interface{}{"isPrivate": true},
+	}))
+	assert.NoError(t, machine.Create(ctx, &catalog.Stream{RoomId: "room-public", CreatorIdentity: "host2", CreatedAt: now}))
+
+	reader := bufio.NewReader(res.Body)
+	var lines []string
+	for len(lines) < 3 {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	assert.That(t, strings.HasPrefix(lines[0], "id: "))
+	assert.Equal(t, lines[1], "event: stream.created")
+	assert.That(t, strings.Contains(lines[2], `"room_id":"room-public"`))
+	assert.That(t, strings.Contains(lines[2], `"state":"waiting-for-host"`))
+}
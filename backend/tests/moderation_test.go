@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/audit"
+	"backend/authz"
+	"backend/ban"
+	"backend/catalog"
+	"backend/handlers"
+	"backend/keyring"
+	"backend/permission"
+	"backend/policy"
+	"backend/waitlist"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 참가자 관리: 생성자만 가능, 소스별 음소거, 권한 일부 변경, 내보내기, 모든 작업이 관리 기록에 남음
+func TestModerationAPI(t *testing.T) {
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1"}`}},
+		participants: map[string][]*livekit.ParticipantInfo{"room-1": {{
+			Identity: "viewer1",
+			Tracks: []*livekit.TrackInfo{
+				{Sid: "TR_mic", Source: livekit.TrackSource_MICROPHONE},
+				{Sid: "TR_cam", Source: livekit.TrackSource_CAMERA},
+			},
+			Permission: &livekit.ParticipantPermission{CanSubscribe: true, CanPublish: true, CanPublishData: true},
+		}}},
+	}
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: "secret-secret-secret-secret-secret"})
+	assert.NoError(t, err)
+	h := handlers.NewModerationHandler(newFakeLiveKit(t, service), keys, catalog.NewMemoryRepository(), audit.NewLog(audit.NewMemoryStore()), permission.NewService(permission.NewMemoryStore()))
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	requireActor := authz.RequireActor(sessions, authz.NewAdminKeys(nil))
+	call := func(handler echo.HandlerFunc, identity, method, target, body string) *httptest.ResponseRecorder {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		e := echo.New()
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("room_id", "identity")
+		c.SetParamValues("room-1", target)
+		if err := requireActor(handler)(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	assert.Equal(t, call(h.MuteParticipant, "viewer2", http.MethodPost, "viewer1", `{}`).Code, http.StatusForbidden)
+	assert.Equal(t, call(h.MuteParticipant, "host1", http.MethodPost, "nobody", `{}`).Code, http.StatusNotFound)
+
+	rec := call(h.MuteParticipant, "host1", http.MethodPost, "viewer1", `{"source":"microphone","reason":"noise"}`)
+	assert.Equal(t, rec.Code, http.StatusOK)
+	var muted handlers.MuteParticipantResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &muted))
+	assert.DeepEqual(t, muted.Tracks, []string{"TR_mic"})
+	assert.True(t, service.participants["room-1"][0].Tracks[0].Muted)
+	assert.False(t, service.participants["room-1"][0].Tracks[1].Muted)
+
+	rec = call(h.UpdatePermissions, "host1", http.MethodPatch, "viewer1", `{"can_publish_data":false}`)
+	assert.Equal(t, rec.Code, http.StatusOK)
+	permission := service.participants["room-1"][0].Permission
+	assert.False(t, permission.CanPublishData)
+	assert.True(t, permission.CanPublish)
+
+	assert.Equal(t, call(h.RemoveParticipant, "host1", http.MethodDelete, "viewer1", "").Code, http.StatusOK)
+	assert.Equal(t, len(service.participants["room-1"]), 0)
+
+	rec = call(h.ListAudit, "host1", http.MethodGet, "", "")
+	assert.Equal(t, rec.Code, http.StatusOK)
+	var entries handlers.ListAuditResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+	assert.Equal(t, entries.Total, 3)
+	assert.Equal(t, entries.Entries[0].Action, audit.ActionRemove)
+	assert.Equal(t, entries.Entries[2].Action, audit.ActionMute)
+	assert.Equal(t, entries.Entries[2].Actor, "host1")
+	assert.Equal(t, entries.Entries[2].Reason, "noise")
+}
+
+// 권한 회수: 토큰을 갱신해도 회수한 권한은 돌아오지 않음
+func TestPermissionOverrideRefresh(t *testing.T) {
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1"}`}},
+		participants: map[string][]*livekit.ParticipantInfo{"room-1": {{
+			Identity:   "viewer1",
+			Permission: &livekit.ParticipantPermission{CanSubscribe: true, CanPublishData: true},
+		}}},
+	}
+	hostURL := newFakeLiveKit(t, service)
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: "secret-secret-secret-secret-secret"})
+	assert.NoError(t, err)
+	policies, err := policy.NewEngine("")
+	assert.NoError(t, err)
+	permissions := permission.NewService(permission.NewMemoryStore())
+	moderation := handlers.NewModerationHandler(hostURL, keys, catalog.NewMemoryRepository(), audit.NewLog(audit.NewMemoryStore()), permissions)
+	tokens := handlers.NewTokenHandler(hostURL, keys, policies, account.NewService(account.NewMemoryStore(), nil), ban.NewService(ban.NewMemoryStore()), catalog.NewMemoryRepository(), waitlist.NewService(waitlist.NewMemoryStore(), waitlist.DefaultConfig()), permissions)
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	call := func(handler echo.HandlerFunc, identity, method, body string) *httptest.ResponseRecorder {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		e := echo.New()
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("room_id", "identity")
+		c.SetParamValues("room-1", "viewer1")
+		if err := authz.RequireActor(sessions, authz.NewAdminKeys(nil))(handler)(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	at, err := policies.NewAccessToken("APIkey", "secret-secret-secret-secret-secret", policy.RoleViewer, "room-1", "viewer1")
+	assert.NoError(t, err)
+	token, err := at.ToJWT()
+	assert.NoError(t, err)
+
+	assert.Equal(t, call(moderation.UpdatePermissions, "host1", http.MethodPatch, `{"can_publish_data":false}`).Code, http.StatusOK)
+
+	rec := call(tokens.RefreshToken, "viewer1", http.MethodPost, `{"token":"`+token+`"}`)
+	assert.Equal(t, rec.Code, http.StatusOK)
+	var refreshed handlers.RefreshTokenResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &refreshed))
+	verifier, err := auth.ParseAPIToken(refreshed.Token)
+	assert.NoError(t, err)
+	claims, err := verifier.Verify("secret-secret-secret-secret-secret")
+	assert.NoError(t, err)
+	assert.False(t, claims.Video.GetCanPublishData())
+	assert.True(t, claims.Video.GetCanSubscribe())
+}
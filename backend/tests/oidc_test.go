@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"backend/oidc"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/zeebo/assert"
+)
+
+// mockIdP discovery, JWKS, token 엔드포인트를 제공하는 로컬 IdP
+type mockIdP struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	nonce     string
+	jwksHits  int
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	idp := &mockIdP{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.jwksHits++
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "test-key", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		// PKCE 검증: S256(code_verifier) == code_challenge
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "good-code" || base64.RawURLEncoding.EncodeToString(sum[:]) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idp.idToken(t, idp.nonce)})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *mockIdP) idToken(t *testing.T, nonce string) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: idp.key},
+		(&jose.SignerOptions{}).WithHeader("kid", "test-key"))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   idp.server.URL,
+		Subject:  "user-42",
+		Audience: jwt.Audience{"livekit-backend"},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(5 * time.Minute)),
+	}).Claims(map[string]interface{}{
+		"nonce":              nonce,
+		"preferred_username": "alice",
+		"name":               "Alice Kim",
+		"realm_access":       map[string]interface{}{"roles": []string{"offline_access", "livekit-moderator"}},
+	}).CompactSerialize()
+	assert.NoError(t, err)
+	return token
+}
+
+// PKCE 인가 코드 흐름 + 클레임 매핑
+func TestOIDCAuthorizationCodeFlow(t *testing.T) {
+	idp := newMockIdP(t)
+	provider := oidc.NewProvider("keycloak", oidc.ProviderConfig{
+		Issuer:      idp.server.URL,
+		ClientID:    "livekit-backend",
+		RedirectURL: "http://localhost:8080/api/auth/oidc/keycloak/callback",
+		Claims:      oidc.ClaimMapping{Identity: "preferred_username", Roles: "realm_access.roles"},
+		RoleMapping: map[string]string{"livekit-moderator": "moderator"},
+	}, nil)
+	ctx := context.Background()
+
+	state, ls, challenge := oidc.NewLoginState("keycloak")
+	authURL, err := provider.AuthCodeURL(ctx, state, ls.Nonce, challenge)
+	assert.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, u.Query().Get("code_challenge_method"), "S256")
+	assert.Equal(t, u.Query().Get("state"), state)
+	idp.challenge = u.Query().Get("code_challenge")
+	idp.nonce = u.Query().Get("nonce")
+
+	// 잘못된 verifier는 IdP에서 거부
+	_, err = provider.Exchange(ctx, "good-code", "wrong-verifier", ls.Nonce)
+	assert.Error(t, err)
+
+	claims, err := provider.Exchange(ctx, "good-code", ls.CodeVerifier, ls.Nonce)
+	assert.NoError(t, err)
+
+	principal, err := provider.Principal(claims)
+	assert.NoError(t, err)
+	assert.Equal(t, principal.Identity, "keycloak:alice")
+	assert.Equal(t, principal.DisplayName, "Alice Kim")
+	assert.Equal(t, principal.Role, "moderator")
+	assert.Equal(t, principal.UserID, "keycloak:user-42")
+
+	// nonce 불일치 토큰 거부, JWKS는 캐시 사용
+	_, err = provider.VerifyIDToken(ctx, idp.idToken(t, "other-nonce"), ls.Nonce)
+	assert.Equal(t, err, oidc.ErrNonceMismatch)
+	assert.Equal(t, idp.jwksHits, 1)
+}
+
+// state는 한 번만 사용 가능
+func TestOIDCStateSingleUse(t *testing.T) {
+	store := oidc.NewMemoryStateStore()
+	state, ls, _ := oidc.NewLoginState("keycloak")
+	store.Put(state, ls)
+
+	got, ok := store.Take(state)
+	assert.True(t, ok)
+	assert.Equal(t, got.CodeVerifier, ls.CodeVerifier)
+
+	_, ok = store.Take(state)
+	assert.False(t, ok)
+}
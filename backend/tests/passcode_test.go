@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/passcode"
+
+	"github.com/zeebo/assert"
+)
+
+// 룸 PIN 설정, 확인, 실패 횟수 초과 시 잠금
+func TestPasscodeLockout(t *testing.T) {
+	ctx := context.Background()
+	svc := passcode.NewService(passcode.NewMemoryStore(), passcode.NewMemoryThrottle(passcode.ThrottleConfig{
+		MaxAttempts: 3,
+		Window:      time.Minute,
+		Lockout:     time.Minute,
+	}))
+
+	assert.Equal(t, svc.Set(ctx, "room-1", passcode.KindPIN, "12ab"), passcode.ErrInvalidPIN)
+	assert.NoError(t, svc.Set(ctx, "room-1", passcode.KindPIN, "4829"))
+
+	// 보호되지 않은 룸은 통과
+	assert.NoError(t, svc.Verify(ctx, "room-2", "", "id:viewer"))
+
+	assert.Equal(t, svc.Verify(ctx, "room-1", "", "id:viewer"), passcode.ErrRequired)
+	assert.NoError(t, svc.Verify(ctx, "room-1", "4829", "id:viewer"))
+	assert.NoError(t, svc.VerifyPIN(ctx, "room-1", "4829", "sip:+821012345678"))
+
+	assert.Equal(t, svc.Verify(ctx, "room-1", "0000", "id:viewer"), passcode.ErrWrongPasscode)
+	assert.Equal(t, svc.Verify(ctx, "room-1", "0000", "id:viewer"), passcode.ErrWrongPasscode)
+
+	// 세 번째 실패에서 잠기고, 잠긴 동안은 올바른 PIN도 거부
+	var locked *passcode.LockedError
+	assert.That(t, errors.As(svc.Verify(ctx, "room-1", "0000", "id:viewer"), &locked))
+	assert.That(t, locked.RetryAfter > 0)
+	assert.That(t, errors.As(svc.Verify(ctx, "room-1", "4829", "id:viewer"), &locked))
+
+	// 다른 참가자는 영향 없음
+	assert.NoError(t, svc.Verify(ctx, "room-1", "4829", "id:other"))
+
+	assert.NoError(t, svc.Clear(ctx, "room-1"))
+	_, ok, err := svc.Protection(ctx, "room-1")
+	assert.NoError(t, err)
+	assert.That(t, !ok)
+}
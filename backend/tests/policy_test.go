@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"backend/policy"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 기본 정책: 시청자는 발행 불가, 호스트는 발행 가능
+func TestDefaultPolicyGrants(t *testing.T) {
+	engine, err := policy.NewEngine("")
+	assert.NoError(t, err)
+
+	viewer, err := engine.Grant(policy.RoleViewer, "room-1")
+	assert.NoError(t, err)
+	assert.False(t, viewer.GetCanPublish())
+	assert.True(t, viewer.GetCanSubscribe())
+	assert.True(t, viewer.GetCanPublishData())
+
+	host, err := engine.Grant(policy.RoleHost, "room-1")
+	assert.NoError(t, err)
+	assert.True(t, host.GetCanPublish())
+	assert.Equal(t, host.Room, "room-1")
+
+	_, err = engine.Grant(policy.Role("unknown"), "room-1")
+	assert.Error(t, err)
+}
+
+// 정책 파일 로드 및 재로드
+func TestPolicyFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+roles:
+  viewer:
+    can_publish: true
+    can_subscribe: true
+    can_publish_sources: [microphone]
+    ttl: 10m
+    attributes:
+      tier: free
+`), 0o644))
+
+	engine, err := policy.NewEngine(path)
+	assert.NoError(t, err)
+
+	apiKey, apiSecret := "devkey", "secretsecretsecretsecretsecretsecret"
+	at, err := engine.NewAccessToken(apiKey, apiSecret, policy.RoleViewer, "room-1", "viewer-1")
+	assert.NoError(t, err)
+	token, err := at.ToJWT()
+	assert.NoError(t, err)
+
+	verifier, err := auth.ParseAPIToken(token)
+	assert.NoError(t, err)
+	claims, err := verifier.Verify(apiSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, claims.Identity, "viewer-1")
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], "viewer")
+	assert.Equal(t, claims.Attributes["tier"], "free")
+	assert.True(t, claims.Video.GetCanPublishSource(livekit.TrackSource_MICROPHONE))
+	assert.False(t, claims.Video.GetCanPublishSource(livekit.TrackSource_CAMERA))
+
+	// 파일에 없는 역할은 기본 정책 유지
+	host, err := engine.Lookup(policy.RoleHost)
+	assert.NoError(t, err)
+	assert.Equal(t, host.TTL, time.Hour)
+
+	// 잘못된 소스는 재로드 실패, 기존 정책 유지
+	assert.NoError(t, os.WriteFile(path, []byte("roles:\n  viewer:\n    can_publish_sources: [hologram]\n"), 0o644))
+	assert.Error(t, engine.Reload())
+	viewer, err := engine.Lookup(policy.RoleViewer)
+	assert.NoError(t, err)
+	assert.True(t, viewer.CanPublish)
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/ratelimit"
+
+	"github.com/labstack/echo/v4"
+	"github.com/zeebo/assert"
+)
+
+// 토큰 버킷 소진 후 거부, 키별 독립
+func TestMemoryLimiter(t *testing.T) {
+	ctx := context.Background()
+	limiter := ratelimit.NewMemoryLimiter()
+	limit := ratelimit.PerMinute(60, 2)
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := limiter.Allow(ctx, "ip:1", limit)
+		assert.NoError(t, err)
+		assert.That(t, allowed)
+	}
+	allowed, wait, err := limiter.Allow(ctx, "ip:1", limit)
+	assert.NoError(t, err)
+	assert.That(t, !allowed)
+	assert.That(t, wait > 0)
+
+	allowed, _, err = limiter.Allow(ctx, "ip:2", limit)
+	assert.NoError(t, err)
+	assert.That(t, allowed)
+}
+
+// 룸 단위 제한 초과 시 429 + Retry-After, 핸들러는 본문을 그대로 읽음
+func TestRateLimitMiddleware(t *testing.T) {
+	e := echo.New()
+	mw := ratelimit.Middleware(ratelimit.NewMemoryLimiter(), ratelimit.Policy{
+		Name:    "join_stream",
+		PerRoom: ratelimit.PerHour(1, 1),
+		Room:    ratelimit.RoomFromJSON("room_id"),
+	})
+	handler := mw(func(c echo.Context) error {
+		var req struct {
+			RoomId string `json:"room_id"`
+		}
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, req.RoomId)
+	})
+
+	call := func(room string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, "/api/join_stream", strings.NewReader(`{"room_id":"`+room+`"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		return rec, handler(e.NewContext(req, rec))
+	}
+
+	rec, err := call("room-1")
+	assert.NoError(t, err)
+	assert.Equal(t, rec.Body.String(), "room-1")
+
+	rec, err = call("room-1")
+	httpErr, ok := err.(*echo.HTTPError)
+	assert.That(t, ok)
+	assert.Equal(t, httpErr.Code, http.StatusTooManyRequests)
+	assert.Equal(t, rec.Header().Get(echo.HeaderRetryAfter), "3600")
+
+	_, err = call("room-2")
+	assert.NoError(t, err)
+}
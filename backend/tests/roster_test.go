@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"backend/roster"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 동시 조회 수 제한, 실패/시간 초과 룸 보고, 스냅샷 재사용과 웹훅 무효화
+func TestRosterCollect(t *testing.T) {
+	ctx := context.Background()
+	cache := roster.NewCache(roster.Config{TTL: time.Minute, Concurrency: 2, Timeout: 200 * time.Millisecond})
+
+	var running, peak, calls int32
+	fetch := func(ctx context.Context, roomId string) ([]*livekit.ParticipantInfo, error) {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		switch roomId {
+		case "room-broken":
+			return nil, errors.New("unavailable")
+		case "room-slow":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		time.Sleep(10 * time.Millisecond)
+		return []*livekit.ParticipantInfo{{Identity: "viewer-" + roomId}}, nil
+	}
+
+	var rooms []string
+	for i := 0; i < 6; i++ {
+		rooms = append(rooms, fmt.Sprintf("room-%d", i))
+	}
+	result, failed := cache.Collect(ctx, append(rooms, "room-broken", "room-slow"), fetch)
+	assert.Equal(t, len(result), 6)
+	assert.Equal(t, result["room-3"][0].Identity, "viewer-room-3")
+	assert.Equal(t, len(failed), 2)
+	assert.That(t, atomic.LoadInt32(&peak) <= 2)
+
+	// 캐시된 룸은 다시 조회하지 않음, 무효화된 룸만 조회
+	atomic.StoreInt32(&calls, 0)
+	assert.NoError(t, cache.HandleWebhook(ctx, &livekit.WebhookEvent{Event: "participant_joined", Room: &livekit.Room{Name: "room-1"}}))
+	result, failed = cache.Collect(ctx, rooms, fetch)
+	assert.Equal(t, len(result), 6)
+	assert.Equal(t, len(failed), 0)
+	assert.Equal(t, atomic.LoadInt32(&calls), int32(1))
+}
+
+// 동시 조회 차례를 기다리다 제한 시간이 지난 룸은 빈 룸이 아니라 실패로 보고하고 캐시하지 않음
+func TestRosterCollectQueuedTimeout(t *testing.T) {
+	ctx := context.Background()
+	cache := roster.NewCache(roster.Config{TTL: time.Minute, Concurrency: 1, Timeout: 50 * time.Millisecond})
+
+	slow := func(ctx context.Context, roomId string) ([]*livekit.ParticipantInfo, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	result, failed := cache.Collect(ctx, []string{"room-1", "room-2", "room-3"}, slow)
+	assert.Equal(t, len(result), 0)
+	assert.Equal(t, len(failed), 3)
+
+	// 실패한 룸은 다음 요청에서 다시 조회
+	fetch := func(ctx context.Context, roomId string) ([]*livekit.ParticipantInfo, error) {
+		return []*livekit.ParticipantInfo{{Identity: "viewer-" + roomId}}, nil
+	}
+	result, failed = cache.Collect(ctx, []string{"room-1", "room-2", "room-3"}, fetch)
+	assert.Equal(t, len(result), 3)
+	assert.Equal(t, len(failed), 0)
+}
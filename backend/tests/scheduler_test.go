@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/catalog"
+	"backend/lifecycle"
+	"backend/scheduler"
+
+	"github.com/zeebo/assert"
+)
+
+type fakeProvisioner struct {
+	provisioned map[string]bool
+	hostPresent map[string]bool
+	tornDown    map[string]bool
+}
+
+func (p *fakeProvisioner) Provision(ctx context.Context, stream *catalog.Stream, emptyTimeout time.Duration) (*catalog.Ingress, error) {
+	p.provisioned[stream.RoomId] = true
+	if stream.Ingress != nil {
+		return &catalog.Ingress{Type: stream.Ingress.Type, IngressId: "IN_" + stream.RoomId, StreamKey: "key"}, nil
+	}
+	return nil, nil
+}
+
+func (p *fakeProvisioner) HostPresent(ctx context.Context, stream *catalog.Stream) (bool, error) {
+	return p.hostPresent[stream.RoomId], nil
+}
+
+func (p *fakeProvisioner) Teardown(ctx context.Context, stream *catalog.Stream) error {
+	p.tornDown[stream.RoomId] = true
+	return nil
+}
+
+// 시작 전 룸 생성, 호스트 접속 기록, 호스트 미접속 시 no-show 처리
+func TestSchedulerTick(t *testing.T) {
+	ctx := context.Background()
+	repo := catalog.NewMemoryRepository()
+	provisioner := &fakeProvisioner{
+		provisioned: map[string]bool{},
+		hostPresent: map[string]bool{},
+		tornDown:    map[string]bool{},
+	}
+	cfg := scheduler.DefaultConfig()
+	s := scheduler.New(repo, lifecycle.NewMachine(repo), provisioner, nil, cfg)
+
+	start := time.Now().Add(time.Hour)
+	for _, id := range []string{"room-a", "room-b"} {
+		stream := &catalog.Stream{RoomId: id, CreatorIdentity: "host123", CreatedAt: time.Now(), ScheduledAt: &start}
+		if id == "room-b" {
+			stream.Ingress = &catalog.Ingress{Type: "rtmp"}
+		}
+		assert.NoError(t, repo.Save(ctx, stream))
+	}
+
+	// 아직 이르면 아무것도 하지 않음
+	assert.NoError(t, s.Tick(ctx, start.Add(-cfg.Lead-time.Minute)))
+	assert.Equal(t, len(provisioner.provisioned), 0)
+
+	// 시작 Lead 전부터 룸 생성
+	assert.NoError(t, s.Tick(ctx, start.Add(-cfg.Lead)))
+	assert.That(t, provisioner.provisioned["room-a"] && provisioner.provisioned["room-b"])
+	b, err := repo.Get(ctx, "room-b")
+	assert.NoError(t, err)
+	assert.Equal(t, b.Ingress.IngressId, "IN_room-b")
+	assert.That(t, !b.Scheduled())
+
+	// room-a 호스트 접속, room-b는 no-show
+	provisioner.hostPresent["room-a"] = true
+	assert.NoError(t, s.Tick(ctx, start.Add(time.Minute)))
+	assert.NoError(t, s.Tick(ctx, start.Add(cfg.NoShowAfter+time.Minute)))
+
+	a, err := repo.Get(ctx, "room-a")
+	assert.NoError(t, err)
+	assert.That(t, a.HostJoinedAt != nil && !a.Ended())
+
+	b, err = repo.Get(ctx, "room-b")
+	assert.NoError(t, err)
+	assert.That(t, b.NoShow && b.Ended())
+	assert.That(t, provisioner.tornDown["room-b"] && !provisioner.tornDown["room-a"])
+}
+
+// 스케줄러 대상 조회: 호스트가 접속했거나 종료된 스트림, Lead 이후에 시작하는 스트림은 제외하고 시작 시각순
+func TestCatalogListAwaitingHost(t *testing.T) {
+	ctx := context.Background()
+	repo := catalog.NewMemoryRepository()
+	now := time.Now()
+	soon, later, far := now.Add(time.Minute), now.Add(3*time.Minute), now.Add(time.Hour)
+	for _, stream := range []*catalog.Stream{
+		{RoomId: "room-later", ScheduledAt: &later},
+		{RoomId: "room-soon", ScheduledAt: &soon},
+		{RoomId: "room-far", ScheduledAt: &far},
+		{RoomId: "room-joined", ScheduledAt: &soon, HostJoinedAt: &now},
+		{RoomId: "room-ended", ScheduledAt: &soon, EndedAt: &now},
+		{RoomId: "room-now"},
+	} {
+		stream.CreatedAt = now
+		assert.NoError(t, repo.Save(ctx, stream))
+	}
+
+	list, err := repo.ListAwaitingHost(ctx, now.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, len(list), 2)
+	assert.Equal(t, list[0].RoomId, "room-soon")
+	assert.Equal(t, list[1].RoomId, "room-later")
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/catalog"
+	"backend/search"
+
+	"github.com/zeebo/assert"
+)
+
+func searchIDs(t *testing.T, index *search.Index, text string) []string {
+	hits, _, err := index.Search(context.Background(), text, 10, func(doc *search.Document) bool {
+		return !doc.Private && !doc.Ended
+	})
+	assert.NoError(t, err)
+	ids := []string{}
+	for _, hit := range hits {
+		ids = append(ids, hit.RoomId)
+	}
+	return ids
+}
+
+// 한국어 조사/붙여쓰기, 접두어, 오타 허용, 제목 가중치, 비공개/종료 제외
+func TestSearchIndex(t *testing.T) {
+	ctx := context.Background()
+	repo := catalog.NewMemoryRepository()
+	now := time.Now()
+	end := now
+	for _, stream := range []*catalog.Stream{
+		{RoomId: "room-ko", Metadata: map[string]interface{}{"title": "금요일 저녁 방송을 합니다", "description": "같이 이야기해요"}},
+		{RoomId: "room-en", Metadata: map[string]interface{}{"title": "Minecraft building stream", "tags": []interface{}{"gaming"}}},
+		{RoomId: "room-desc", Metadata: map[string]interface{}{"title": "Chill music", "description": "minecraft soundtrack"}},
+		{RoomId: "room-private", Metadata: map[string]interface{}{"title": "Minecraft private", "isPrivate": true}},
+		{RoomId: "room-ended", Metadata: map[string]interface{}{"title": "Minecraft finale"}, EndedAt: &end},
+	} {
+		stream.CreatedAt = now
+		assert.NoError(t, repo.Save(ctx, stream))
+	}
+
+	index, err := search.NewIndex()
+	assert.NoError(t, err)
+	assert.NoError(t, index.Rebuild(ctx, repo))
+
+	assert.DeepEqual(t, searchIDs(t, index, "방송"), []string{"room-ko"})
+	assert.DeepEqual(t, searchIDs(t, index, "금요일저녁"), []string{"room-ko"})
+	assert.DeepEqual(t, searchIDs(t, index, "minecraft"), []string{"room-en", "room-desc"})
+	assert.DeepEqual(t, searchIDs(t, index, "minecrfat"), []string{"room-en", "room-desc"})
+	assert.DeepEqual(t, searchIDs(t, index, "build"), []string{"room-en"})
+	assert.DeepEqual(t, searchIDs(t, index, "gaming"), []string{"room-en"})
+	assert.DeepEqual(t, searchIDs(t, index, "   "), []string{})
+
+	// 메타데이터 변경 반영
+	assert.NoError(t, index.Put(search.FromStream(&catalog.Stream{
+		RoomId: "room-desc", Metadata: map[string]interface{}{"title": "Chill music"},
+	})))
+	assert.DeepEqual(t, searchIDs(t, index, "minecraft"), []string{"room-en"})
+
+	// 다시 채우면 카탈로그에 없는 문서는 제거
+	other := catalog.NewMemoryRepository()
+	assert.NoError(t, other.Save(ctx, &catalog.Stream{RoomId: "room-ko", Metadata: map[string]interface{}{"title": "금요일 저녁 방송을 합니다"}, CreatedAt: now}))
+	assert.NoError(t, index.Rebuild(ctx, other))
+	assert.DeepEqual(t, searchIDs(t, index, "minecraft"), []string{})
+	assert.DeepEqual(t, searchIDs(t, index, "방송"), []string{"room-ko"})
+}
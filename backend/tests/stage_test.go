@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/audit"
+	"backend/authz"
+	"backend/catalog"
+	"backend/handlers"
+	"backend/keyring"
+	"backend/policy"
+	"backend/stage"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 무대: 접속한 시청자만 손들기, 생성자만 승인, 승인 시 발언자 권한, 강등 시 구독 전용, 이벤트는 룸 전체에 전송
+func TestStageWorkflow(t *testing.T) {
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1"}`}},
+		participants: map[string][]*livekit.ParticipantInfo{"room-1": {{
+			Identity:   "viewer1",
+			Name:       "Viewer One",
+			Permission: &livekit.ParticipantPermission{CanSubscribe: true, CanPublishData: true},
+			Attributes: map[string]string{policy.AttributeRole: string(policy.RoleViewer)},
+		}}},
+	}
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: "secret-secret-secret-secret-secret"})
+	assert.NoError(t, err)
+	policies, err := policy.NewEngine("")
+	assert.NoError(t, err)
+	auditLog := audit.NewLog(audit.NewMemoryStore())
+	h := handlers.NewStageHandler(newFakeLiveKit(t, service), keys, policies, catalog.NewMemoryRepository(), stage.NewQueue(stage.NewMemoryStore()), auditLog)
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	requireActor := authz.RequireActor(sessions, authz.NewAdminKeys(nil))
+	call := func(handler echo.HandlerFunc, identity, target, body string) *httptest.ResponseRecorder {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("room_id", "identity")
+		c.SetParamValues("room-1", target)
+		if err := requireActor(handler)(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	// 룸에 없는 사용자는 손들 수 없음
+	assert.Equal(t, call(h.RaiseHand, "viewer2", "", `{}`).Code, http.StatusConflict)
+	assert.Equal(t, call(h.RaiseHand, "viewer1", "", `{"message":"question"}`).Code, http.StatusCreated)
+	assert.Equal(t, call(h.RaiseHand, "viewer1", "", `{}`).Code, http.StatusOK)
+
+	assert.Equal(t, call(h.ListRequests, "viewer1", "", "").Code, http.StatusForbidden)
+	rec := call(h.ListRequests, "host1", "", "")
+	assert.Equal(t, rec.Code, http.StatusOK)
+	var list handlers.ListStageRequestsResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Equal(t, list.Total, 1)
+	assert.Equal(t, list.Requests[0].Name, "Viewer One")
+	assert.Equal(t, list.Requests[0].Message, "question")
+
+	assert.Equal(t, call(h.ApproveRequest, "viewer1", "viewer1", "").Code, http.StatusForbidden)
+	assert.Equal(t, call(h.ApproveRequest, "host1", "viewer1", "").Code, http.StatusOK)
+	viewer := service.participants["room-1"][0]
+	assert.True(t, viewer.Permission.CanPublish)
+	assert.Equal(t, len(viewer.Permission.CanPublishSources), 2)
+	assert.Equal(t, viewer.Attributes[policy.AttributeRole], string(policy.RoleSpeaker))
+	assert.Equal(t, call(h.ApproveRequest, "host1", "viewer1", "").Code, http.StatusNotFound)
+	assert.Equal(t, call(h.RaiseHand, "viewer1", "", `{}`).Code, http.StatusConflict)
+
+	// 본인도 무대에서 내려올 수 있음
+	assert.Equal(t, call(h.Demote, "viewer1", "viewer1", "").Code, http.StatusOK)
+	assert.False(t, viewer.Permission.CanPublish)
+	assert.True(t, viewer.Permission.CanSubscribe)
+	assert.Equal(t, viewer.Attributes[policy.AttributeRole], string(policy.RoleViewer))
+
+	assert.Equal(t, call(h.RaiseHand, "viewer1", "", `{}`).Code, http.StatusCreated)
+	assert.Equal(t, call(h.DenyRequest, "host1", "viewer1", "").Code, http.StatusOK)
+
+	var types []string
+	for _, sent := range service.sent {
+		assert.Equal(t, sent.GetTopic(), handlers.StageTopic)
+		assert.Equal(t, sent.Kind, livekit.DataPacket_RELIABLE)
+		var event handlers.StageEvent
+		assert.NoError(t, json.Unmarshal(sent.Data, &event))
+		types = append(types, event.Type)
+	}
+	assert.DeepEqual(t, types, []string{
+		handlers.StageHandRaised, handlers.StageApproved, handlers.StageDemoted,
+		handlers.StageHandRaised, handlers.StageDenied,
+	})
+
+	entries, err := auditLog.List(t.Context(), "room-1", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, len(entries), 3)
+	assert.Equal(t, entries[0].Action, audit.ActionDeny)
+	assert.Equal(t, entries[2].Action, audit.ActionPromote)
+}
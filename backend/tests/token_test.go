@@ -0,0 +1,273 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/ban"
+	"backend/catalog"
+	"backend/handlers"
+	"backend/keyring"
+	"backend/permission"
+	"backend/policy"
+	"backend/waitlist"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+const testTokenSecret = "secret-secret-secret-secret-secret"
+
+// tokenRefresher 토큰 갱신 테스트 대역 (LiveKit 대역 서버, 인메모리 저장소)
+type tokenRefresher struct {
+	t        *testing.T
+	handler  *handlers.TokenHandler
+	policies *policy.Engine
+	sessions *account.SessionIssuer
+	users    *account.MemoryStore
+	waiting  *waitlist.Service
+}
+
+func newTokenRefresher(t *testing.T, service *fakeRoomService, streams catalog.Repository) *tokenRefresher {
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: testTokenSecret})
+	assert.NoError(t, err)
+	policies, err := policy.NewEngine("")
+	assert.NoError(t, err)
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	users := account.NewMemoryStore()
+	waiting := waitlist.NewService(waitlist.NewMemoryStore(), waitlist.Config{AdmissionTTL: 30 * time.Second})
+	return &tokenRefresher{
+		t:        t,
+		handler:  handlers.NewTokenHandler(newFakeLiveKit(t, service), keys, policies, account.NewService(users, sessions), ban.NewService(ban.NewMemoryStore()), streams, waiting, permission.NewService(permission.NewMemoryStore())),
+		policies: policies,
+		sessions: sessions,
+		users:    users,
+		waiting:  waiting,
+	}
+}
+
+// token 역할 정책으로 LiveKit 토큰 발급
+func (r *tokenRefresher) token(role policy.Role, room, identity string) string {
+	at, err := r.policies.NewAccessToken("APIkey", testTokenSecret, role, room, identity)
+	assert.NoError(r.t, err)
+	token, err := at.ToJWT()
+	assert.NoError(r.t, err)
+	return token
+}
+
+// refresh principal 세션으로 토큰 갱신 요청
+func (r *tokenRefresher) refresh(principal *account.Principal, token string) (*httptest.ResponseRecorder, *auth.ClaimGrants) {
+	session, err := r.sessions.Issue(principal)
+	assert.NoError(r.t, err)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/token/refresh", strings.NewReader(`{"token":"`+token+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if err := account.RequireAuth(r.sessions)(r.handler.RefreshToken)(c); err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+	if rec.Code != http.StatusOK {
+		return rec, nil
+	}
+	var res handlers.RefreshTokenResponse
+	assert.NoError(r.t, json.Unmarshal(rec.Body.Bytes(), &res))
+	verifier, err := auth.ParseAPIToken(res.Token)
+	assert.NoError(r.t, err)
+	claims, err := verifier.Verify(testTokenSecret)
+	assert.NoError(r.t, err)
+	return rec, claims
+}
+
+// join principal 세션으로 /getToken 회의 토큰 요청
+func (r *tokenRefresher) join(principal *account.Principal, room string) *httptest.ResponseRecorder {
+	session, err := r.sessions.Issue(principal)
+	assert.NoError(r.t, err)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/getToken?room="+room, nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if err := account.RequireAuth(r.sessions)(r.handler.GetToken)(c); err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+	return rec
+}
+
+// 회의 토큰: 카탈로그에 없는 회의 룸만 발언자 토큰 발급, 스트림은 join_stream으로 안내
+func TestGetTokenRejectsStreams(t *testing.T) {
+	ctx := context.Background()
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-1", CreatorIdentity: "host1", State: catalog.StateLive}))
+	r := newTokenRefresher(t, &fakeRoomService{}, streams)
+	user := &account.Principal{UserID: "u1", Identity: "user1"}
+
+	rec := r.join(user, "my-room")
+	assert.Equal(t, rec.Code, http.StatusOK)
+	verifier, err := auth.ParseAPIToken(rec.Body.String())
+	assert.NoError(t, err)
+	claims, err := verifier.Verify(testTokenSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleSpeaker))
+
+	rec = r.join(user, "room-1")
+	assert.Equal(t, rec.Code, http.StatusConflict)
+}
+
+// 토큰 갱신: 접속하지 않은 회의 발언자는 발언자 유지, 스트림 시청자는 시청자, LiveKit 장애는 5xx
+func TestRefreshTokenParticipantLookup(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "my-room"}, {Name: "room-1", Metadata: `{"creator_identity":"host1"}`}},
+	}
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-1", CreatorIdentity: "host1", State: catalog.StateLive}))
+	r := newTokenRefresher(t, service, streams)
+	user := &account.Principal{UserID: "u1", Identity: "user1"}
+
+	// /getToken 회의 참가자 (카탈로그 기록 없음)
+	_, claims := r.refresh(user, r.token(policy.RoleSpeaker, "my-room", "user1"))
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleSpeaker))
+	assert.True(t, claims.Video.GetCanPublish())
+
+	// 스트림 발언자 토큰이라도 무대 속성이 없으면 시청자
+	_, claims = r.refresh(user, r.token(policy.RoleSpeaker, "room-1", "user1"))
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleViewer))
+
+	service.lookupErr = errors.New("livekit unavailable")
+	rec, _ := r.refresh(user, r.token(policy.RoleSpeaker, "my-room", "user1"))
+	assert.Equal(t, rec.Code, http.StatusInternalServerError)
+}
+
+// 토큰 갱신: 정원이 있는 스트림에 접속하지 않은 시청자는 남은 입장 허가 시각까지만 재발급, 허가가 없으면 거절
+func TestRefreshTokenWaitlistAdmission(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1"}`}},
+	}
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-1", CreatorIdentity: "host1", State: catalog.StateLive, MaxViewers: 1}))
+	r := newTokenRefresher(t, service, streams)
+
+	rec, _ := r.refresh(&account.Principal{UserID: "u1", Identity: "viewer1"}, r.token(policy.RoleViewer, "room-1", "viewer1"))
+	assert.Equal(t, rec.Code, http.StatusConflict)
+
+	status, err := r.waiting.Join(ctx, "room-1", "viewer2", 1)
+	assert.NoError(t, err)
+	assert.True(t, status.Admitted)
+	rec, claims := r.refresh(&account.Principal{UserID: "u2", Identity: "viewer2"}, r.token(policy.RoleViewer, "room-1", "viewer2"))
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleViewer))
+	var res handlers.RefreshTokenResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.That(t, !res.ExpiresAt.After(status.AdmittedUntil.Add(time.Second)))
+
+	// 운영자는 정원과 무관
+	rec, _ = r.refresh(&account.Principal{UserID: "u3", Identity: "mod1", Role: string(policy.RoleModerator)}, r.token(policy.RoleViewer, "room-1", "mod1"))
+	assert.Equal(t, rec.Code, http.StatusOK)
+}
+
+// 토큰 갱신: 만료/알 수 없는 키/다른 사용자의 토큰 거부, 역할 재확인 (호스트, 운영자, 공동 진행자, 봇)
+func TestRefreshTokenRoles(t *testing.T) {
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1"}`}},
+	}
+	r := newTokenRefresher(t, service, catalog.NewMemoryRepository())
+	viewer := &account.Principal{UserID: "u1", Identity: "viewer1"}
+
+	// 만료된 토큰 (AccessToken은 지난 만료 시각을 만들 수 없어 직접 서명, 검증 허용 오차 1분보다 이전)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(testTokenSecret)}, (&jose.SignerOptions{}).WithType("JWT"))
+	assert.NoError(t, err)
+	expired, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:    "APIkey",
+		Subject:   "viewer1",
+		NotBefore: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		Expiry:    jwt.NewNumericDate(time.Now().Add(-2 * time.Minute)),
+	}).Claims(auth.ClaimGrants{
+		Identity:   "viewer1",
+		Video:      &auth.VideoGrant{Room: "room-1", RoomJoin: true},
+		Attributes: map[string]string{policy.AttributeRole: string(policy.RoleViewer)},
+	}).CompactSerialize()
+	assert.NoError(t, err)
+	rec, _ := r.refresh(viewer, expired)
+	assert.Equal(t, rec.Code, http.StatusUnauthorized)
+
+	// 키링에 없는 키로 서명된 토큰
+	at, err := r.policies.NewAccessToken("OtherKey", testTokenSecret, policy.RoleViewer, "room-1", "viewer1")
+	assert.NoError(t, err)
+	unknown, err := at.ToJWT()
+	assert.NoError(t, err)
+	rec, _ = r.refresh(viewer, unknown)
+	assert.Equal(t, rec.Code, http.StatusUnauthorized)
+
+	// 다른 사용자의 토큰
+	rec, _ = r.refresh(viewer, r.token(policy.RoleViewer, "room-1", "viewer2"))
+	assert.Equal(t, rec.Code, http.StatusForbidden)
+
+	// 룸 생성자가 아닌 호스트 토큰
+	_, claims := r.refresh(&account.Principal{UserID: "u2", Identity: "host1"}, r.token(policy.RoleHost, "room-1", "host1"))
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleHost))
+	rec, _ = r.refresh(&account.Principal{UserID: "u3", Identity: "host2"}, r.token(policy.RoleHost, "room-1", "host2"))
+	assert.Equal(t, rec.Code, http.StatusForbidden)
+
+	// 계정 역할이 회수된 운영자/공동 진행자는 시청자로 강등
+	for _, role := range []policy.Role{policy.RoleModerator, policy.RoleCoHost} {
+		_, claims = r.refresh(&account.Principal{UserID: "u4", Identity: "staff1", Role: string(role)}, r.token(role, "room-1", "staff1"))
+		assert.Equal(t, claims.Attributes[policy.AttributeRole], string(role))
+		_, claims = r.refresh(&account.Principal{UserID: "u4", Identity: "staff1"}, r.token(role, "room-1", "staff1"))
+		assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleViewer))
+		assert.False(t, claims.Video.RoomAdmin)
+		assert.False(t, claims.Video.GetCanPublish())
+	}
+
+	// 봇 토큰은 갱신 불가
+	rec, _ = r.refresh(&account.Principal{UserID: "u5", Identity: "bot1"}, r.token(policy.RoleBot, "room-1", "bot1"))
+	assert.Equal(t, rec.Code, http.StatusForbidden)
+}
+
+// 토큰 갱신: 운영자/공동 진행자 역할은 세션이 아니라 계정 저장소와 룸의 참가자 속성에서 다시 확인
+func TestRefreshTokenRevalidatesStaffRoles(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1"}`}},
+	}
+	r := newTokenRefresher(t, service, catalog.NewMemoryRepository())
+	user := &account.User{ID: "u1", Username: "staff1", Role: string(policy.RoleModerator)}
+	assert.NoError(t, r.users.Create(ctx, user))
+	moderator := account.PrincipalFromUser(user, account.ProviderLocal)
+
+	_, claims := r.refresh(moderator, r.token(policy.RoleModerator, "room-1", "staff1"))
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleModerator))
+
+	// 세션이 유효해도 계정에서 역할이 회수되면 강등
+	revoked := &account.User{ID: "u2", Username: "staff2"}
+	assert.NoError(t, r.users.Create(ctx, revoked))
+	stale := account.PrincipalFromUser(revoked, account.ProviderLocal)
+	stale.Role = string(policy.RoleModerator)
+	_, claims = r.refresh(stale, r.token(policy.RoleModerator, "room-1", "staff2"))
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleViewer))
+
+	// 룸에서 시청자로 바뀐 공동 진행자는 강등
+	cohost := &account.User{ID: "u3", Username: "staff3", Role: string(policy.RoleCoHost)}
+	assert.NoError(t, r.users.Create(ctx, cohost))
+	principal := account.PrincipalFromUser(cohost, account.ProviderLocal)
+	_, claims = r.refresh(principal, r.token(policy.RoleCoHost, "room-1", "staff3"))
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleCoHost))
+	service.participants = map[string][]*livekit.ParticipantInfo{
+		"room-1": {{Identity: "staff3", Attributes: map[string]string{policy.AttributeRole: string(policy.RoleViewer)}}},
+	}
+	_, claims = r.refresh(principal, r.token(policy.RoleCoHost, "room-1", "staff3"))
+	assert.Equal(t, claims.Attributes[policy.AttributeRole], string(policy.RoleViewer))
+}
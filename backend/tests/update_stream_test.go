@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/authz"
+	"backend/catalog"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 메타데이터 수정: If-Match 필수, 버전 충돌 시 412, 생성자/운영자만 수정, 룸 메타데이터 반영
+func TestUpdateStreamMetadata(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{rooms: []*livekit.Room{{
+		Name: "room-1", Metadata: `{"title":"old","creator_identity":"host1"}`, CreationTime: 1000,
+	}}}
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{
+		RoomId:          "room-1",
+		CreatorIdentity: "host1",
+		Metadata:        map[string]interface{}{"title": "old", "creator_identity": "host1", "category": "talk"},
+		CreatedAt:       time.Unix(1000, 0),
+		State:           catalog.StateLive,
+	}))
+	h := newTestStreamHandler(t, newFakeLiveKit(t, service), streams)
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	token := func(identity string) string {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		return session.Token
+	}
+	update := authz.RequireActor(sessions, authz.NewAdminKeys(nil))(h.UpdateStream)
+	patch := func(identity, ifMatch, body string) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPatch, "/api/streams/room-1", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token(identity))
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("room_id")
+		c.SetParamValues("room-1")
+		if err := update(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	body := `{"metadata":{"title":"new title","category":null,"tags":["music"]}}`
+	assert.Equal(t, patch("host1", "", body).Code, http.StatusPreconditionRequired)
+	assert.Equal(t, patch("viewer", `"0"`, body).Code, http.StatusForbidden)
+	assert.Equal(t, patch("host1", `"0"`, `{"metadata":{"creator_identity":"viewer"}}`).Code, http.StatusBadRequest)
+	assert.Equal(t, patch("host1", `"0"`, `{"metadata":{"title":""}}`).Code, http.StatusBadRequest)
+
+	rec := patch("host1", `"0"`, body)
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.Equal(t, rec.Header().Get("ETag"), `"1"`)
+
+	stored, err := streams.Get(ctx, "room-1")
+	assert.NoError(t, err)
+	assert.Equal(t, stored.MetadataVersion, int64(1))
+	assert.Equal(t, stored.Metadata["title"], "new title")
+	assert.Equal(t, stored.Metadata["creator_identity"], "host1")
+	_, hasCategory := stored.Metadata["category"]
+	assert.False(t, hasCategory)
+
+	var roomMetadata map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(service.rooms[0].Metadata), &roomMetadata))
+	assert.Equal(t, roomMetadata["title"], "new title")
+
+	// 같은 버전으로 다시 수정하면 덮어쓰지 않고 현재 ETag와 함께 412
+	rec = patch("host1", `"0"`, `{"metadata":{"title":"stale"}}`)
+	assert.Equal(t, rec.Code, http.StatusPreconditionFailed)
+	assert.Equal(t, rec.Header().Get("ETag"), `"1"`)
+	stored, err = streams.Get(ctx, "room-1")
+	assert.NoError(t, err)
+	assert.Equal(t, stored.Metadata["title"], "new title")
+}
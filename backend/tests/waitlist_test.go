@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/account"
+	"backend/ban"
+	"backend/catalog"
+	"backend/handlers"
+	"backend/invite"
+	"backend/waitlist"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/livekit"
+	"github.com/zeebo/assert"
+)
+
+// 대기열: 도착 순서대로 허가, 허가된 좌석은 만료 전까지 유지, 만료되면 다음 사람에게
+func TestWaitlistOrder(t *testing.T) {
+	ctx := context.Background()
+	service := waitlist.NewService(waitlist.NewMemoryStore(), waitlist.Config{AdmissionTTL: 50 * time.Millisecond})
+
+	status, err := service.Join(ctx, "room-1", "alice", 1)
+	assert.NoError(t, err)
+	assert.True(t, status.Admitted)
+
+	status, err = service.Join(ctx, "room-1", "bob", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, status, waitlist.Status{Position: 1})
+	status, err = service.Join(ctx, "room-1", "carol", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, status.Position, 2)
+
+	// alice가 접속해 시청자로 집계되면 빈 좌석 없음
+	assert.NoError(t, service.Leave(ctx, "room-1", "alice"))
+	admitted, err := service.Advance(ctx, "room-1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, len(admitted), 0)
+
+	// alice가 나가면 bob 허가, carol은 1번
+	admitted, err = service.Advance(ctx, "room-1", 1)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, admitted, []string{"bob"})
+	status, err = service.Status(ctx, "room-1", "carol")
+	assert.NoError(t, err)
+	assert.Equal(t, status.Position, 1)
+
+	// bob이 허가 후 접속하지 않으면 carol에게 좌석이 넘어감
+	time.Sleep(60 * time.Millisecond)
+	admitted, err = service.Advance(ctx, "room-1", 1)
+	assert.NoError(t, err)
+	assert.DeepEqual(t, admitted, []string{"carol"})
+	status, err = service.Status(ctx, "room-1", "bob")
+	assert.NoError(t, err)
+	assert.False(t, status.Waiting())
+}
+
+// 정원이 찬 룸: wait 없이 참여하면 409, wait=true면 202와 대기 순번
+func TestJoinStreamCapacity(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{
+		rooms: []*livekit.Room{{Name: "room-1", Metadata: `{"creator_identity":"host1"}`, CreationTime: 1000}},
+		participants: map[string][]*livekit.ParticipantInfo{"room-1": {
+			{Identity: "host1 (via OBS)", Kind: livekit.ParticipantInfo_INGRESS},
+			{Identity: "viewer1"},
+		}},
+	}
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{
+		RoomId: "room-1", CreatorIdentity: "host1", CreatedAt: time.Unix(1000, 0), State: catalog.StateLive, MaxViewers: 1,
+	}))
+	h := newTestStreamHandler(t, newFakeLiveKit(t, service), streams)
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	join := account.RequireAuth(sessions)(h.JoinStream)
+	post := func(identity, body string) *httptest.ResponseRecorder {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/join_stream", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if err := join(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	assert.Equal(t, post("viewer2", `{"room_id":"room-1"}`).Code, http.StatusConflict)
+
+	rec := post("viewer2", `{"room_id":"room-1","wait":true}`)
+	assert.Equal(t, rec.Code, http.StatusAccepted)
+	var waiting handlers.WaitlistResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &waiting))
+	assert.Equal(t, waiting.Status, "waiting")
+	assert.Equal(t, waiting.Position, 1)
+
+	// 호스트는 정원과 무관하게 참여
+	assert.Equal(t, post("host1", `{"room_id":"room-1"}`).Code, http.StatusOK)
+}
+
+// 대기열 입장: 대기 중 재시도는 초대 코드를 쓰지 않고, 차단되면 제외, 토큰은 입장 허가 만료까지만 유효
+func TestWaitlistAdmission(t *testing.T) {
+	ctx := context.Background()
+	service := &fakeRoomService{
+		rooms:        []*livekit.Room{{Name: "room-1"}, {Name: "room-2"}},
+		participants: map[string][]*livekit.ParticipantInfo{"room-1": {{Identity: "viewer1"}}, "room-2": {{Identity: "viewer1"}}},
+	}
+	streams := catalog.NewMemoryRepository()
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{
+		RoomId: "room-1", CreatorIdentity: "host1", Metadata: map[string]interface{}{"isPrivate": true}, State: catalog.StateLive, MaxViewers: 1,
+	}))
+	assert.NoError(t, streams.Save(ctx, &catalog.Stream{RoomId: "room-2", CreatorIdentity: "host1", State: catalog.StateLive, MaxViewers: 1}))
+	invites := invite.NewService(invite.NewMemoryStore(), "invite-secret")
+	_, code, err := invites.Mint(ctx, "room-1", "host1", time.Hour, 1)
+	assert.NoError(t, err)
+	bans := ban.NewService(ban.NewMemoryStore())
+	waiting := waitlist.NewService(waitlist.NewMemoryStore(), waitlist.Config{AdmissionTTL: 30 * time.Second})
+	h := newTestStreamHandlerWith(t, newFakeLiveKit(t, service), streams, invites, bans, waiting)
+
+	sessions := account.NewSessionIssuer("test-session-secret-0123456789abcdef", time.Hour)
+	request := func(handler echo.HandlerFunc, method, roomId, identity, body string) *httptest.ResponseRecorder {
+		session, err := sessions.Issue(&account.Principal{UserID: identity, Identity: identity})
+		assert.NoError(t, err)
+		e := echo.New()
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+session.Token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("room_id")
+		c.SetParamValues(roomId)
+		if err := account.RequireAuth(sessions)(handler)(c); err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	// 정원이 찬 동안 다시 시도해도 초대 코드는 그대로
+	body := `{"room_id":"room-1","wait":true,"invite_code":"` + code + `"}`
+	assert.Equal(t, request(h.JoinStream, http.MethodPost, "room-1", "viewer2", body).Code, http.StatusAccepted)
+	assert.Equal(t, request(h.JoinStream, http.MethodPost, "room-1", "viewer2", body).Code, http.StatusAccepted)
+	assert.NoError(t, invites.Check(ctx, code, "room-1"))
+
+	// 비공개 스트림은 허가되어도 토큰 없이 admitted (초대 코드로 join_stream을 다시 호출)
+	_, err = waiting.Advance(ctx, "room-1", 1)
+	assert.NoError(t, err)
+	var res handlers.WaitlistResponse
+	assert.NoError(t, json.Unmarshal(request(h.GetWaitlist, http.MethodGet, "room-1", "viewer2", "").Body.Bytes(), &res))
+	assert.Equal(t, res.Status, "admitted")
+	assert.Nil(t, res.ConnectionDetails)
+
+	// 허가된 토큰은 입장 허가 만료 시각까지만 유효
+	_, err = waiting.Join(ctx, "room-2", "viewer3", 1)
+	assert.NoError(t, err)
+	rec := request(h.GetWaitlist, http.MethodGet, "room-2", "viewer3", "")
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.NotNil(t, res.ConnectionDetails)
+	token, err := jwt.ParseSigned(res.ConnectionDetails.Token)
+	assert.NoError(t, err)
+	var claims jwt.Claims
+	assert.NoError(t, token.UnsafeClaimsWithoutVerification(&claims))
+	assert.True(t, claims.Expiry.Time().Before(time.Now().Add(31*time.Second)))
+
+	// 대기 중에 차단되면 토큰 없이 대기열에서 제외
+	_, err = waiting.Join(ctx, "room-2", "viewer4", 0)
+	assert.NoError(t, err)
+	_, err = bans.Ban(ctx, "room-2", ban.KindIdentity, "viewer4", "spam", "host1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, request(h.GetWaitlist, http.MethodGet, "room-2", "viewer4", "").Code, http.StatusForbidden)
+	status, err := waiting.Status(ctx, "room-2", "viewer4")
+	assert.NoError(t, err)
+	assert.False(t, status.Waiting())
+}
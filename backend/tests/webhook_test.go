@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/events"
+	"backend/handlers"
+	"backend/keyring"
+
+	"github.com/labstack/echo/v4"
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
+	"github.com/zeebo/assert"
+)
+
+func signedWebhook(t *testing.T, key, secret, body string) *http.Request {
+	sum := sha256.Sum256([]byte(body))
+	token, err := auth.NewAccessToken(key, secret).SetSha256(base64.StdEncoding.EncodeToString(sum[:])).ToJWT()
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/livekit", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, "application/webhook+json")
+	req.Header.Set(echo.HeaderAuthorization, token)
+	return req
+}
+
+// 서명 검증, 이벤트 ID 중복 제거, 구독 이벤트 종류별 전달
+func TestWebhookReceiver(t *testing.T) {
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: "secret-secret-secret-secret-secret"})
+	assert.NoError(t, err)
+
+	dispatcher := events.NewDispatcher()
+	var joined, all []string
+	dispatcher.Subscribe("joined", func(ctx context.Context, event *livekit.WebhookEvent) error {
+		joined = append(joined, event.Participant.Identity)
+		return nil
+	}, webhook.EventParticipantJoined)
+	dispatcher.Subscribe("all", func(ctx context.Context, event *livekit.WebhookEvent) error {
+		all = append(all, events.RoomName(event))
+		return nil
+	})
+	h := handlers.NewWebhookHandler(keys, dispatcher, events.NewMemoryDeduper())
+
+	e := echo.New()
+	send := func(req *http.Request) int {
+		rec := httptest.NewRecorder()
+		err := h.ReceiveLiveKit(e.NewContext(req, rec))
+		if he, ok := err.(*echo.HTTPError); ok {
+			return he.Code
+		}
+		assert.NoError(t, err)
+		return rec.Code
+	}
+
+	joinedBody := `{"event":"participant_joined","id":"EV_1","room":{"name":"room-1"},"participant":{"identity":"viewer1"}}`
+	startedBody := `{"event":"room_started","id":"EV_2","room":{"name":"room-1"}}`
+
+	assert.Equal(t, send(signedWebhook(t, "APIkey", "secret-secret-secret-secret-secret", joinedBody)), http.StatusOK)
+	assert.Equal(t, send(signedWebhook(t, "APIkey", "secret-secret-secret-secret-secret", joinedBody)), http.StatusOK) // 재전송
+	assert.Equal(t, send(signedWebhook(t, "APIkey", "secret-secret-secret-secret-secret", startedBody)), http.StatusOK)
+	assert.DeepEqual(t, joined, []string{"viewer1"})
+	assert.DeepEqual(t, all, []string{"room-1", "room-1"})
+
+	// 알 수 없는 키, 잘못된 서명, 본문 변조
+	assert.Equal(t, send(signedWebhook(t, "other", "secret-secret-secret-secret-secret", startedBody)), http.StatusUnauthorized)
+	assert.Equal(t, send(signedWebhook(t, "APIkey", "wrong-secret-wrong-secret-wrong", startedBody)), http.StatusUnauthorized)
+	tampered := signedWebhook(t, "APIkey", "secret-secret-secret-secret-secret", startedBody)
+	tampered.Body = http.NoBody
+	assert.Equal(t, send(tampered), http.StatusUnauthorized)
+	assert.Equal(t, len(all), 2)
+}
+
+// 구독자가 실패하면 5xx로 응답해 재전송 받고, 재전송된 이벤트는 실패한 구독자만 다시 호출
+func TestWebhookRedelivery(t *testing.T) {
+	keys, err := keyring.New("", keyring.KeyPair{Key: "APIkey", Secret: "secret-secret-secret-secret-secret"})
+	assert.NoError(t, err)
+
+	dispatcher := events.NewDispatcher()
+	recorded, attempts := 0, 0
+	dispatcher.Subscribe("recorder", func(ctx context.Context, event *livekit.WebhookEvent) error {
+		recorded++
+		return nil
+	})
+	dispatcher.Subscribe("flaky", func(ctx context.Context, event *livekit.WebhookEvent) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("temporarily unavailable")
+		}
+		return nil
+	})
+	h := handlers.NewWebhookHandler(keys, dispatcher, events.NewMemoryDeduper())
+
+	e := echo.New()
+	send := func() int {
+		req := signedWebhook(t, "APIkey", "secret-secret-secret-secret-secret", `{"event":"room_finished","id":"EV_1","room":{"name":"room-1"}}`)
+		rec := httptest.NewRecorder()
+		if err := h.ReceiveLiveKit(e.NewContext(req, rec)); err != nil {
+			he, ok := err.(*echo.HTTPError)
+			assert.True(t, ok)
+			return he.Code
+		}
+		return rec.Code
+	}
+
+	assert.Equal(t, send(), http.StatusInternalServerError)
+	assert.Equal(t, send(), http.StatusOK) // 재전송: 실패한 구독자만 다시 호출
+	assert.Equal(t, send(), http.StatusOK) // 처리 완료 후 재전송은 건너뜀
+	assert.Equal(t, recorded, 1)
+	assert.Equal(t, attempts, 2)
+}
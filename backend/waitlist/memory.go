@@ -0,0 +1,129 @@
+package waitlist
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryRoom struct {
+	queue    []string             // 도착 순서
+	admitted map[string]time.Time // identity -> 허가 만료 시각
+}
+
+// MemoryStore 인메모리 대기열 (단일 인스턴스/테스트용)
+type MemoryStore struct {
+	mu    sync.Mutex
+	rooms map[string]*memoryRoom
+}
+
+// NewMemoryStore 생성자
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{rooms: map[string]*memoryRoom{}}
+}
+
+func (s *MemoryStore) room(roomId string) *memoryRoom {
+	r, ok := s.rooms[roomId]
+	if !ok {
+		r = &memoryRoom{admitted: map[string]time.Time{}}
+		s.rooms[roomId] = r
+	}
+	return r
+}
+
+func (s *MemoryStore) Enqueue(ctx context.Context, roomId, identity string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.room(roomId)
+	if expiresAt, ok := r.admitted[identity]; ok && expiresAt.After(at) {
+		return nil
+	}
+	for _, queued := range r.queue {
+		if queued == identity {
+			return nil
+		}
+	}
+	r.queue = append(r.queue, identity)
+	return nil
+}
+
+func (s *MemoryStore) Position(ctx context.Context, roomId, identity string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.rooms[roomId]; ok {
+		for i, queued := range r.queue {
+			if queued == identity {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, nil
+}
+
+func (s *MemoryStore) Admit(ctx context.Context, roomId string, seats int, now, expiresAt time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.rooms[roomId]
+	if !ok {
+		return nil, nil
+	}
+	for identity, at := range r.admitted {
+		if !at.After(now) {
+			delete(r.admitted, identity)
+		}
+	}
+	var admitted []string
+	for len(r.admitted) < seats && len(r.queue) > 0 {
+		identity := r.queue[0]
+		r.queue = r.queue[1:]
+		r.admitted[identity] = expiresAt
+		admitted = append(admitted, identity)
+	}
+	return admitted, nil
+}
+
+func (s *MemoryStore) AdmittedUntil(ctx context.Context, roomId, identity string, now time.Time) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.rooms[roomId]
+	if !ok {
+		return time.Time{}, nil
+	}
+	expiresAt, ok := r.admitted[identity]
+	if !ok || !expiresAt.After(now) {
+		return time.Time{}, nil
+	}
+	return expiresAt, nil
+}
+
+func (s *MemoryStore) Remove(ctx context.Context, roomId, identity string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.rooms[roomId]
+	if !ok {
+		return nil
+	}
+	delete(r.admitted, identity)
+	for i, queued := range r.queue {
+		if queued == identity {
+			r.queue = append(r.queue[:i:i], r.queue[i+1:]...)
+			break
+		}
+	}
+	if len(r.queue) == 0 && len(r.admitted) == 0 {
+		delete(s.rooms, roomId)
+	}
+	return nil
+}
+
+func (s *MemoryStore) Clear(ctx context.Context, roomId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rooms, roomId)
+	return nil
+}
@@ -0,0 +1,113 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 마지막 변경 후 대기열을 유지하는 기간 (종료 이벤트를 놓친 룸 정리)
+const roomRetention = 24 * time.Hour
+
+// admitScript 만료된 허가 정리와 대기열 앞에서부터 허가를 원자적으로 수행
+//
+//	KEYS[1]  waitlist:<room>:queue     sorted set (score = 도착 시각 ms)
+//	KEYS[2]  waitlist:<room>:admitted  sorted set (score = 허가 만료 시각 ms)
+//	ARGV     seats, now(ms), expiresAt(ms), retention(ms)
+//	반환     새로 허가된 identity 목록
+var admitScript = redis.NewScript(`
+local seats = tonumber(ARGV[1])
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', ARGV[2])
+
+local admitted = {}
+local free = seats - redis.call('ZCARD', KEYS[2])
+while free > 0 do
+  local head = redis.call('ZPOPMIN', KEYS[1])
+  if #head == 0 then
+    break
+  end
+  redis.call('ZADD', KEYS[2], ARGV[3], head[1])
+  table.insert(admitted, head[1])
+  free = free - 1
+end
+if #admitted > 0 then
+  redis.call('PEXPIRE', KEYS[2], ARGV[4])
+end
+return admitted
+`)
+
+// RedisStore Redis 기반 대기열 (여러 백엔드 인스턴스가 같은 순서 공유)
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 생성자
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+func queueKey(roomId string) string {
+	return "waitlist:" + roomId + ":queue"
+}
+
+func admittedKey(roomId string) string {
+	return "waitlist:" + roomId + ":admitted"
+}
+
+func (s *RedisStore) Enqueue(ctx context.Context, roomId, identity string, at time.Time) error {
+	until, err := s.AdmittedUntil(ctx, roomId, identity, at)
+	if err != nil || !until.IsZero() {
+		return err
+	}
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAddNX(ctx, queueKey(roomId), redis.Z{Score: float64(at.UnixMilli()), Member: identity})
+		pipe.Expire(ctx, queueKey(roomId), roomRetention)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) Position(ctx context.Context, roomId, identity string) (int, error) {
+	rank, err := s.rdb.ZRank(ctx, queueKey(roomId), identity).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int(rank) + 1, nil
+}
+
+func (s *RedisStore) Admit(ctx context.Context, roomId string, seats int, now, expiresAt time.Time) ([]string, error) {
+	return admitScript.Run(ctx, s.rdb, []string{queueKey(roomId), admittedKey(roomId)},
+		seats, now.UnixMilli(), expiresAt.UnixMilli(), roomRetention.Milliseconds()).StringSlice()
+}
+
+func (s *RedisStore) AdmittedUntil(ctx context.Context, roomId, identity string, now time.Time) (time.Time, error) {
+	expiresAt, err := s.rdb.ZScore(ctx, admittedKey(roomId), identity).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if int64(expiresAt) <= now.UnixMilli() {
+		return time.Time{}, nil
+	}
+	return time.UnixMilli(int64(expiresAt)), nil
+}
+
+func (s *RedisStore) Remove(ctx context.Context, roomId, identity string) error {
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, queueKey(roomId), identity)
+		pipe.ZRem(ctx, admittedKey(roomId), identity)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) Clear(ctx context.Context, roomId string) error {
+	return s.rdb.Del(ctx, queueKey(roomId), admittedKey(roomId)).Err()
+}
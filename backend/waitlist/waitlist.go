@@ -0,0 +1,102 @@
+package waitlist
+
+import (
+	"context"
+	"time"
+)
+
+// Store 룸별 대기열과 입장 허가(좌석 예약) 저장소
+// 대기열은 도착 순서(FIFO)이며, 입장 허가는 만료 시각까지 좌석을 잡아 둔다.
+type Store interface {
+	// Enqueue 대기열 끝에 추가 (이미 대기 중이거나 허가된 사용자는 그대로)
+	Enqueue(ctx context.Context, roomId, identity string, at time.Time) error
+	// Position 1부터 시작하는 대기 순번 (대기열에 없으면 0)
+	Position(ctx context.Context, roomId, identity string) (int, error)
+	// Admit 만료된 허가를 정리한 뒤, 허가 수가 seats보다 적은 동안 대기열 앞에서부터 허가
+	// 새로 허가된 사용자 반환
+	Admit(ctx context.Context, roomId string, seats int, now, expiresAt time.Time) ([]string, error)
+	// AdmittedUntil 만료되지 않은 입장 허가의 만료 시각 (허가가 없으면 zero)
+	AdmittedUntil(ctx context.Context, roomId, identity string, now time.Time) (time.Time, error)
+	// Remove 대기열과 입장 허가에서 제거 (입장 완료, 대기 포기)
+	Remove(ctx context.Context, roomId, identity string) error
+	// Clear 룸의 대기열과 허가 전체 삭제 (스트림 종료)
+	Clear(ctx context.Context, roomId string) error
+}
+
+// Config 대기열 설정
+type Config struct {
+	AdmissionTTL time.Duration // 입장 허가 후 접속까지 좌석을 잡아 두는 시간
+}
+
+// DefaultConfig 허가 후 1분 안에 접속하지 않으면 다음 사람에게 좌석을 넘김
+func DefaultConfig() Config {
+	return Config{AdmissionTTL: time.Minute}
+}
+
+// Status 대기 상태
+type Status struct {
+	Position      int       // 1부터, 대기 중이 아니면 0
+	Admitted      bool      // 입장 허가됨 (토큰 발급 가능)
+	AdmittedUntil time.Time // 입장 허가 만료 시각 (발급하는 토큰도 이때까지만 유효)
+}
+
+// Waiting 대기열이나 허가 목록에 있는지
+func (s Status) Waiting() bool {
+	return s.Position > 0 || s.Admitted
+}
+
+// Service 정원이 있는 룸의 시청자 대기열
+type Service struct {
+	store Store
+	cfg   Config
+}
+
+// NewService 생성자
+func NewService(store Store, cfg Config) *Service {
+	return &Service{store: store, cfg: cfg}
+}
+
+// Join 대기열에 넣고 빈 좌석(seats = 정원 - 현재 시청자)만큼 앞에서부터 허가한 뒤 상태 반환
+// 먼저 기다리던 사용자가 있으면 좌석이 비어 있어도 그 뒤에 선다.
+func (s *Service) Join(ctx context.Context, roomId, identity string, seats int) (Status, error) {
+	now := time.Now()
+	if err := s.store.Enqueue(ctx, roomId, identity, now); err != nil {
+		return Status{}, err
+	}
+	if _, err := s.Advance(ctx, roomId, seats); err != nil {
+		return Status{}, err
+	}
+	return s.Status(ctx, roomId, identity)
+}
+
+// Advance 빈 좌석만큼 대기열 앞에서부터 입장 허가
+func (s *Service) Advance(ctx context.Context, roomId string, seats int) ([]string, error) {
+	now := time.Now()
+	return s.store.Admit(ctx, roomId, seats, now, now.Add(s.cfg.AdmissionTTL))
+}
+
+// Status 대기 순번과 입장 허가 여부
+func (s *Service) Status(ctx context.Context, roomId, identity string) (Status, error) {
+	until, err := s.store.AdmittedUntil(ctx, roomId, identity, time.Now())
+	if err != nil {
+		return Status{}, err
+	}
+	if !until.IsZero() {
+		return Status{Admitted: true, AdmittedUntil: until}, nil
+	}
+	position, err := s.store.Position(ctx, roomId, identity)
+	if err != nil {
+		return Status{}, err
+	}
+	return Status{Position: position}, nil
+}
+
+// Leave 대기 포기 또는 입장 완료 (허가된 좌석은 LiveKit 참가자 수로 넘어감)
+func (s *Service) Leave(ctx context.Context, roomId, identity string) error {
+	return s.store.Remove(ctx, roomId, identity)
+}
+
+// Clear 종료된 룸의 대기열 삭제
+func (s *Service) Clear(ctx context.Context, roomId string) error {
+	return s.store.Clear(ctx, roomId)
+}